@@ -0,0 +1,102 @@
+package calculations
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// Конвенции начисления процентов за месяц.
+const (
+	DayCountFlat   = "flat"          // годовая ставка / 12, по умолчанию
+	DayCountActual = "actual_actual" // фактические дни месяца к 365/366
+)
+
+// Accruer — общий механизм помесячного начисления процентов для кредитных
+// и депозитных калькуляторов: единая точка для месячной ставки, денежного
+// округления и контроля остатка. Новые конвенции начисления (дневные базы,
+// переменные ставки) подключаются здесь, а не в каждом калькуляторе.
+type Accruer struct {
+	cfg           Config
+	monthly       float64
+	annualPercent float64
+	periods       []RatePeriod
+	dayCount      string
+	start         time.Time
+}
+
+// newAccruer создает механизм начисления с постоянной годовой ставкой.
+func newAccruer(cfg Config, annualRatePercent float64) Accruer {
+	return Accruer{cfg: cfg, monthly: MonthlyRate(annualRatePercent), annualPercent: annualRatePercent}
+}
+
+// newVariableAccruer создает механизм с графиком ставок по месяцам.
+func newVariableAccruer(cfg Config, periods []RatePeriod) Accruer {
+	return Accruer{cfg: cfg, periods: periods}
+}
+
+// newActualDaysAccruer создает механизм actual/actual: проценты месяца m
+// начисляются за фактические дни периода от start+(m-1) до start+m месяцев
+// к числу дней в году начала периода (365 или 366).
+func newActualDaysAccruer(cfg Config, annualRatePercent float64, start time.Time) Accruer {
+	return Accruer{cfg: cfg, annualPercent: annualRatePercent, dayCount: DayCountActual, start: start}
+}
+
+// newDailyCompoundAccruer создает механизм дневной капитализации: дневная
+// ставка (годовая к 365/366) капитализируется за фактические дни платежного
+// периода месяца m, что дает чуть большую эффективную месячную ставку,
+// чем простое начисление за те же дни.
+func newDailyCompoundAccruer(cfg Config, annualRatePercent float64, start time.Time) Accruer {
+	return Accruer{cfg: cfg, annualPercent: annualRatePercent, dayCount: CompoundingDaily, start: start}
+}
+
+// Rate возвращает ставку за месяц m согласно конвенции начисления.
+func (a Accruer) Rate(m int) float64 {
+	switch {
+	case len(a.periods) > 0:
+		return MonthlyRate(annualRateForMonth(a.periods, m))
+	case a.dayCount == DayCountActual:
+		day := a.start.Day()
+		periodStart := dueDate(a.start, m-1, day)
+		periodEnd := dueDate(a.start, m, day)
+		days := periodEnd.Sub(periodStart).Hours() / 24
+		return a.annualPercent / 100 * days / float64(daysInYear(periodStart.Year()))
+	case a.dayCount == CompoundingDaily:
+		day := a.start.Day()
+		periodStart := dueDate(a.start, m-1, day)
+		periodEnd := dueDate(a.start, m, day)
+		days := periodEnd.Sub(periodStart).Hours() / 24
+		daily := a.annualPercent / 100 / float64(daysInYear(periodStart.Year()))
+		return math.Pow(1+daily, days) - 1
+	}
+	return a.monthly
+}
+
+// Interest начисляет проценты за месяц m на базу base с денежным округлением.
+func (a Accruer) Interest(m int, base float64) float64 {
+	return Round(base*a.Rate(m), a.cfg.Precision)
+}
+
+// ReduceDebt списывает из остатка долга долю principalPart; остаток ниже
+// допуска округления — ошибка, мелкий отрицательный хвост обнуляется.
+func (a Accruer) ReduceDebt(m int, balance, principalPart float64) (float64, error) {
+	balance = Round(balance-principalPart, a.cfg.Precision)
+	if balance < -a.cfg.NegativeBalanceTolerance() {
+		return 0, fmt.Errorf("отрицательный остаток долга в месяце %d", m)
+	}
+	if balance < 0 {
+		balance = 0
+	}
+	return balance, nil
+}
+
+// CheckBalanceCap проверяет, что накопительный баланс не превысил предел.
+// operation называет шаг, на котором произошло превышение (пополнение,
+// начисление процентов) — проверка вызывается после каждой мутации
+// баланса, чтобы диагностика указывала на конкретную операцию.
+func (a Accruer) CheckBalanceCap(m int, balance float64, operation string) error {
+	if balance > a.cfg.MaxBalance {
+		return fmt.Errorf("баланс превысил допустимый максимум в месяце %d: %s", m, operation)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package calculations
+
+import "testing"
+
+// Регрессию поведения калькуляторов после выноса начисления в Accruer
+// держат существующие тесты графиков; здесь проверяется сам механизм.
+
+func TestAccruerConstantRate(t *testing.T) {
+	cfg := DefaultConfig()
+	acc := newAccruer(cfg, 12)
+	for _, m := range []int{1, 7, 360} {
+		inDelta(t, acc.Rate(m), 0.01, 1e-12)
+	}
+	inDelta(t, acc.Interest(1, 1_000_000), Round(1_000_000*MonthlyRate(12), cfg.Precision), 1e-9)
+}
+
+func TestAccruerVariableRate(t *testing.T) {
+	cfg := DefaultConfig()
+	acc := newVariableAccruer(cfg, []RatePeriod{
+		{FromMonth: 1, AnnualRatePercent: 10},
+		{FromMonth: 7, AnnualRatePercent: 5},
+	})
+	inDelta(t, acc.Rate(6), MonthlyRate(10), 1e-12)
+	inDelta(t, acc.Rate(7), MonthlyRate(5), 1e-12)
+}
+
+func TestAccruerReduceDebtGuard(t *testing.T) {
+	cfg := DefaultConfig()
+	acc := newAccruer(cfg, 12)
+	// Мелкий отрицательный хвост округления обнуляется.
+	balance, err := acc.ReduceDebt(1, 100, 100.004)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if balance != 0 {
+		t.Fatalf("хвост округления должен обнуляться, получено %v", balance)
+	}
+	// Существенный уход в минус — ошибка.
+	if _, err := acc.ReduceDebt(1, 100, 200); err == nil {
+		t.Fatal("ожидалась ошибка отрицательного остатка")
+	}
+}
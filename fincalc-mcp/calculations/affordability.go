@@ -0,0 +1,98 @@
+package calculations
+
+import "fmt"
+
+// MaxHomePriceResult — ответ на вопрос «какое жилье мне по карману»:
+// бюджет платежа из дохода и лимита DTI, максимальное тело кредита
+// и итоговая цена с учетом первоначального взноса.
+type MaxHomePriceResult struct {
+	MonthlyIncome   float64 `json:"monthly_income"`
+	DTILimitPercent float64 `json:"dti_limit_percent"`
+	// PaymentBudget — часть дохода, доступная на платеж по ипотеке
+	// после вычета налогов и страховки.
+	PaymentBudget float64 `json:"payment_budget"`
+	MaxPrincipal  float64 `json:"max_principal"`
+	DownPayment   float64 `json:"down_payment"`
+	MaxHomePrice  float64 `json:"max_home_price"`
+}
+
+// MaxHomePrice выводит максимальную цену жилья из дохода: лимит DTI дает
+// бюджет платежа, вычитаются налоги и страховка, остаток обращается в
+// максимальное тело кредита через MaxPrincipalForPayment, сверху
+// прибавляется первоначальный взнос.
+func MaxHomePrice(cfg Config, monthlyIncome, dtiLimitPercent, annualRatePercent float64, months int, downPayment, taxesInsurance float64) (*MaxHomePriceResult, error) {
+	if monthlyIncome <= 0 || monthlyIncome > cfg.MaxPrincipal {
+		return nil, &ValidationError{
+			Field:   "monthly_income",
+			Value:   monthlyIncome,
+			Min:     0,
+			Max:     cfg.MaxPrincipal,
+			Message: fmt.Sprintf("доход должен быть больше 0 и не более %.0f", cfg.MaxPrincipal),
+		}
+	}
+	if dtiLimitPercent <= 0 || dtiLimitPercent > 100 {
+		return nil, errOutOfRange("dti_limit_percent", 1, 100)
+	}
+	if err := CheckRate(cfg, annualRatePercent); err != nil {
+		return nil, err
+	}
+	if err := CheckMonths(cfg, months); err != nil {
+		return nil, err
+	}
+	if err := CheckAmount(cfg, downPayment); err != nil {
+		return nil, err
+	}
+	if err := CheckAmount(cfg, taxesInsurance); err != nil {
+		return nil, err
+	}
+	budget := monthlyIncome*dtiLimitPercent/100 - taxesInsurance
+	if budget <= 0 {
+		return nil, fmt.Errorf("налоги и страховка %.2f съедают весь бюджет платежа", taxesInsurance)
+	}
+	principal := MaxPrincipalForPayment(budget, annualRatePercent, months)
+	if principal > cfg.MaxPrincipal {
+		principal = cfg.MaxPrincipal
+	}
+	return &MaxHomePriceResult{
+		MonthlyIncome:   monthlyIncome,
+		DTILimitPercent: dtiLimitPercent,
+		PaymentBudget:   Round2(budget),
+		MaxPrincipal:    Round2(principal),
+		DownPayment:     downPayment,
+		MaxHomePrice:    Round2(principal + downPayment),
+	}, nil
+}
+
+// MinIncomeResult — зеркальный ответ со стороны кредитора: какой валовый
+// доход нужен, чтобы желаемый кредит прошел по лимиту DTI.
+type MinIncomeResult struct {
+	MonthlyPayment  float64 `json:"monthly_payment"`
+	TaxesInsurance  float64 `json:"taxes_insurance,omitempty"`
+	DTILimitPercent float64 `json:"dti_limit_percent"`
+	// RequiredIncome — минимальный месячный доход: (платеж + налоги и
+	// страховка) / лимит DTI.
+	RequiredIncome float64 `json:"required_income"`
+}
+
+// MinIncomeRequired решает обратную к MaxHomePrice задачу: по желаемым
+// параметрам кредита и лимиту DTI выводит минимальный валовый месячный
+// доход для предквалификации. Платеж берется из аннуитетного графика,
+// сверху добавляются налоги и страховка.
+func MinIncomeRequired(cfg Config, p LoanParams, dtiLimitPercent, taxesInsurance float64) (*MinIncomeResult, error) {
+	if dtiLimitPercent <= 0 || dtiLimitPercent > 100 {
+		return nil, errOutOfRange("dti_limit_percent", 1, 100)
+	}
+	if err := CheckAmount(cfg, taxesInsurance); err != nil {
+		return nil, err
+	}
+	res, err := AnnuitySchedule(cfg, p)
+	if err != nil {
+		return nil, err
+	}
+	return &MinIncomeResult{
+		MonthlyPayment:  res.MonthlyPayment,
+		TaxesInsurance:  taxesInsurance,
+		DTILimitPercent: dtiLimitPercent,
+		RequiredIncome:  Round2((res.MonthlyPayment + taxesInsurance) / (dtiLimitPercent / 100)),
+	}, nil
+}
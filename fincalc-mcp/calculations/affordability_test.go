@@ -0,0 +1,58 @@
+package calculations
+
+import "testing"
+
+func TestMaxHomePriceChain(t *testing.T) {
+	cfg := DefaultConfig()
+	// Доход 200 000, лимит DTI 40% (бюджет 80 000), минус 5 000 налогов
+	// и страховки — на платеж остается 75 000.
+	res, err := MaxHomePrice(cfg, 200_000, 40, 12, 240, 2_000_000, 5_000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	inDelta(t, res.PaymentBudget, 75_000, 0.01)
+	// Сверка цепочки: платеж на найденное тело возвращает бюджет.
+	inDelta(t, AnnuityPayment(res.MaxPrincipal, 12, 240), 75_000, 1)
+	inDelta(t, res.MaxHomePrice, res.MaxPrincipal+2_000_000, 0.01)
+}
+
+func TestMaxHomePriceValidation(t *testing.T) {
+	cfg := DefaultConfig()
+	if _, err := MaxHomePrice(cfg, 0, 40, 12, 240, 0, 0); err == nil {
+		t.Fatal("ожидалась ошибка для нулевого дохода")
+	}
+	if _, err := MaxHomePrice(cfg, 200_000, 120, 12, 240, 0, 0); err == nil {
+		t.Fatal("ожидалась ошибка для лимита DTI вне диапазона")
+	}
+	if _, err := MaxHomePrice(cfg, 100_000, 40, 12, 240, 0, 50_000); err == nil {
+		t.Fatal("ожидалась ошибка: налоги съедают весь бюджет")
+	}
+}
+
+func TestMinIncomeRequired(t *testing.T) {
+	cfg := DefaultConfig()
+	p := LoanParams{Principal: 3_000_000, AnnualRatePercent: 12, Months: 240}
+	res, err := MinIncomeRequired(cfg, p, 40, 5_000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Сверка цепочки: платеж из графика плюс налоги делится на лимит DTI.
+	payment := Round2(AnnuityPayment(3_000_000, 12, 240))
+	inDelta(t, res.MonthlyPayment, payment, 0.01)
+	inDelta(t, res.RequiredIncome, (payment+5_000)/0.4, 0.01)
+	// С таким доходом прямая проверка DTI проходит впритык.
+	dti, err := DebtToIncome(cfg, res.RequiredIncome, []float64{payment + 5_000}, 40)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dti.ExceedsThreshold {
+		t.Fatal("минимальный доход должен проходить по лимиту DTI")
+	}
+
+	if _, err := MinIncomeRequired(cfg, p, 0, 0); err == nil {
+		t.Fatal("ожидалась ошибка для нулевого лимита DTI")
+	}
+	if _, err := MinIncomeRequired(cfg, p, 40, -1); err == nil {
+		t.Fatal("ожидалась ошибка для отрицательных налогов")
+	}
+}
@@ -0,0 +1,96 @@
+package calculations
+
+import "fmt"
+
+// AllocationOption — итог одного варианта распределения месячного
+// профицита между досрочным погашением кредита и инвестициями.
+type AllocationOption struct {
+	// OverpaymentSharePercent — доля профицита, направляемая на досрочное
+	// погашение; остаток инвестируется.
+	OverpaymentSharePercent float64 `json:"overpayment_share_percent"`
+	Investments             float64 `json:"investments"`
+	RemainingDebt           float64 `json:"remaining_debt"`
+	// NetWorth — инвестиции минус остаток долга на горизонте.
+	NetWorth float64 `json:"net_worth"`
+}
+
+// OptimalAllocation перебирает распределения месячного профицита между
+// досрочным погашением аннуитетного кредита и инвестициями (от 0 до 100%
+// на погашение с шагом stepPercent) и возвращает вариант с максимальным
+// чистым состоянием на горизонте: инвестиции минус остаток долга. После
+// полного погашения кредита освободившийся платеж и доля досрочного
+// направляются в инвестиции.
+func OptimalAllocation(cfg Config, p LoanParams, monthlySurplus, investmentRatePercent float64, horizonMonths, stepPercent int) (map[string]any, error) {
+	if err := CheckPrincipal(cfg, p.Principal); err != nil {
+		return nil, err
+	}
+	if err := CheckRate(cfg, p.AnnualRatePercent); err != nil {
+		return nil, err
+	}
+	if err := CheckMonths(cfg, p.Months); err != nil {
+		return nil, err
+	}
+	if monthlySurplus <= 0 || monthlySurplus > cfg.MaxPrincipal {
+		return nil, fmt.Errorf("месячный профицит должен быть положительным и не более %.0f", cfg.MaxPrincipal)
+	}
+	if err := CheckRate(cfg, investmentRatePercent); err != nil {
+		return nil, err
+	}
+	if err := CheckMonths(cfg, horizonMonths); err != nil {
+		return nil, err
+	}
+	if stepPercent < 1 || stepPercent > 100 {
+		return nil, errOutOfRange("step_percent", 1, 100)
+	}
+	if err := cfg.CheckExpansion(100/stepPercent + 1); err != nil {
+		return nil, err
+	}
+
+	payment := AnnuityPayment(p.Principal, p.AnnualRatePercent, p.Months)
+	loanRate := MonthlyRate(p.AnnualRatePercent)
+	investRate := MonthlyRate(investmentRatePercent)
+	options := make([]AllocationOption, 0, 100/stepPercent+1)
+	var best AllocationOption
+	for share := 0; share <= 100; share += stepPercent {
+		balance := p.Principal
+		invest := 0.0
+		for m := 1; m <= horizonMonths; m++ {
+			invest *= 1 + investRate
+			extra := monthlySurplus * float64(share) / 100
+			toInvest := monthlySurplus - extra
+			if balance > 0 {
+				interest := balance * loanRate
+				due := payment
+				if balance+interest < due {
+					due = balance + interest
+				}
+				balance += interest - due
+				if extra >= balance {
+					toInvest += extra - balance
+					balance = 0
+				} else {
+					balance -= extra
+				}
+			} else {
+				// Кредит погашен: освободившийся платеж тоже инвестируется.
+				toInvest += extra + payment
+			}
+			invest += toInvest
+		}
+		option := AllocationOption{
+			OverpaymentSharePercent: float64(share),
+			Investments:             Round2(invest),
+			RemainingDebt:           Round2(balance),
+			NetWorth:                Round2(invest - balance),
+		}
+		options = append(options, option)
+		if len(options) == 1 || option.NetWorth > best.NetWorth {
+			best = option
+		}
+	}
+	return map[string]any{
+		"monthly_payment": Round2(payment),
+		"options":         options,
+		"best":            best,
+	}, nil
+}
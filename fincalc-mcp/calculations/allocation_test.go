@@ -0,0 +1,35 @@
+package calculations
+
+import "testing"
+
+func TestOptimalAllocation(t *testing.T) {
+	cfg := DefaultConfig()
+	loan := LoanParams{Principal: 1_000_000, AnnualRatePercent: 20, Months: 120}
+	// Дорогой кредит при скромной доходности — весь профицит в погашение.
+	out, err := OptimalAllocation(cfg, loan, 20_000, 5, 120, 25)
+	if err != nil {
+		t.Fatal(err)
+	}
+	best := out["best"].(AllocationOption)
+	if best.OverpaymentSharePercent != 100 {
+		t.Fatalf("при дорогом кредите лучшая доля погашения 100%%, получено %v", best.OverpaymentSharePercent)
+	}
+	if len(out["options"].([]AllocationOption)) != 5 {
+		t.Fatalf("ожидалось 5 вариантов, получено %d", len(out["options"].([]AllocationOption)))
+	}
+
+	// Дешевый кредит при высокой доходности — выгоднее инвестировать.
+	cheap := LoanParams{Principal: 1_000_000, AnnualRatePercent: 5, Months: 120}
+	out, err = OptimalAllocation(cfg, cheap, 20_000, 20, 120, 25)
+	if err != nil {
+		t.Fatal(err)
+	}
+	best = out["best"].(AllocationOption)
+	if best.OverpaymentSharePercent != 0 {
+		t.Fatalf("при высокой доходности лучшая доля погашения 0%%, получено %v", best.OverpaymentSharePercent)
+	}
+
+	if _, err := OptimalAllocation(cfg, loan, 20_000, 5, 120, 0); err == nil {
+		t.Fatal("ожидалась ошибка для нулевого шага")
+	}
+}
@@ -0,0 +1,90 @@
+package calculations
+
+import "math"
+
+// AnnuityPayment возвращает размер ежемесячного аннуитетного платежа.
+func AnnuityPayment(principal, annualRatePercent float64, months int) float64 {
+	r := MonthlyRate(annualRatePercent)
+	if r == 0 {
+		return principal / float64(months)
+	}
+	return principal * r / (1 - math.Pow(1+r, -float64(months)))
+}
+
+// LoanConstant возвращает кредитную константу — отношение годового
+// обслуживания долга (12 аннуитетных платежей) к телу кредита, в процентах.
+// Стандартная метрика коммерческой недвижимости; при нулевой ставке
+// вырождается в 1200/срок.
+func LoanConstant(principal, annualRatePercent float64, months int) float64 {
+	payment := AnnuityPayment(principal, annualRatePercent, months)
+	return payment * 12 / principal * 100
+}
+
+// MaxPrincipalForPayment решает обратную к AnnuityPayment задачу:
+// максимальное тело кредита, которое обслуживается заданным платежом
+// на заданных ставке и сроке.
+func MaxPrincipalForPayment(payment, annualRatePercent float64, months int) float64 {
+	r := MonthlyRate(annualRatePercent)
+	if r == 0 {
+		return payment * float64(months)
+	}
+	return payment * (1 - math.Pow(1+r, -float64(months))) / r
+}
+
+// AnnuitySchedule строит помесячный график аннуитетного кредита.
+func AnnuitySchedule(cfg Config, p LoanParams) (*CalculationResult, error) {
+	if err := p.validate(cfg); err != nil {
+		return nil, err
+	}
+	acc := newAccruer(cfg, p.AnnualRatePercent)
+	payment := AnnuityPayment(p.Principal, p.AnnualRatePercent, p.Months)
+	res := &CalculationResult{
+		Kind:              KindAnnuity,
+		Principal:         p.Principal,
+		AnnualRatePercent: p.AnnualRatePercent,
+		Months:            p.Months,
+		MonthlyPayment:    Round(payment, cfg.Precision),
+		Schedule:          make([]ScheduleEntry, 0, p.Months),
+	}
+	balance := p.Principal
+	for m := 1; m <= p.Months; m++ {
+		interest := acc.Interest(m, balance)
+		principalPart := Round(payment-interest, cfg.Precision)
+		// Последний платеж гасит остаток целиком, закрывая накопленную
+		// ошибку округления.
+		if m == p.Months {
+			principalPart = Round(balance, cfg.Precision)
+		}
+		var err error
+		if balance, err = acc.ReduceDebt(m, balance, principalPart); err != nil {
+			return nil, err
+		}
+		pay := Round(principalPart+interest, cfg.Precision)
+		res.Schedule = append(res.Schedule, ScheduleEntry{
+			Month:              m,
+			Payment:            pay,
+			PrincipalComponent: principalPart,
+			Interest:           interest,
+			RemainingPrincipal: balance,
+		})
+		res.TotalPayment += pay
+		res.TotalInterest += interest
+	}
+	res.TotalPayment = Round(res.TotalPayment, cfg.Precision)
+	res.TotalInterest = Round(res.TotalInterest, cfg.Precision)
+	res.CrossoverMonth = CrossoverMonth(res.Schedule)
+	fillPaymentShares(cfg, res.Schedule)
+	return res, nil
+}
+
+// CrossoverMonth возвращает первый месяц, в котором погашение основного
+// долга в платеже превышает проценты, или -1, если такого месяца нет
+// (очень высокая ставка).
+func CrossoverMonth(schedule []ScheduleEntry) int {
+	for _, entry := range schedule {
+		if entry.PrincipalComponent > entry.Interest {
+			return entry.Month
+		}
+	}
+	return -1
+}
@@ -0,0 +1,161 @@
+package calculations
+
+import "testing"
+
+func TestAnnuitySchedule(t *testing.T) {
+	cfg := DefaultConfig()
+	res, err := AnnuitySchedule(cfg, LoanParams{Principal: 1_000_000, AnnualRatePercent: 12, Months: 12})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Классическое контрольное значение: 1 млн под 12% на год.
+	inDelta(t, res.MonthlyPayment, 88848.79, 0.01)
+	if got := len(res.Schedule); got != 12 {
+		t.Fatalf("ожидалось 12 строк графика, получено %d", got)
+	}
+	last := res.Schedule[len(res.Schedule)-1]
+	inDelta(t, last.RemainingPrincipal, 0, 0.01)
+	inDelta(t, res.TotalPayment, res.TotalInterest+res.Principal, 0.05)
+}
+
+func TestAnnuityScheduleZeroRate(t *testing.T) {
+	cfg := DefaultConfig()
+	res, err := AnnuitySchedule(cfg, LoanParams{Principal: 120_000, AnnualRatePercent: 0, Months: 12})
+	if err != nil {
+		t.Fatal(err)
+	}
+	inDelta(t, res.MonthlyPayment, 10_000, 1e-9)
+	inDelta(t, res.TotalInterest, 0, 1e-9)
+}
+
+func TestDifferentialScheduleTotals(t *testing.T) {
+	cfg := DefaultConfig()
+	res, err := DifferentialSchedule(cfg, LoanParams{Principal: 1_000_000, AnnualRatePercent: 12, Months: 12})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.FirstPayment <= res.LastPayment {
+		t.Fatalf("первый платеж %v должен превышать последний %v", res.FirstPayment, res.LastPayment)
+	}
+	inDelta(t, res.Schedule[len(res.Schedule)-1].RemainingPrincipal, 0, 0.01)
+}
+
+func TestNegativeBalanceToleranceFollowsPrecision(t *testing.T) {
+	cfg := DefaultConfig()
+	inDelta(t, cfg.NegativeBalanceTolerance(), 0.005, 1e-12)
+	cfg.Precision = 6
+	inDelta(t, cfg.NegativeBalanceTolerance(), 5e-7, 1e-15)
+}
+
+func TestAnnuityScheduleHighPrecision(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Precision = 6
+	res, err := AnnuitySchedule(cfg, LoanParams{Principal: 1_000_000, AnnualRatePercent: 12.345, Months: 120})
+	if err != nil {
+		t.Fatal(err)
+	}
+	inDelta(t, res.Schedule[len(res.Schedule)-1].RemainingPrincipal, 0, 1e-6)
+}
+
+func TestAnnuityScheduleWholeUnitPrecision(t *testing.T) {
+	// При округлении до целых ошибка округления на порядок больше
+	// прежнего фиксированного допуска -0.01 — теперь допуск выводится
+	// из точности и ложных ошибок нет.
+	cfg := DefaultConfig()
+	cfg.Precision = 0
+	res, err := AnnuitySchedule(cfg, LoanParams{Principal: 100_000, AnnualRatePercent: 12, Months: 36})
+	if err != nil {
+		t.Fatal(err)
+	}
+	inDelta(t, res.Schedule[len(res.Schedule)-1].RemainingPrincipal, 0, 0.5)
+}
+
+func TestCrossoverMonth(t *testing.T) {
+	cfg := DefaultConfig()
+	// 1 млн под 12% на год: уже первый платеж гасит долг быстрее процентов.
+	res, err := AnnuitySchedule(cfg, LoanParams{Principal: 1_000_000, AnnualRatePercent: 12, Months: 12})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.CrossoverMonth != 1 {
+		t.Fatalf("ожидался месяц 1, получен %d", res.CrossoverMonth)
+	}
+	// Длинная ипотека под высокую ставку: перелом наступает ближе к концу.
+	res, err = AnnuitySchedule(cfg, LoanParams{Principal: 5_000_000, AnnualRatePercent: 15, Months: 240})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.CrossoverMonth <= 1 || res.CrossoverMonth > 240 {
+		t.Fatalf("неожиданный месяц перелома %d", res.CrossoverMonth)
+	}
+	entry := res.Schedule[res.CrossoverMonth-1]
+	if entry.PrincipalComponent <= entry.Interest {
+		t.Fatalf("в месяце %d основной долг %v не превышает проценты %v", res.CrossoverMonth, entry.PrincipalComponent, entry.Interest)
+	}
+	if res.CrossoverMonth > 1 {
+		prev := res.Schedule[res.CrossoverMonth-2]
+		if prev.PrincipalComponent > prev.Interest {
+			t.Fatalf("месяц %d не первый: переломом был уже %d", res.CrossoverMonth, prev.Month)
+		}
+	}
+}
+
+func TestCrossoverMonthNever(t *testing.T) {
+	// Проценты всегда превышают основной долг — перелома нет.
+	schedule := []ScheduleEntry{
+		{Month: 1, PrincipalComponent: 10, Interest: 100},
+		{Month: 2, PrincipalComponent: 20, Interest: 90},
+	}
+	if got := CrossoverMonth(schedule); got != -1 {
+		t.Fatalf("ожидалось -1, получено %d", got)
+	}
+}
+
+func TestPaymentSharesSumToHundred(t *testing.T) {
+	cfg := DefaultConfig()
+	annuity, err := AnnuitySchedule(cfg, LoanParams{Principal: 1_000_000, AnnualRatePercent: 12, Months: 60})
+	if err != nil {
+		t.Fatal(err)
+	}
+	differential, err := DifferentialSchedule(cfg, LoanParams{Principal: 1_000_000, AnnualRatePercent: 12, Months: 60})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, res := range []*CalculationResult{annuity, differential} {
+		for _, entry := range res.Schedule {
+			if entry.InterestShare+entry.PrincipalShare < 99.9 || entry.InterestShare+entry.PrincipalShare > 100.1 {
+				t.Fatalf("%s, месяц %d: доли %v + %v должны давать ~100",
+					res.Kind, entry.Month, entry.InterestShare, entry.PrincipalShare)
+			}
+		}
+	}
+	// У длинного кредита в начале преобладают проценты.
+	long, err := AnnuitySchedule(cfg, LoanParams{Principal: 1_000_000, AnnualRatePercent: 12, Months: 240})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if long.Schedule[0].InterestShare <= long.Schedule[0].PrincipalShare {
+		t.Fatal("в первый месяц длинного кредита доля процентов должна преобладать")
+	}
+}
+
+func TestPaymentSharesZeroRate(t *testing.T) {
+	cfg := DefaultConfig()
+	res, err := AnnuitySchedule(cfg, LoanParams{Principal: 1_200_000, AnnualRatePercent: 0, Months: 12})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Беспроцентный кредит: весь платеж — основной долг.
+	for _, entry := range res.Schedule {
+		inDelta(t, entry.PrincipalShare, 100, 0.01)
+		inDelta(t, entry.InterestShare, 0, 0.01)
+	}
+}
+
+func TestLoanConstant(t *testing.T) {
+	// Сверка с выводом через платеж: константа = 12 платежей / тело.
+	payment := AnnuityPayment(10_000_000, 12, 240)
+	inDelta(t, LoanConstant(10_000_000, 12, 240), payment*12/10_000_000*100, 1e-9)
+	// При нулевой ставке константа вырождается в 1200/срок.
+	inDelta(t, LoanConstant(5_000_000, 0, 120), 10, 1e-9)
+}
@@ -0,0 +1,118 @@
+package calculations
+
+import "fmt"
+
+// ProductProfile — ограничения кредитного продукта: допустимые диапазоны
+// суммы и срока плюс лимит доли платежа в доходе. Диапазоны продукта
+// лежат внутри глобальных лимитов Config и сужают их.
+type ProductProfile struct {
+	MinPrincipal float64 `json:"min_principal"`
+	MaxPrincipal float64 `json:"max_principal"`
+	MinMonths    int     `json:"min_months"`
+	MaxMonths    int     `json:"max_months"`
+	// MaxPaymentToIncomePercent — предельная доля аннуитетного платежа
+	// в месячном доходе заемщика (PTI).
+	MaxPaymentToIncomePercent float64 `json:"max_payment_to_income_percent"`
+}
+
+func (p ProductProfile) validate(cfg Config) error {
+	if p.MinPrincipal < 0 || p.MinPrincipal > p.MaxPrincipal {
+		return fmt.Errorf("диапазон сумм продукта задан некорректно: от %.2f до %.2f", p.MinPrincipal, p.MaxPrincipal)
+	}
+	if err := CheckPrincipal(cfg, p.MaxPrincipal); err != nil {
+		return err
+	}
+	if p.MinMonths < 1 || p.MinMonths > p.MaxMonths {
+		return fmt.Errorf("диапазон сроков продукта задан некорректно: от %d до %d месяцев", p.MinMonths, p.MaxMonths)
+	}
+	if err := CheckMonths(cfg, p.MaxMonths); err != nil {
+		return err
+	}
+	if p.MaxPaymentToIncomePercent <= 0 || p.MaxPaymentToIncomePercent > 100 {
+		return errOutOfRange("max_payment_to_income_percent", 1, 100)
+	}
+	return nil
+}
+
+// RuleResult — итог проверки одного правила заявки.
+type RuleResult struct {
+	Rule    string `json:"rule"`
+	Passed  bool   `json:"passed"`
+	Message string `json:"message,omitempty"`
+}
+
+// ApplicationResult — структурированный вердикт по заявке: общий флаг
+// и результат каждого правила по отдельности.
+type ApplicationResult struct {
+	Valid bool         `json:"valid"`
+	Rules []RuleResult `json:"rules"`
+	// MonthlyPayment и PaymentToIncomePercent заполняются, когда полевые
+	// проверки прошли и платеж удалось вычислить.
+	MonthlyPayment         float64 `json:"monthly_payment,omitempty"`
+	PaymentToIncomePercent float64 `json:"payment_to_income_percent,omitempty"`
+}
+
+// ValidateApplication прогоняет кредитную заявку через полевые валидаторы
+// и бизнес-правила продукта за один вызов: границы полей, диапазоны суммы
+// и срока продукта, доля платежа в доходе. Нарушения возвращаются не
+// ошибкой, а списком правил с флагами — ошибка остается для некорректного
+// профиля продукта или дохода. Правило payment_to_income проверяется
+// только когда полевые валидаторы прошли: иначе платеж не определен.
+func ValidateApplication(cfg Config, p LoanParams, monthlyIncome float64, product ProductProfile) (*ApplicationResult, error) {
+	if err := product.validate(cfg); err != nil {
+		return nil, err
+	}
+	if monthlyIncome <= 0 || monthlyIncome > cfg.MaxPrincipal {
+		return nil, &ValidationError{
+			Field:   "monthly_income",
+			Value:   monthlyIncome,
+			Min:     0,
+			Max:     cfg.MaxPrincipal,
+			Message: fmt.Sprintf("доход должен быть больше 0 и не более %.0f", cfg.MaxPrincipal),
+		}
+	}
+	res := &ApplicationResult{}
+	rule := func(name string, err error) bool {
+		r := RuleResult{Rule: name, Passed: err == nil}
+		if err != nil {
+			r.Message = err.Error()
+		}
+		res.Rules = append(res.Rules, r)
+		return r.Passed
+	}
+	fieldsOK := rule("principal_valid", CheckPrincipal(cfg, p.Principal))
+	fieldsOK = rule("rate_valid", CheckRate(cfg, p.AnnualRatePercent)) && fieldsOK
+	fieldsOK = rule("months_valid", CheckMonths(cfg, p.Months)) && fieldsOK
+
+	var bandErr error
+	if p.Principal < product.MinPrincipal || p.Principal > product.MaxPrincipal {
+		bandErr = fmt.Errorf("сумма %.2f вне диапазона продукта от %.2f до %.2f", p.Principal, product.MinPrincipal, product.MaxPrincipal)
+	}
+	rule("principal_in_product_range", bandErr)
+	bandErr = nil
+	if p.Months < product.MinMonths || p.Months > product.MaxMonths {
+		bandErr = fmt.Errorf("срок %d вне диапазона продукта от %d до %d месяцев", p.Months, product.MinMonths, product.MaxMonths)
+	}
+	rule("months_in_product_range", bandErr)
+
+	if fieldsOK {
+		payment := Round2(AnnuityPayment(p.Principal, p.AnnualRatePercent, p.Months))
+		pti := payment / monthlyIncome * 100
+		res.MonthlyPayment = payment
+		res.PaymentToIncomePercent = RoundPercent(cfg, pti)
+		var ptiErr error
+		if pti > product.MaxPaymentToIncomePercent {
+			ptiErr = fmt.Errorf("платеж %.2f занимает %.2f%% дохода при лимите %.2f%%", payment, pti, product.MaxPaymentToIncomePercent)
+		}
+		rule("payment_to_income", ptiErr)
+	}
+
+	res.Valid = true
+	for _, r := range res.Rules {
+		if !r.Passed {
+			res.Valid = false
+			break
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,65 @@
+package calculations
+
+import "testing"
+
+func TestValidateApplication(t *testing.T) {
+	cfg := DefaultConfig()
+	product := ProductProfile{
+		MinPrincipal:              1_000_000,
+		MaxPrincipal:              5_000_000,
+		MinMonths:                 12,
+		MaxMonths:                 240,
+		MaxPaymentToIncomePercent: 40,
+	}
+	// Все полевые проверки и диапазоны продукта проходят, но платеж
+	// съедает слишком большую долю дохода — падает ровно одно правило.
+	res, err := ValidateApplication(cfg, LoanParams{
+		Principal:         3_000_000,
+		AnnualRatePercent: 12,
+		Months:            120,
+	}, 60_000, product)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Valid {
+		t.Fatal("заявка с перегруженным доходом не должна проходить")
+	}
+	failed := 0
+	for _, r := range res.Rules {
+		if !r.Passed {
+			failed++
+			if r.Rule != "payment_to_income" {
+				t.Fatalf("упало неожиданное правило %q: %s", r.Rule, r.Message)
+			}
+			if r.Message == "" {
+				t.Fatal("упавшее правило должно объяснять причину")
+			}
+		}
+	}
+	if failed != 1 {
+		t.Fatalf("ожидалось ровно одно упавшее правило, получено %d", failed)
+	}
+	if res.PaymentToIncomePercent <= product.MaxPaymentToIncomePercent {
+		t.Fatalf("PTI %v должен превышать лимит %v", res.PaymentToIncomePercent, product.MaxPaymentToIncomePercent)
+	}
+
+	// С достаточным доходом та же заявка валидна целиком.
+	ok, err := ValidateApplication(cfg, LoanParams{
+		Principal:         3_000_000,
+		AnnualRatePercent: 12,
+		Months:            120,
+	}, 200_000, product)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok.Valid {
+		t.Fatalf("заявка должна проходить все правила: %+v", ok.Rules)
+	}
+
+	// Некорректный профиль продукта — ошибка, а не список правил.
+	bad := product
+	bad.MinMonths = 300
+	if _, err := ValidateApplication(cfg, LoanParams{Principal: 3_000_000, AnnualRatePercent: 12, Months: 120}, 200_000, bad); err == nil {
+		t.Fatal("ожидалась ошибка для некорректного профиля продукта")
+	}
+}
@@ -0,0 +1,54 @@
+package calculations
+
+// BlendedAPRResult — сводная эффективная ставка по связке «срочный кредит
+// плюс возобновляемая линия», взвешенная по среднему задолженному капиталу.
+type BlendedAPRResult struct {
+	TermAPRPercent       float64 `json:"term_apr_percent"`
+	RevolvingRatePercent float64 `json:"revolving_rate_percent"`
+	UtilizationPercent   float64 `json:"utilization_percent"`
+	AverageTermDebt      float64 `json:"average_term_debt"`
+	AverageRevolvingDebt float64 `json:"average_revolving_debt"`
+	BlendedAPRPercent    float64 `json:"blended_apr_percent"`
+}
+
+// BlendedAPR считает сводную ставку пакета из срочного кредита и
+// возобновляемой линии. Приближение для линии: средний остаток равен
+// лимиту, умноженному на среднюю утилизацию, держится весь срок кредита
+// и обслуживается только процентами (тело линии не амортизируется).
+// Сводная ставка — средняя из ставок частей, взвешенная по среднему
+// задолженному капиталу каждой части; при нулевой утилизации она
+// совпадает со ставкой срочного кредита.
+func BlendedAPR(cfg Config, p LoanParams, revolvingLimit, revolvingRatePercent, utilizationPercent float64) (*BlendedAPRResult, error) {
+	if err := CheckAmount(cfg, revolvingLimit); err != nil {
+		return nil, err
+	}
+	if err := CheckRate(cfg, revolvingRatePercent); err != nil {
+		return nil, err
+	}
+	if utilizationPercent < 0 || utilizationPercent > 100 {
+		return nil, errOutOfRange("utilization_percent", 0, 100)
+	}
+	res, err := AnnuitySchedule(cfg, p)
+	if err != nil {
+		return nil, err
+	}
+	// Средний остаток срочного кредита — по балансам на начало месяца.
+	sum := p.Principal
+	for _, entry := range res.Schedule[:len(res.Schedule)-1] {
+		sum += entry.RemainingPrincipal
+	}
+	avgTerm := sum / float64(p.Months)
+	avgRevolving := revolvingLimit * utilizationPercent / 100
+	blended := p.AnnualRatePercent
+	if avgTerm+avgRevolving > 0 {
+		blended = (avgTerm*p.AnnualRatePercent + avgRevolving*revolvingRatePercent) / (avgTerm + avgRevolving)
+	}
+	return &BlendedAPRResult{
+		TermAPRPercent:       p.AnnualRatePercent,
+		RevolvingRatePercent: revolvingRatePercent,
+		UtilizationPercent:   utilizationPercent,
+		AverageTermDebt:      Round(avgTerm, cfg.Precision),
+		AverageRevolvingDebt: Round(avgRevolving, cfg.Precision),
+		BlendedAPRPercent:    Round(blended, 4),
+	}, nil
+}
@@ -0,0 +1,43 @@
+package calculations
+
+import "testing"
+
+func TestBlendedAPRZeroUtilization(t *testing.T) {
+	cfg := DefaultConfig()
+	p := LoanParams{Principal: 1_000_000, AnnualRatePercent: 12, Months: 60}
+	res, err := BlendedAPR(cfg, p, 500_000, 25, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Без использования линии сводная ставка равна ставке кредита.
+	inDelta(t, res.BlendedAPRPercent, 12, 0.0001)
+	inDelta(t, res.AverageRevolvingDebt, 0, 0.01)
+}
+
+func TestBlendedAPRFullUtilization(t *testing.T) {
+	cfg := DefaultConfig()
+	p := LoanParams{Principal: 1_000_000, AnnualRatePercent: 12, Months: 60}
+	res, err := BlendedAPR(cfg, p, 500_000, 25, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Дорогая линия тянет сводную ставку вверх, но не выше своей.
+	if res.BlendedAPRPercent <= 12 || res.BlendedAPRPercent >= 25 {
+		t.Fatalf("сводная ставка %v должна лежать между 12 и 25", res.BlendedAPRPercent)
+	}
+	inDelta(t, res.AverageRevolvingDebt, 500_000, 0.01)
+	// Ручная сверка взвешивания.
+	want := (res.AverageTermDebt*12 + 500_000*25) / (res.AverageTermDebt + 500_000)
+	inDelta(t, res.BlendedAPRPercent, want, 0.0001)
+}
+
+func TestBlendedAPRValidation(t *testing.T) {
+	cfg := DefaultConfig()
+	p := LoanParams{Principal: 1_000_000, AnnualRatePercent: 12, Months: 60}
+	if _, err := BlendedAPR(cfg, p, 500_000, 25, 101); err == nil {
+		t.Fatal("ожидалась ошибка для утилизации выше 100%")
+	}
+	if _, err := BlendedAPR(cfg, p, -1, 25, 50); err == nil {
+		t.Fatal("ожидалась ошибка для отрицательного лимита")
+	}
+}
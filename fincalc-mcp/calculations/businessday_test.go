@@ -0,0 +1,41 @@
+package calculations
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBusinessDayAccrual(t *testing.T) {
+	cfg := DefaultConfig()
+	// Июнь 2026: 30 дней, из них 8 выходных — рабочих 22.
+	p := DepositParams{
+		InitialAmount:     1_000_000,
+		AnnualRatePercent: 10,
+		Months:            1,
+		Capitalize:        true,
+		StartDate:         time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC),
+	}
+	res, err := BusinessDayAccrual(cfg, p, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	daily := 1_000_000 * 10.0 / 100 / 365
+	inDelta(t, res.TotalInterest, daily*22, 0.01)
+	inDelta(t, res.CalendarDayInterest, daily*30, 0.01)
+	inDelta(t, res.Difference, daily*8, 0.02)
+
+	// Праздник в будний день (пятница 12 июня) выключает еще один день.
+	holiday := time.Date(2026, 6, 12, 0, 0, 0, 0, time.UTC)
+	withHoliday, err := BusinessDayAccrual(cfg, p, []time.Time{holiday})
+	if err != nil {
+		t.Fatal(err)
+	}
+	inDelta(t, withHoliday.TotalInterest, daily*21, 0.01)
+
+	// Без даты открытия начисление по рабочим дням не определено.
+	noStart := p
+	noStart.StartDate = time.Time{}
+	if _, err := BusinessDayAccrual(cfg, noStart, nil); err == nil {
+		t.Fatal("ожидалась ошибка без даты открытия")
+	}
+}
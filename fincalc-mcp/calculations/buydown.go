@@ -0,0 +1,60 @@
+package calculations
+
+import "fmt"
+
+// BuydownResult — экономика выкупа ставки: платеж до и после, месяц
+// окупаемости разовой комиссии и чистая выгода за весь срок.
+type BuydownResult struct {
+	BaseRatePercent       float64 `json:"base_rate_percent"`
+	ReducedRatePercent    float64 `json:"reduced_rate_percent"`
+	PointsCost            float64 `json:"points_cost"`
+	BaseMonthlyPayment    float64 `json:"base_monthly_payment"`
+	ReducedMonthlyPayment float64 `json:"reduced_monthly_payment"`
+	MonthlySavings        float64 `json:"monthly_savings"`
+	BreakEvenMonth        int     `json:"break_even_month"` // -1, если выкуп не окупается
+	LifetimeSavings       float64 `json:"lifetime_savings"` // за вычетом стоимости выкупа
+}
+
+// RateBuydown сравнивает аннуитетный кредит по базовой и выкупленной
+// ставкам: ежемесячная экономия, месяц, в котором накопленная экономия
+// впервые превышает стоимость выкупа, и чистая выгода за срок.
+func RateBuydown(cfg Config, p LoanParams, pointsCost, reducedRatePercent float64) (*BuydownResult, error) {
+	if err := CheckAmount(cfg, pointsCost); err != nil {
+		return nil, err
+	}
+	if err := CheckRate(cfg, reducedRatePercent); err != nil {
+		return nil, err
+	}
+	if reducedRatePercent >= p.AnnualRatePercent {
+		return nil, fmt.Errorf("выкупленная ставка %.4f%% должна быть ниже базовой %.4f%%", reducedRatePercent, p.AnnualRatePercent)
+	}
+	base, err := AnnuitySchedule(cfg, p)
+	if err != nil {
+		return nil, err
+	}
+	reducedParams := p
+	reducedParams.AnnualRatePercent = reducedRatePercent
+	reduced, err := AnnuitySchedule(cfg, reducedParams)
+	if err != nil {
+		return nil, err
+	}
+	out := &BuydownResult{
+		BaseRatePercent:       p.AnnualRatePercent,
+		ReducedRatePercent:    reducedRatePercent,
+		PointsCost:            pointsCost,
+		BaseMonthlyPayment:    base.MonthlyPayment,
+		ReducedMonthlyPayment: reduced.MonthlyPayment,
+		MonthlySavings:        Round(base.MonthlyPayment-reduced.MonthlyPayment, cfg.Precision),
+		BreakEvenMonth:        -1,
+		LifetimeSavings:       Round(base.TotalPayment-reduced.TotalPayment-pointsCost, cfg.Precision),
+	}
+	saved := 0.0
+	for m := 1; m <= p.Months; m++ {
+		saved += base.Schedule[m-1].Payment - reduced.Schedule[m-1].Payment
+		if saved > pointsCost {
+			out.BreakEvenMonth = m
+			break
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,49 @@
+package calculations
+
+import "testing"
+
+func TestRateBuydownBreaksEvenMidTerm(t *testing.T) {
+	cfg := DefaultConfig()
+	p := LoanParams{Principal: 5_000_000, AnnualRatePercent: 12, Months: 240}
+	res, err := RateBuydown(cfg, p, 300_000, 11)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.MonthlySavings <= 0 {
+		t.Fatalf("ежемесячная экономия должна быть положительной, получено %v", res.MonthlySavings)
+	}
+	if res.BreakEvenMonth <= 1 || res.BreakEvenMonth >= p.Months {
+		t.Fatalf("окупаемость должна наступать в середине срока, получено %d", res.BreakEvenMonth)
+	}
+	// В месяц окупаемости накопленная экономия превышает стоимость выкупа.
+	inDelta(t, float64(res.BreakEvenMonth), 300_000/res.MonthlySavings, 1.5)
+	if res.LifetimeSavings <= 0 {
+		t.Fatalf("за весь срок выкуп должен быть выгоден, получено %v", res.LifetimeSavings)
+	}
+}
+
+func TestRateBuydownNeverPaysOff(t *testing.T) {
+	cfg := DefaultConfig()
+	p := LoanParams{Principal: 1_000_000, AnnualRatePercent: 12, Months: 12}
+	res, err := RateBuydown(cfg, p, 500_000, 11)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.BreakEvenMonth != -1 {
+		t.Fatalf("непомерный выкуп не должен окупаться, получено %d", res.BreakEvenMonth)
+	}
+	if res.LifetimeSavings >= 0 {
+		t.Fatalf("чистая выгода должна быть отрицательной, получено %v", res.LifetimeSavings)
+	}
+}
+
+func TestRateBuydownValidation(t *testing.T) {
+	cfg := DefaultConfig()
+	p := LoanParams{Principal: 1_000_000, AnnualRatePercent: 12, Months: 120}
+	if _, err := RateBuydown(cfg, p, 100_000, 12); err == nil {
+		t.Fatal("ожидалась ошибка: выкупленная ставка не ниже базовой")
+	}
+	if _, err := RateBuydown(cfg, p, -1, 11); err == nil {
+		t.Fatal("ожидалась ошибка для отрицательной стоимости выкупа")
+	}
+}
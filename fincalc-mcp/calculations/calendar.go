@@ -0,0 +1,50 @@
+package calculations
+
+import "time"
+
+// dateLayout — формат календарных дат в ответах (дата без времени, RFC3339).
+const dateLayout = "2006-01-02"
+
+// daysInMonth возвращает число дней в месяце.
+func daysInMonth(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// daysInYear возвращает число дней в году (365 или 366).
+func daysInYear(year int) int {
+	return time.Date(year, 12, 31, 0, 0, 0, 0, time.UTC).YearDay()
+}
+
+// dueDate возвращает дату платежа через monthsAhead месяцев после даты
+// выдачи: желаемый день прижимается к последнему дню короткого месяца
+// (например, 31-е число в феврале становится 28-м или 29-м).
+func dueDate(start time.Time, monthsAhead, dayOfMonth int) time.Time {
+	total := int(start.Month()) - 1 + monthsAhead
+	year := start.Year() + total/12
+	month := time.Month(total%12 + 1)
+	day := dayOfMonth
+	if last := daysInMonth(year, month); day > last {
+		day = last
+	}
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// DueDates проставляет календарные даты платежей в графике, продвигаясь
+// помесячно от даты выдачи, и записывает дату полного погашения в сводку.
+// Вместе с датой заполняются Year и Quarter строки — чтобы внешние системы
+// группировали график по фискальным периодам без разбора дат.
+func DueDates(result *CalculationResult, start time.Time, paymentDayOfMonth int) error {
+	if paymentDayOfMonth < 1 || paymentDayOfMonth > 31 {
+		return errOutOfRange("payment_day_of_month", 1, 31)
+	}
+	for i := range result.Schedule {
+		due := dueDate(start, result.Schedule[i].Month, paymentDayOfMonth)
+		result.Schedule[i].DueDate = due.Format(dateLayout)
+		result.Schedule[i].Year = due.Year()
+		result.Schedule[i].Quarter = (int(due.Month())-1)/3 + 1
+	}
+	if n := len(result.Schedule); n > 0 {
+		result.PayoffDate = result.Schedule[n-1].DueDate
+	}
+	return nil
+}
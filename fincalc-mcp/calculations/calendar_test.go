@@ -0,0 +1,87 @@
+package calculations
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDueDatesClampShortMonths(t *testing.T) {
+	cfg := DefaultConfig()
+	res, err := AnnuitySchedule(cfg, LoanParams{Principal: 100_000, AnnualRatePercent: 12, Months: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Date(2024, time.January, 31, 0, 0, 0, 0, time.UTC)
+	if err := DueDates(res, start, 31); err != nil {
+		t.Fatal(err)
+	}
+	// Февраль 2024 (високосный) — 29-е, март — снова 31-е.
+	if res.Schedule[0].DueDate != "2024-02-29" {
+		t.Fatalf("ожидалось 2024-02-29, получено %s", res.Schedule[0].DueDate)
+	}
+	if res.Schedule[1].DueDate != "2024-03-31" {
+		t.Fatalf("ожидалось 2024-03-31, получено %s", res.Schedule[1].DueDate)
+	}
+	if res.PayoffDate != "2024-04-30" {
+		t.Fatalf("ожидалась дата погашения 2024-04-30, получено %s", res.PayoffDate)
+	}
+}
+
+func TestDueDatesYearRollover(t *testing.T) {
+	cfg := DefaultConfig()
+	res, err := AnnuitySchedule(cfg, LoanParams{Principal: 100_000, AnnualRatePercent: 12, Months: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Date(2024, time.November, 15, 0, 0, 0, 0, time.UTC)
+	if err := DueDates(res, start, 15); err != nil {
+		t.Fatal(err)
+	}
+	if res.Schedule[1].DueDate != "2025-01-15" {
+		t.Fatalf("переход через год: ожидалось 2025-01-15, получено %s", res.Schedule[1].DueDate)
+	}
+}
+
+func TestDueDatesValidatesDay(t *testing.T) {
+	res := &CalculationResult{Schedule: []ScheduleEntry{{Month: 1}}}
+	if err := DueDates(res, time.Now(), 32); err == nil {
+		t.Fatal("ожидалась ошибка для дня месяца вне диапазона")
+	}
+}
+
+func TestDueDatesFiscalPeriods(t *testing.T) {
+	cfg := DefaultConfig()
+	res, err := AnnuitySchedule(cfg, LoanParams{Principal: 100_000, AnnualRatePercent: 12, Months: 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Date(2025, time.January, 15, 0, 0, 0, 0, time.UTC)
+	if err := DueDates(res, start, 15); err != nil {
+		t.Fatal(err)
+	}
+	// Февраль и март — Q1, апрель — Q2, май — Q2.
+	wantQuarters := []int{1, 1, 2, 2}
+	for i, want := range wantQuarters {
+		if res.Schedule[i].Quarter != want {
+			t.Fatalf("месяц %d: ожидался квартал %d, получен %d (%s)",
+				i+1, want, res.Schedule[i].Quarter, res.Schedule[i].DueDate)
+		}
+		if res.Schedule[i].Year != 2025 {
+			t.Fatalf("месяц %d: ожидался 2025 год, получен %d", i+1, res.Schedule[i].Year)
+		}
+	}
+}
+
+func TestDueDatesFiscalPeriodsOnlyWithStartDate(t *testing.T) {
+	cfg := DefaultConfig()
+	res, err := AnnuitySchedule(cfg, LoanParams{Principal: 100_000, AnnualRatePercent: 12, Months: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Без даты выдачи фискальные поля остаются незаполненными.
+	for _, entry := range res.Schedule {
+		if entry.Year != 0 || entry.Quarter != 0 {
+			t.Fatalf("без start_date Year/Quarter должны быть пустыми: %+v", entry)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package calculations
+
+// CapitalizationComparison прогоняет один и тот же вклад с помесячной и
+// годовой капитализацией при одинаковой номинальной ставке и количественно
+// показывает выигрыш более частого зачисления процентов: по итоговому
+// балансу и по эффективной годовой доходности.
+func CapitalizationComparison(cfg Config, p DepositParams) (map[string]any, error) {
+	p.Capitalize = true
+	p.CapitalizationFrequency = CapitalizationMonthly
+	monthly, err := DepositSchedule(cfg, p)
+	if err != nil {
+		return nil, err
+	}
+	p.CapitalizationFrequency = CapitalizationAnnual
+	annual, err := DepositSchedule(cfg, p)
+	if err != nil {
+		return nil, err
+	}
+	monthlyYield, err := EffectiveAnnualYield(p.AnnualRatePercent, FreqMonthly)
+	if err != nil {
+		return nil, err
+	}
+	annualYield, err := EffectiveAnnualYield(p.AnnualRatePercent, FreqAnnual)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{
+		"monthly": map[string]any{
+			"final_balance":           monthly.FinalBalance,
+			"total_interest":          monthly.TotalInterest,
+			"effective_yield_percent": RoundPercent(cfg, monthlyYield*100),
+		},
+		"annual": map[string]any{
+			"final_balance":           annual.FinalBalance,
+			"total_interest":          annual.TotalInterest,
+			"effective_yield_percent": RoundPercent(cfg, annualYield*100),
+		},
+		"final_balance_difference":           Round2(monthly.FinalBalance - annual.FinalBalance),
+		"effective_yield_difference_percent": RoundPercent(cfg, (monthlyYield-annualYield)*100),
+	}, nil
+}
@@ -0,0 +1,49 @@
+package calculations
+
+import "testing"
+
+func TestAnnualCapitalization(t *testing.T) {
+	cfg := DefaultConfig()
+	p := DepositParams{
+		InitialAmount:           100_000,
+		AnnualRatePercent:       12,
+		Months:                  24,
+		Capitalize:              true,
+		CapitalizationFrequency: CapitalizationAnnual,
+	}
+	res, err := DepositSchedule(cfg, p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Внутри года проценты простые: баланс первых 11 месяцев не растет.
+	if res.Schedule[0].Balance != 100_000 || res.Schedule[10].Balance != 100_000 {
+		t.Fatalf("до годовой капитализации баланс не должен меняться: %v, %v", res.Schedule[0].Balance, res.Schedule[10].Balance)
+	}
+	// После 12-го месяца зачислен годовой процент: 100000 * 12% = 12000.
+	inDelta(t, res.Schedule[11].Balance, 112_000, 0.01)
+	// Второй год начисляется уже на капитализированный баланс.
+	inDelta(t, res.FinalBalance, 112_000*1.12, 0.01)
+}
+
+func TestCapitalizationComparison(t *testing.T) {
+	cfg := DefaultConfig()
+	out, err := CapitalizationComparison(cfg, DepositParams{
+		InitialAmount:     500_000,
+		AnnualRatePercent: 10,
+		Months:            36,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	monthly := out["monthly"].(map[string]any)
+	annual := out["annual"].(map[string]any)
+	if monthly["final_balance"].(float64) <= annual["final_balance"].(float64) {
+		t.Fatalf("помесячная капитализация должна давать больше: %v <= %v", monthly["final_balance"], annual["final_balance"])
+	}
+	if out["final_balance_difference"].(float64) <= 0 {
+		t.Fatalf("разница балансов должна быть положительной, получено %v", out["final_balance_difference"])
+	}
+	if out["effective_yield_difference_percent"].(float64) <= 0 {
+		t.Fatalf("разница эффективных доходностей должна быть положительной, получено %v", out["effective_yield_difference_percent"])
+	}
+}
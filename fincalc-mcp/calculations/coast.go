@@ -0,0 +1,77 @@
+package calculations
+
+import "fmt"
+
+// CoastPointResult — точка, после которой взносы можно прекратить: capital
+// уже достаточен, чтобы доехать до цели на одном сложном проценте.
+type CoastPointResult struct {
+	TargetAmount float64 `json:"target_amount"`
+	// CoastMonth — самый ранний месяц, после которого взносы не нужны;
+	// 0 — хватает начальной суммы, -1 — цель недостижима даже при взносах
+	// весь срок.
+	CoastMonth int `json:"coast_month"`
+	// FinalBalance — итог при остановке взносов в CoastMonth (при -1 —
+	// итог со взносами весь срок).
+	FinalBalance float64 `json:"final_balance"`
+}
+
+// CoastPoint находит самый ранний месяц, с которого можно прекратить
+// пополнения и все равно достичь цели к горизонту на одном росте капитала:
+// накопление идет двумя фазами — со взносами, затем «на выбеге». Итог
+// монотонно растет с длительностью фазы взносов, поэтому точка ищется
+// бинарным поиском.
+func CoastPoint(cfg Config, p InvestmentParams, targetAmount float64) (*CoastPointResult, error) {
+	if targetAmount <= 0 || targetAmount > cfg.MaxBalance {
+		return nil, fmt.Errorf("целевая сумма должна быть в диапазоне от 0 до %.0f", cfg.MaxBalance)
+	}
+	finalAt := func(contributingMonths int) (float64, error) {
+		balance := p.InitialAmount
+		if contributingMonths > 0 {
+			phase := p
+			phase.Months = contributingMonths
+			res, err := CalculateInvestment(cfg, phase)
+			if err != nil {
+				return 0, err
+			}
+			balance = res.FinalBalance
+		}
+		if remaining := p.Months - contributingMonths; remaining > 0 && balance > 0 {
+			res, err := DepositSchedule(cfg, DepositParams{
+				InitialAmount:     balance,
+				AnnualRatePercent: p.AnnualReturnPercent,
+				Months:            remaining,
+				Capitalize:        true,
+			})
+			if err != nil {
+				return 0, err
+			}
+			balance = res.FinalBalance
+		}
+		return balance, nil
+	}
+	atFull, err := finalAt(p.Months)
+	if err != nil {
+		return nil, err
+	}
+	if atFull < targetAmount {
+		return &CoastPointResult{TargetAmount: targetAmount, CoastMonth: -1, FinalBalance: atFull}, nil
+	}
+	lo, hi := 0, p.Months
+	for lo < hi {
+		mid := (lo + hi) / 2
+		balance, err := finalAt(mid)
+		if err != nil {
+			return nil, err
+		}
+		if balance >= targetAmount {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+	final, err := finalAt(lo)
+	if err != nil {
+		return nil, err
+	}
+	return &CoastPointResult{TargetAmount: targetAmount, CoastMonth: lo, FinalBalance: final}, nil
+}
@@ -0,0 +1,51 @@
+package calculations
+
+import "testing"
+
+func TestCoastPoint(t *testing.T) {
+	cfg := DefaultConfig()
+	p := InvestmentParams{
+		InitialAmount:       100_000,
+		MonthlyContribution: 20_000,
+		AnnualReturnPercent: 10,
+		Months:              240,
+	}
+	res, err := CoastPoint(cfg, p, 2_000_000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.CoastMonth <= 0 || res.CoastMonth >= p.Months {
+		t.Fatalf("ожидалась точка внутри срока, получено %d", res.CoastMonth)
+	}
+	if res.FinalBalance < 2_000_000 {
+		t.Fatalf("итог на выбеге %v не достигает цели", res.FinalBalance)
+	}
+	// Месяцем раньше цели еще не достичь — точка действительно самая ранняя.
+	earlier := p
+	earlier.Months = res.CoastMonth - 1
+	partial, err := CalculateInvestment(cfg, earlier)
+	if err != nil {
+		t.Fatal(err)
+	}
+	coast, err := DepositSchedule(cfg, DepositParams{
+		InitialAmount:     partial.FinalBalance,
+		AnnualRatePercent: p.AnnualReturnPercent,
+		Months:            p.Months - earlier.Months,
+		Capitalize:        true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if coast.FinalBalance >= 2_000_000 {
+		t.Fatalf("точка не минимальна: выбег с месяца %d тоже достигает цели", res.CoastMonth-1)
+	}
+
+	// Недостижимая цель.
+	unreachable, err := CoastPoint(cfg, p, 1e11)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if unreachable.CoastMonth != -1 {
+		t.Fatalf("ожидался -1 для недостижимой цели, получено %d", unreachable.CoastMonth)
+	}
+}
@@ -0,0 +1,43 @@
+package calculations
+
+// ScheduleToColumns преобразует помесячный график в колоночный формат:
+// отдельные выровненные массивы одинаковой длины по каждой серии.
+// Включаются только серии, относящиеся к виду расчета, что заметно
+// сокращает размер ответа для графиков.
+func ScheduleToColumns(result *CalculationResult) map[string][]float64 {
+	n := len(result.Schedule)
+	months := make([]float64, n)
+	interest := make([]float64, n)
+	for i, e := range result.Schedule {
+		months[i] = float64(e.Month)
+		interest[i] = e.Interest
+	}
+	columns := map[string][]float64{
+		"months":   months,
+		"interest": interest,
+	}
+	switch result.Kind {
+	case KindAnnuity, KindDifferential:
+		payments := make([]float64, n)
+		principal := make([]float64, n)
+		remaining := make([]float64, n)
+		for i, e := range result.Schedule {
+			payments[i] = e.Payment
+			principal[i] = e.PrincipalComponent
+			remaining[i] = e.RemainingPrincipal
+		}
+		columns["payments"] = payments
+		columns["principal_components"] = principal
+		columns["remaining_principal"] = remaining
+	case KindDeposit, KindInvestment:
+		contributions := make([]float64, n)
+		balance := make([]float64, n)
+		for i, e := range result.Schedule {
+			contributions[i] = e.Contribution
+			balance[i] = e.Balance
+		}
+		columns["contributions"] = contributions
+		columns["balance"] = balance
+	}
+	return columns
+}
@@ -0,0 +1,40 @@
+package calculations
+
+import "testing"
+
+func TestScheduleToColumnsLoan(t *testing.T) {
+	cfg := DefaultConfig()
+	res, err := AnnuitySchedule(cfg, LoanParams{Principal: 1_000_000, AnnualRatePercent: 12, Months: 24})
+	if err != nil {
+		t.Fatal(err)
+	}
+	columns := ScheduleToColumns(res)
+	for name, series := range columns {
+		if len(series) != res.Months {
+			t.Fatalf("длина серии %q равна %d, ожидалось %d", name, len(series), res.Months)
+		}
+	}
+	if _, ok := columns["balance"]; ok {
+		t.Fatal("серия balance не относится к кредитному графику")
+	}
+	if _, ok := columns["payments"]; !ok {
+		t.Fatal("в кредитном графике должна быть серия payments")
+	}
+}
+
+func TestScheduleToColumnsDeposit(t *testing.T) {
+	cfg := DefaultConfig()
+	res, err := DepositSchedule(cfg, DepositParams{InitialAmount: 100_000, AnnualRatePercent: 10, Months: 6, Capitalize: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	columns := ScheduleToColumns(res)
+	for name, series := range columns {
+		if len(series) != res.Months {
+			t.Fatalf("длина серии %q равна %d, ожидалось %d", name, len(series), res.Months)
+		}
+	}
+	if _, ok := columns["payments"]; ok {
+		t.Fatal("серия payments не относится к графику вклада")
+	}
+}
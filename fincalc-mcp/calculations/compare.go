@@ -0,0 +1,147 @@
+package calculations
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// TermOption — сводка аннуитетного кредита для одного срока.
+// InterestDelta — прирост переплаты относительно предыдущего
+// (более короткого) срока в списке.
+type TermOption struct {
+	Months         int     `json:"months"`
+	MonthlyPayment float64 `json:"monthly_payment"`
+	TotalPayment   float64 `json:"total_payment"`
+	TotalInterest  float64 `json:"total_interest"`
+	InterestDelta  float64 `json:"interest_delta"`
+}
+
+// TermComparison считает один и тот же аннуитетный кредит для набора
+// сроков и возвращает сводки, отсортированные по сроку.
+func TermComparison(cfg Config, principal, annualRatePercent float64, terms []int) ([]TermOption, error) {
+	if len(terms) == 0 {
+		return nil, fmt.Errorf("список сроков не может быть пустым")
+	}
+	if err := cfg.CheckExpansion(len(terms)); err != nil {
+		return nil, err
+	}
+	sorted := append([]int(nil), terms...)
+	sort.Ints(sorted)
+	options := make([]TermOption, 0, len(sorted))
+	for i, months := range sorted {
+		if err := CheckMonths(cfg, months); err != nil {
+			return nil, err
+		}
+		res, err := AnnuitySchedule(cfg, LoanParams{
+			Principal:         principal,
+			AnnualRatePercent: annualRatePercent,
+			Months:            months,
+		})
+		if err != nil {
+			return nil, err
+		}
+		option := TermOption{
+			Months:         months,
+			MonthlyPayment: res.MonthlyPayment,
+			TotalPayment:   res.TotalPayment,
+			TotalInterest:  res.TotalInterest,
+		}
+		if i > 0 {
+			option.InterestDelta = Round2(res.TotalInterest - options[i-1].TotalInterest)
+		}
+		options = append(options, option)
+	}
+	return options, nil
+}
+
+// overpaymentPercent — переплата к телу кредита в процентах. Вырожденное
+// тело (неположительное либо дающее Inf/NaN при делении в обход валидации
+// обработчиков) дает 0 и флаг, чтобы NaN не протекал в JSON.
+func overpaymentPercent(cfg Config, totalInterest, principal float64) (float64, bool) {
+	ratio := totalInterest / principal * 100
+	if principal <= 0 || math.IsNaN(ratio) || math.IsInf(ratio, 0) {
+		return 0, true
+	}
+	return RoundPercent(cfg, ratio), false
+}
+
+// CompareLoans сравнивает аннуитетную и дифференцированную схемы для одного
+// кредита и возвращает сводку с рекомендацией.
+func CompareLoans(cfg Config, p LoanParams) (map[string]any, error) {
+	annuity, err := AnnuitySchedule(cfg, p)
+	if err != nil {
+		return nil, err
+	}
+	differential, err := DifferentialSchedule(cfg, p)
+	if err != nil {
+		return nil, err
+	}
+	savings := Round2(annuity.TotalInterest - differential.TotalInterest)
+	recommendation := "Дифференцированная схема дешевле по общей переплате, " +
+		"но первые платежи заметно выше аннуитетных."
+	if savings <= 0 {
+		recommendation = "Переплата по схемам совпадает: выбирайте аннуитет " +
+			"ради одинаковых платежей."
+	}
+	annuityOverpayment, annuityDegenerate := overpaymentPercent(cfg, annuity.TotalInterest, p.Principal)
+	differentialOverpayment, differentialDegenerate := overpaymentPercent(cfg, differential.TotalInterest, p.Principal)
+	comparison := map[string]any{
+		"annuity": map[string]any{
+			"monthly_payment":     annuity.MonthlyPayment,
+			"total_payment":       annuity.TotalPayment,
+			"total_interest":      annuity.TotalInterest,
+			"overpayment_percent": annuityOverpayment,
+		},
+		"differential": map[string]any{
+			"first_payment":       differential.FirstPayment,
+			"last_payment":        differential.LastPayment,
+			"total_payment":       differential.TotalPayment,
+			"total_interest":      differential.TotalInterest,
+			"overpayment_percent": differentialOverpayment,
+		},
+		"savings_with_differential": savings,
+		"recommendation":            recommendation,
+	}
+	if annuityDegenerate || differentialDegenerate {
+		comparison["degenerate_principal"] = true
+	}
+	return comparison, nil
+}
+
+// Предпочтения заемщика для адресной рекомендации схемы платежей.
+const (
+	PreferMinTotalCost     = "min_total_cost"     // минимальная переплата
+	PreferMinEarlyPayments = "min_early_payments" // щадящие первые платежи
+	PreferStableBudget     = "stable_budget"      // предсказуемый бюджет
+)
+
+// RecommendScheme выбирает схему платежей под заявленное предпочтение
+// заемщика, опираясь на уже посчитанные числа CompareLoans: переплату,
+// аннуитетный платеж и первый дифференцированный платеж.
+func RecommendScheme(comparison map[string]any, preference string) (recommendedType, rationale string, err error) {
+	savings, _ := comparison["savings_with_differential"].(float64)
+	annuity, _ := comparison["annuity"].(map[string]any)
+	differential, _ := comparison["differential"].(map[string]any)
+	monthlyPayment, _ := annuity["monthly_payment"].(float64)
+	firstPayment, _ := differential["first_payment"].(float64)
+	switch preference {
+	case PreferMinTotalCost:
+		if savings > 0 {
+			return KindDifferential, fmt.Sprintf(
+				"Дифференцированная схема экономит %.2f на переплате за весь срок.", savings), nil
+		}
+		return KindAnnuity, "Переплата по схемам совпадает: аннуитет дает те же итоги при ровных платежах.", nil
+	case PreferMinEarlyPayments:
+		if firstPayment > monthlyPayment {
+			return KindAnnuity, fmt.Sprintf(
+				"Первый дифференцированный платеж %.2f выше аннуитетного %.2f: аннуитет щадит бюджет на старте.",
+				firstPayment, monthlyPayment), nil
+		}
+		return KindDifferential, "Первые дифференцированные платежи не выше аннуитетных.", nil
+	case PreferStableBudget:
+		return KindAnnuity, "Аннуитет держит одинаковый платеж весь срок — проще планировать бюджет.", nil
+	}
+	return "", "", fmt.Errorf("параметр %q должен быть %q, %q или %q",
+		"preference", PreferMinTotalCost, PreferMinEarlyPayments, PreferStableBudget)
+}
@@ -0,0 +1,129 @@
+package calculations
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestCompareLoansSavings(t *testing.T) {
+	cfg := DefaultConfig()
+	res, err := CompareLoans(cfg, LoanParams{Principal: 1_000_000, AnnualRatePercent: 12, Months: 24})
+	if err != nil {
+		t.Fatal(err)
+	}
+	savings, ok := res["savings_with_differential"].(float64)
+	if !ok || savings <= 0 {
+		t.Fatalf("дифференцированная схема должна экономить проценты, получено %v", res["savings_with_differential"])
+	}
+}
+
+func TestTermComparison(t *testing.T) {
+	cfg := DefaultConfig()
+	options, err := TermComparison(cfg, 1_000_000, 12, []int{36, 12, 24})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(options) != 3 {
+		t.Fatalf("ожидалось 3 варианта, получено %d", len(options))
+	}
+	for i, option := range options {
+		if want := []int{12, 24, 36}[i]; option.Months != want {
+			t.Fatalf("варианты должны быть отсортированы по сроку: позиция %d содержит %d", i, option.Months)
+		}
+		if i > 0 {
+			if option.TotalInterest <= options[i-1].TotalInterest {
+				t.Fatal("переплата должна расти с увеличением срока")
+			}
+			if option.InterestDelta <= 0 {
+				t.Fatalf("дельта переплаты должна быть положительной, получено %v", option.InterestDelta)
+			}
+		}
+	}
+}
+
+func TestTermComparisonValidatesTerm(t *testing.T) {
+	cfg := DefaultConfig()
+	if _, err := TermComparison(cfg, 1_000_000, 12, []int{12, cfg.MaxMonths + 1}); err == nil {
+		t.Fatal("ожидалась ошибка для срока сверх MaxMonths")
+	}
+}
+
+func TestTermComparisonRejectsOversizedExpansion(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.MaxExpandedCalculations = 2
+	_, err := TermComparison(cfg, 1_000_000, 12, []int{12, 24, 36})
+	if err == nil {
+		t.Fatal("ожидался отказ до начала расчета при превышении лимита")
+	}
+	var ve *ValidationError
+	if !errors.As(err, &ve) {
+		t.Fatalf("ожидалась ValidationError с границами лимита, получено %T", err)
+	}
+	if ve.Max != 2 {
+		t.Fatalf("в ошибке должен быть лимит 2, получено %v", ve.Max)
+	}
+}
+
+func TestOptimalRefinanceRejectsOversizedExpansion(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.MaxExpandedCalculations = 10
+	_, err := OptimalRefinanceMonth(cfg, LoanParams{Principal: 1_000_000, AnnualRatePercent: 12, Months: 120}, 9, 0)
+	if err == nil {
+		t.Fatal("ожидался отказ: перебор 120 месяцев превышает лимит 10")
+	}
+}
+
+func TestRecommendSchemeFlipsWithPreference(t *testing.T) {
+	cfg := DefaultConfig()
+	p := LoanParams{Principal: 1_000_000, AnnualRatePercent: 12, Months: 120}
+	comparison, err := CompareLoans(cfg, p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Одни и те же числа — разные рекомендации под разные предпочтения.
+	cheap, _, err := RecommendScheme(comparison, PreferMinTotalCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cheap != KindDifferential {
+		t.Fatalf("минимальная переплата должна указывать на дифференцированную схему, получено %q", cheap)
+	}
+	gentle, rationale, err := RecommendScheme(comparison, PreferMinEarlyPayments)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gentle != KindAnnuity {
+		t.Fatalf("щадящий старт должен указывать на аннуитет, получено %q", gentle)
+	}
+	if rationale == "" {
+		t.Fatal("обоснование не должно быть пустым")
+	}
+	stable, _, err := RecommendScheme(comparison, PreferStableBudget)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stable != KindAnnuity {
+		t.Fatalf("ровный бюджет должен указывать на аннуитет, получено %q", stable)
+	}
+	if _, _, err := RecommendScheme(comparison, "нечто"); err == nil {
+		t.Fatal("ожидалась ошибка для неизвестного предпочтения")
+	}
+}
+
+func TestCompareLoansTinyPrincipalNoNaN(t *testing.T) {
+	cfg := DefaultConfig()
+	// Прямой вызов в обход валидации обработчиков: крошечное тело не должно
+	// пронести Inf/NaN в JSON-сводку.
+	comparison, err := CompareLoans(cfg, LoanParams{Principal: 0.01, AnnualRatePercent: 12, Months: 12})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, scheme := range []string{"annuity", "differential"} {
+		summary := comparison[scheme].(map[string]any)
+		percent := summary["overpayment_percent"].(float64)
+		if math.IsNaN(percent) || math.IsInf(percent, 0) {
+			t.Fatalf("%s: overpayment_percent не должен быть NaN/Inf, получено %v", scheme, percent)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package calculations
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDailyCompoundingCostsMore(t *testing.T) {
+	cfg := DefaultConfig()
+	p := LoanParams{Principal: 1_000_000, AnnualRatePercent: 12, Months: 60}
+	start := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	daily, err := AnnuityScheduleDailyCompounding(cfg, p, start)
+	if err != nil {
+		t.Fatal(err)
+	}
+	monthly, err := AnnuitySchedule(cfg, p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Дневная капитализация чуть дороже месячной, но в пределах процента.
+	if daily.TotalInterest <= monthly.TotalInterest {
+		t.Fatalf("дневная капитализация должна быть дороже: %v <= %v", daily.TotalInterest, monthly.TotalInterest)
+	}
+	if daily.TotalInterest > monthly.TotalInterest*1.01 {
+		t.Fatalf("разница конвенций неправдоподобно велика: %v против %v", daily.TotalInterest, monthly.TotalInterest)
+	}
+	inDelta(t, daily.ExtraCostVsMonthly, daily.TotalInterest-monthly.TotalInterest, 0.01)
+	// Долг полностью погашен.
+	if last := daily.Schedule[len(daily.Schedule)-1].RemainingPrincipal; last != 0 {
+		t.Fatalf("остаток долга после последнего платежа: %v", last)
+	}
+
+	if _, err := AnnuityScheduleDailyCompounding(cfg, p, time.Time{}); err == nil {
+		t.Fatal("ожидалась ошибка без даты выдачи")
+	}
+}
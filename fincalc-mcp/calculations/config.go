@@ -0,0 +1,64 @@
+package calculations
+
+import (
+	"fmt"
+	"math"
+)
+
+// Config задает глобальные ограничения и точность расчетов.
+// Значения по умолчанию см. в DefaultConfig.
+type Config struct {
+	MaxPrincipal float64 // максимальная сумма кредита или вклада
+	MaxRate      float64 // максимальная годовая ставка, %
+	MaxMonths    int     // максимальный срок в месяцах
+	MaxBalance   float64 // потолок баланса вклада (защита от переполнения)
+	Precision    int     // знаков после запятой для денежных сумм
+	// PercentPrecision — знаков после запятой для процентных полей (ROI,
+	// доля переплаты и т.п.); задается отдельно от денежной точности, чтобы
+	// малые ставки вроде 0.05% не терялись при денежном округлении.
+	PercentPrecision int
+	// MaxExpandedCalculations ограничивает число внутренних расчетов,
+	// в которое разворачивается один составной запрос (сравнения по
+	// спискам, переборы, пакеты) — защита от запроса-бомбы.
+	MaxExpandedCalculations int
+	// OverpaymentWarningRatio — порог мягкого предупреждения о переплате:
+	// если TotalInterest превышает Principal, умноженный на порог, в ответ
+	// добавляется предупреждение (расчет не блокируется). 0 отключает.
+	OverpaymentWarningRatio float64
+}
+
+// CheckExpansion проверяет до начала работы, что составной запрос не
+// развернется в большее число расчетов, чем разрешено конфигурацией.
+func (cfg Config) CheckExpansion(count int) error {
+	if count > cfg.MaxExpandedCalculations {
+		return &ValidationError{
+			Field:   "expanded_calculations",
+			Value:   float64(count),
+			Min:     1,
+			Max:     float64(cfg.MaxExpandedCalculations),
+			Message: fmt.Sprintf("запрос разворачивается в %d расчетов при лимите %d", count, cfg.MaxExpandedCalculations),
+		}
+	}
+	return nil
+}
+
+// NegativeBalanceTolerance возвращает допуск защиты от отрицательного
+// остатка долга: половина единицы последнего денежного разряда. Допуск
+// следует за настроенной точностью, а не захардкожен под два знака.
+func (cfg Config) NegativeBalanceTolerance() float64 {
+	return 0.5 * math.Pow(10, -float64(cfg.Precision))
+}
+
+// DefaultConfig возвращает ограничения по умолчанию.
+func DefaultConfig() Config {
+	return Config{
+		MaxPrincipal:            1_000_000_000,
+		MaxRate:                 100,
+		MaxMonths:               600,
+		MaxBalance:              1e12,
+		Precision:               2,
+		PercentPrecision:        2,
+		MaxExpandedCalculations: 1000,
+		OverpaymentWarningRatio: 1.0,
+	}
+}
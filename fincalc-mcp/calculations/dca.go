@@ -0,0 +1,60 @@
+package calculations
+
+import "fmt"
+
+// DCASequence моделирует регулярные инвестиции при явно заданной
+// последовательности месячных доходностей — для анализа риска
+// последовательности: одна и та же средняя доходность в разном порядке
+// дает разные итоги, пока идут пополнения. Последовательность должна
+// покрывать срок целиком либо укладываться в него циклами.
+func DCASequence(cfg Config, initialAmount, monthlyContribution float64, monthlyReturnsPercent []float64, months int) (*CalculationResult, error) {
+	if err := CheckAmount(cfg, initialAmount); err != nil {
+		return nil, err
+	}
+	if err := CheckAmount(cfg, monthlyContribution); err != nil {
+		return nil, err
+	}
+	if initialAmount == 0 && monthlyContribution == 0 {
+		return nil, fmt.Errorf("начальная сумма и пополнение не могут быть нулевыми одновременно")
+	}
+	if err := CheckMonths(cfg, months); err != nil {
+		return nil, err
+	}
+	if len(monthlyReturnsPercent) == 0 {
+		return nil, fmt.Errorf("последовательность доходностей не должна быть пустой")
+	}
+	if months%len(monthlyReturnsPercent) != 0 {
+		return nil, fmt.Errorf("срок %d месяцев не кратен длине последовательности %d", months, len(monthlyReturnsPercent))
+	}
+	for i, r := range monthlyReturnsPercent {
+		if r < -cfg.MaxRate || r > cfg.MaxRate {
+			return nil, fmt.Errorf("доходность месяца %d вне диапазона от %.0f до %.0f", i+1, -cfg.MaxRate, cfg.MaxRate)
+		}
+	}
+	res := &CalculationResult{
+		Kind:     KindInvestment,
+		Months:   months,
+		Schedule: make([]ScheduleEntry, 0, months),
+	}
+	balance := initialAmount
+	for m := 1; m <= months; m++ {
+		rate := monthlyReturnsPercent[(m-1)%len(monthlyReturnsPercent)] / 100
+		interest := Round(balance*rate, cfg.Precision)
+		balance = Round(balance+interest+monthlyContribution, cfg.Precision)
+		if balance > cfg.MaxBalance {
+			return nil, fmt.Errorf("баланс превысил допустимый максимум в месяце %d", m)
+		}
+		res.Schedule = append(res.Schedule, ScheduleEntry{
+			Month:        m,
+			Contribution: monthlyContribution,
+			Interest:     interest,
+			Balance:      balance,
+		})
+		res.TotalContributions += monthlyContribution
+		res.TotalInterest += interest
+	}
+	res.TotalContributions = Round(res.TotalContributions, cfg.Precision)
+	res.TotalInterest = Round(res.TotalInterest, cfg.Precision)
+	res.FinalBalance = balance
+	return res, nil
+}
@@ -0,0 +1,36 @@
+package calculations
+
+import "testing"
+
+func TestDCASequenceOrderingMatters(t *testing.T) {
+	cfg := DefaultConfig()
+	// Одинаковый набор доходностей в разном порядке: просадка в конце
+	// бьет по уже накопленному капиталу сильнее, чем в начале.
+	early := []float64{-5, -5, 2, 2, 2, 2}
+	late := []float64{2, 2, 2, 2, -5, -5}
+	resEarly, err := DCASequence(cfg, 100_000, 10_000, early, 36)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resLate, err := DCASequence(cfg, 100_000, 10_000, late, 36)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resEarly.FinalBalance == resLate.FinalBalance {
+		t.Fatalf("порядок доходностей должен менять итог при пополнениях: %v == %v", resEarly.FinalBalance, resLate.FinalBalance)
+	}
+	// Без пополнений порядок множителей не важен — итоги совпадают.
+	flatEarly, err := DCASequence(cfg, 100_000, 0, early, 36)
+	if err != nil {
+		t.Fatal(err)
+	}
+	flatLate, err := DCASequence(cfg, 100_000, 0, late, 36)
+	if err != nil {
+		t.Fatal(err)
+	}
+	inDelta(t, flatEarly.FinalBalance, flatLate.FinalBalance, 1)
+
+	if _, err := DCASequence(cfg, 100_000, 0, early, 35); err == nil {
+		t.Fatal("ожидалась ошибка: срок не кратен длине последовательности")
+	}
+}
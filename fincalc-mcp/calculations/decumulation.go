@@ -0,0 +1,58 @@
+package calculations
+
+import "fmt"
+
+// DecumulationSchedule строит график траты накоплений — обратный вкладу:
+// стартовый капитал ежемесячно зарабатывает проценты, из него снимается
+// фиксированная сумма, пока он не иссякнет (или до cfg.MaxMonths, если
+// проценты покрывают снятие). В сводке Months — сколько месяцев хватило
+// капитала, LastPayment — последнее (возможно частичное) снятие,
+// FinalBalance — остаток, если капитал не иссяк.
+func DecumulationSchedule(cfg Config, initialAmount, annualRatePercent, monthlyWithdrawal float64) (*CalculationResult, error) {
+	if err := CheckAmount(cfg, initialAmount); err != nil {
+		return nil, err
+	}
+	if initialAmount == 0 {
+		return nil, fmt.Errorf("начальная сумма не может быть нулевой")
+	}
+	if err := CheckRate(cfg, annualRatePercent); err != nil {
+		return nil, err
+	}
+	if monthlyWithdrawal <= 0 || monthlyWithdrawal > cfg.MaxPrincipal {
+		return nil, errOutOfRange("monthly_withdrawal", 1, int(cfg.MaxPrincipal))
+	}
+	acc := newAccruer(cfg, annualRatePercent)
+	res := &CalculationResult{
+		Kind:              KindDecumulation,
+		AnnualRatePercent: annualRatePercent,
+		Schedule:          make([]ScheduleEntry, 0, 12),
+	}
+	balance := initialAmount
+	for m := 1; m <= cfg.MaxMonths && balance > 0; m++ {
+		interest := acc.Interest(m, balance)
+		balance = Round(balance+interest, cfg.Precision)
+		withdrawal := monthlyWithdrawal
+		// Последнее снятие может быть частичным: берем все, что осталось.
+		if withdrawal > balance {
+			withdrawal = balance
+		}
+		balance = Round(balance-withdrawal, cfg.Precision)
+		if err := acc.CheckBalanceCap(m, balance, "после снятия"); err != nil {
+			return nil, err
+		}
+		res.Schedule = append(res.Schedule, ScheduleEntry{
+			Month:    m,
+			Payment:  withdrawal,
+			Interest: interest,
+			Balance:  balance,
+		})
+		res.Months = m
+		res.LastPayment = withdrawal
+		res.TotalPayment += withdrawal
+		res.TotalInterest += interest
+	}
+	res.TotalPayment = Round(res.TotalPayment, cfg.Precision)
+	res.TotalInterest = Round(res.TotalInterest, cfg.Precision)
+	res.FinalBalance = balance
+	return res, nil
+}
@@ -0,0 +1,39 @@
+package calculations
+
+import "testing"
+
+func TestDecumulationExhausts(t *testing.T) {
+	cfg := DefaultConfig()
+	res, err := DecumulationSchedule(cfg, 100_000, 6, 10_000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.FinalBalance != 0 {
+		t.Fatalf("капитал должен иссякнуть, остаток %v", res.FinalBalance)
+	}
+	if res.Months < 10 || res.Months > 12 {
+		t.Fatalf("капитала должно хватить примерно на 11 месяцев, получено %d", res.Months)
+	}
+	if res.LastPayment <= 0 || res.LastPayment >= 10_000 {
+		t.Fatalf("последнее снятие должно быть частичным, получено %v", res.LastPayment)
+	}
+	last := res.Schedule[len(res.Schedule)-1]
+	if last.Balance != 0 {
+		t.Fatalf("баланс в последнем месяце должен быть нулевым, получено %v", last.Balance)
+	}
+}
+
+func TestDecumulationSustainable(t *testing.T) {
+	cfg := DefaultConfig()
+	// Проценты (1% в месяц) превышают снятие: капитал не иссякает.
+	res, err := DecumulationSchedule(cfg, 1_000_000, 12, 5_000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Months != cfg.MaxMonths {
+		t.Fatalf("устойчивый сценарий должен дойти до предела срока, получено %d", res.Months)
+	}
+	if res.FinalBalance <= 1_000_000 {
+		t.Fatalf("при снятии меньше процентов капитал должен расти, получено %v", res.FinalBalance)
+	}
+}
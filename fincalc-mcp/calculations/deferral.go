@@ -0,0 +1,41 @@
+package calculations
+
+// DeferralCostResult — цена отсрочки выборки кредита: комиссия за
+// обязательство, набежавшая за месяцы ожидания, поверх обычной переплаты.
+type DeferralCostResult struct {
+	DeferralMonths       int     `json:"deferral_months"`
+	CommitmentFeePercent float64 `json:"commitment_fee_percent"`
+	// FeesAccrued — комиссия на невыбранное тело за время отсрочки.
+	FeesAccrued       float64 `json:"fees_accrued"`
+	LoanTotalInterest float64 `json:"loan_total_interest"`
+	// TotalCost — проценты по кредиту плюс комиссия за отсрочку.
+	TotalCost float64 `json:"total_cost"`
+	// ExtraVsImmediate — удорожание против немедленной выборки.
+	ExtraVsImmediate float64 `json:"extra_vs_immediate"`
+}
+
+// DeferralCost считает стоимость отсрочки выборки кредита: пока тело не
+// выбрано, банк начисляет комиссию за обязательство (commitment fee) на всю
+// невыбранную сумму. Сам график после выборки не меняется, поэтому вся
+// разница против немедленного старта — набежавшая комиссия.
+func DeferralCost(cfg Config, p LoanParams, deferralMonths int, commitmentFeePercent float64) (*DeferralCostResult, error) {
+	if deferralMonths < 0 || deferralMonths > cfg.MaxMonths {
+		return nil, errOutOfRange("deferral_months", 0, cfg.MaxMonths)
+	}
+	if err := CheckRate(cfg, commitmentFeePercent); err != nil {
+		return nil, err
+	}
+	res, err := AnnuitySchedule(cfg, p)
+	if err != nil {
+		return nil, err
+	}
+	fees := Round(p.Principal*commitmentFeePercent/100/12*float64(deferralMonths), cfg.Precision)
+	return &DeferralCostResult{
+		DeferralMonths:       deferralMonths,
+		CommitmentFeePercent: commitmentFeePercent,
+		FeesAccrued:          fees,
+		LoanTotalInterest:    res.TotalInterest,
+		TotalCost:            Round(res.TotalInterest+fees, cfg.Precision),
+		ExtraVsImmediate:     fees,
+	}, nil
+}
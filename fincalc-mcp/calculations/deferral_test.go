@@ -0,0 +1,24 @@
+package calculations
+
+import "testing"
+
+func TestDeferralCost(t *testing.T) {
+	cfg := DefaultConfig()
+	p := LoanParams{Principal: 1_000_000, AnnualRatePercent: 10, Months: 60}
+	res, err := DeferralCost(cfg, p, 6, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Полгода комиссии 1% годовых на невыбранный миллион: 5000.
+	inDelta(t, res.FeesAccrued, 5000, 0.01)
+	loan, err := AnnuitySchedule(cfg, p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	inDelta(t, res.TotalCost, loan.TotalInterest+5000, 0.01)
+	inDelta(t, res.ExtraVsImmediate, 5000, 0.01)
+
+	if _, err := DeferralCost(cfg, p, -1, 1); err == nil {
+		t.Fatal("ожидалась ошибка для отрицательной отсрочки")
+	}
+}
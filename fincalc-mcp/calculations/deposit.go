@@ -0,0 +1,232 @@
+package calculations
+
+import (
+	"fmt"
+	"time"
+)
+
+// Частоты пополнения вклада.
+const (
+	ContributionMonthly  = "monthly"
+	ContributionBiweekly = "biweekly" // раз в две недели, 26 взносов в год
+)
+
+// Частоты капитализации процентов вклада.
+const (
+	CapitalizationMonthly = "monthly"
+	CapitalizationAnnual  = "annual" // проценты копятся и зачисляются раз в год
+)
+
+// DepositParams — входные параметры расчета вклада с пополнениями.
+type DepositParams struct {
+	InitialAmount           float64
+	AnnualRatePercent       float64
+	Months                  int
+	MonthlyContribution     float64
+	Capitalize              bool // капитализировать проценты (прибавлять к балансу)
+	ContributionAtBeginning bool // пополнение в начале месяца, до начисления процентов
+	// ContributionEarnsImmediately включает пополнение в базу начисления
+	// своего месяца даже при внесении в конце месяца (модель усреднения,
+	// когда взнос начинает работать сразу).
+	ContributionEarnsImmediately bool
+	// RatePeriods — график будущих ставок («промо, затем базовая»);
+	// при заполнении каждый месяц начисляется по ставке своего участка,
+	// а AnnualRatePercent игнорируется.
+	RatePeriods []RatePeriod
+	// DayCount — конвенция начисления: flat (годовая/12, по умолчанию)
+	// или actual_actual (фактические дни месяца к 365/366 — сходится
+	// с банковскими системами дневного начисления; требует StartDate).
+	DayCount  string
+	StartDate time.Time
+	// MaturityBonus — промо-бонус, зачисляемый разово при дожитии вклада
+	// до конца срока (только в последнем месяце).
+	MaturityBonus float64
+	// ContributionFrequency — частота пополнений: monthly (по умолчанию)
+	// или biweekly. При biweekly MonthlyContribution трактуется как взнос
+	// за двухнедельный период («с каждой зарплаты»), взносы ложатся на
+	// свой календарь, а капитализация остается помесячной.
+	ContributionFrequency string
+	// CapitalizationFrequency — частота зачисления процентов в баланс:
+	// monthly (по умолчанию) или annual — проценты копятся и зачисляются
+	// раз в 12 месяцев и в конце срока. Имеет смысл только при Capitalize.
+	CapitalizationFrequency string
+}
+
+func (p DepositParams) validate(cfg Config) error {
+	if err := CheckAmount(cfg, p.InitialAmount); err != nil {
+		return err
+	}
+	if err := CheckAmount(cfg, p.MonthlyContribution); err != nil {
+		return err
+	}
+	if p.InitialAmount == 0 && p.MonthlyContribution == 0 {
+		return fmt.Errorf("начальная сумма и пополнение не могут быть нулевыми одновременно")
+	}
+	if err := CheckAmount(cfg, p.MaturityBonus); err != nil {
+		return err
+	}
+	if len(p.RatePeriods) > 0 {
+		if err := validateRatePeriods(cfg, p.RatePeriods, p.Months); err != nil {
+			return err
+		}
+	} else if err := CheckRate(cfg, p.AnnualRatePercent); err != nil {
+		return err
+	}
+	switch p.DayCount {
+	case "", DayCountFlat:
+	case DayCountActual:
+		if p.StartDate.IsZero() {
+			return fmt.Errorf("конвенция %q требует даты открытия вклада (start_date)", DayCountActual)
+		}
+		if len(p.RatePeriods) > 0 {
+			return fmt.Errorf("конвенция %q несовместима с графиком ставок rate_periods", DayCountActual)
+		}
+	default:
+		return fmt.Errorf("параметр %q должен быть %q или %q", "day_count", DayCountFlat, DayCountActual)
+	}
+	switch p.ContributionFrequency {
+	case "", ContributionMonthly:
+	case ContributionBiweekly:
+		if p.ContributionAtBeginning || p.ContributionEarnsImmediately {
+			return fmt.Errorf("частота %q несовместима с флагами размещения месячного взноса", ContributionBiweekly)
+		}
+		if p.DayCount == DayCountActual {
+			return fmt.Errorf("частота %q поддерживается только для конвенции %q", ContributionBiweekly, DayCountFlat)
+		}
+	default:
+		return fmt.Errorf("параметр %q должен быть %q или %q", "contribution_frequency", ContributionMonthly, ContributionBiweekly)
+	}
+	switch p.CapitalizationFrequency {
+	case "", CapitalizationMonthly:
+	case CapitalizationAnnual:
+		if !p.Capitalize {
+			return fmt.Errorf("частота %q требует включенной капитализации", CapitalizationAnnual)
+		}
+		if p.ContributionFrequency == ContributionBiweekly {
+			return fmt.Errorf("частота %q несовместима с пополнениями %q", CapitalizationAnnual, ContributionBiweekly)
+		}
+	default:
+		return fmt.Errorf("параметр %q должен быть %q или %q", "capitalization_frequency", CapitalizationMonthly, CapitalizationAnnual)
+	}
+	return CheckMonths(cfg, p.Months)
+}
+
+// DepositSchedule строит помесячный график вклада. Без капитализации
+// начисленные проценты считаются выплачиваемыми вкладчику и не увеличивают
+// баланс, но учитываются в TotalInterest. При заданных RatePeriods в сводке
+// AnnualRatePercent — средневзвешенная по времени (смешанная) ставка.
+func DepositSchedule(cfg Config, p DepositParams) (*CalculationResult, error) {
+	if err := p.validate(cfg); err != nil {
+		return nil, err
+	}
+	acc := newAccruer(cfg, p.AnnualRatePercent)
+	res := &CalculationResult{
+		Kind:              KindDeposit,
+		AnnualRatePercent: p.AnnualRatePercent,
+		Months:            p.Months,
+		Schedule:          make([]ScheduleEntry, 0, p.Months),
+	}
+	switch {
+	case len(p.RatePeriods) > 0:
+		acc = newVariableAccruer(cfg, p.RatePeriods)
+		res.AnnualRatePercent = blendedRatePercent(p.RatePeriods, p.Months)
+	case p.DayCount == DayCountActual:
+		acc = newActualDaysAccruer(cfg, p.AnnualRatePercent, p.StartDate)
+	}
+	balance := p.InitialAmount
+	biweekly := p.ContributionFrequency == ContributionBiweekly
+	annualCap := p.Capitalize && p.CapitalizationFrequency == CapitalizationAnnual
+	// Накопленные, но еще не зачисленные проценты при годовой капитализации:
+	// внутри года они не попадают в базу начисления.
+	accrued := 0.0
+	// Шаг двухнедельного взноса в месяцах: 26 взносов на 12 месяцев.
+	const biweekStep = 12.0 / 26.0
+	paid := 0
+	for m := 1; m <= p.Months; m++ {
+		contribution := p.MonthlyContribution
+		var interest float64
+		if biweekly {
+			// Взносы месяца m ложатся на свой двухнедельный календарь:
+			// каждый успевает заработать простые проценты за остаток
+			// месяца, капитализация остается помесячной.
+			rate := acc.Rate(m)
+			contribution = 0
+			partial := 0.0
+			for {
+				t := float64(paid+1) * biweekStep
+				if t > float64(m)+1e-9 {
+					break
+				}
+				paid++
+				contribution += p.MonthlyContribution
+				partial += p.MonthlyContribution * rate * (float64(m) - t)
+			}
+			contribution = Round(contribution, cfg.Precision)
+			interest = Round(balance*rate+partial, cfg.Precision)
+			if p.Capitalize {
+				balance += interest
+				if err := acc.CheckBalanceCap(m, balance, "после начисления процентов"); err != nil {
+					return nil, err
+				}
+			}
+			balance += contribution
+			if err := acc.CheckBalanceCap(m, balance, "после пополнения"); err != nil {
+				return nil, err
+			}
+		} else {
+			if p.ContributionAtBeginning {
+				balance += contribution
+				if err := acc.CheckBalanceCap(m, balance, "после пополнения"); err != nil {
+					return nil, err
+				}
+			}
+			interestBase := balance
+			if !p.ContributionAtBeginning && p.ContributionEarnsImmediately {
+				interestBase += contribution
+			}
+			interest = acc.Interest(m, interestBase)
+			switch {
+			case annualCap:
+				accrued += interest
+				if m%12 == 0 || m == p.Months {
+					balance += accrued
+					accrued = 0
+					if err := acc.CheckBalanceCap(m, balance, "после начисления процентов"); err != nil {
+						return nil, err
+					}
+				}
+			case p.Capitalize:
+				balance += interest
+				if err := acc.CheckBalanceCap(m, balance, "после начисления процентов"); err != nil {
+					return nil, err
+				}
+			}
+			if !p.ContributionAtBeginning {
+				balance += contribution
+				if err := acc.CheckBalanceCap(m, balance, "после пополнения"); err != nil {
+					return nil, err
+				}
+			}
+		}
+		balance = Round(balance, cfg.Precision)
+		res.Schedule = append(res.Schedule, ScheduleEntry{
+			Month:        m,
+			Contribution: contribution,
+			Interest:     interest,
+			Balance:      balance,
+		})
+		res.TotalContributions += contribution
+		res.TotalInterest += interest
+	}
+	// Промо-бонус зачисляется только при дожитии до конца срока и виден
+	// в последней строке графика и в итоговом балансе.
+	if p.MaturityBonus > 0 {
+		balance = Round(balance+p.MaturityBonus, cfg.Precision)
+		res.Schedule[len(res.Schedule)-1].Balance = balance
+		res.MaturityBonus = p.MaturityBonus
+	}
+	res.TotalContributions = Round(res.TotalContributions, cfg.Precision)
+	res.TotalInterest = Round(res.TotalInterest, cfg.Precision)
+	res.FinalBalance = balance
+	return res, nil
+}
@@ -0,0 +1,299 @@
+package calculations
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDepositScheduleCapitalization(t *testing.T) {
+	cfg := DefaultConfig()
+	res, err := DepositSchedule(cfg, DepositParams{
+		InitialAmount:     100_000,
+		AnnualRatePercent: 12,
+		Months:            12,
+		Capitalize:        true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Сложный процент: 100000 * 1.01^12.
+	inDelta(t, res.FinalBalance, 112_682.50, 0.05)
+}
+
+func TestDepositScheduleWithoutCapitalization(t *testing.T) {
+	cfg := DefaultConfig()
+	res, err := DepositSchedule(cfg, DepositParams{
+		InitialAmount:     100_000,
+		AnnualRatePercent: 12,
+		Months:            12,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Простой процент: баланс не меняется, проценты выплачиваются.
+	inDelta(t, res.FinalBalance, 100_000, 1e-9)
+	inDelta(t, res.TotalInterest, 12_000, 0.01)
+}
+
+func TestCalculateInvestmentMetrics(t *testing.T) {
+	cfg := DefaultConfig()
+	res, err := CalculateInvestment(cfg, InvestmentParams{
+		InitialAmount:       100_000,
+		MonthlyContribution: 10_000,
+		AnnualReturnPercent: 12,
+		Months:              24,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	inDelta(t, res.TotalInvested, 340_000, 0.01)
+	inDelta(t, res.CapitalGain, res.FinalBalance-res.TotalInvested, 0.01)
+	if res.ROIPercent <= 0 {
+		t.Fatalf("ROI должен быть положительным, получено %v", res.ROIPercent)
+	}
+}
+
+func TestContributionEarnsImmediately(t *testing.T) {
+	cfg := DefaultConfig()
+	base := InvestmentParams{
+		InitialAmount:       100_000,
+		MonthlyContribution: 10_000,
+		AnnualReturnPercent: 12,
+		Months:              12,
+	}
+	plain, err := CalculateInvestment(cfg, base)
+	if err != nil {
+		t.Fatal(err)
+	}
+	immediate := base
+	immediate.ContributionEarnsImmediately = true
+	boosted, err := CalculateInvestment(cfg, immediate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if boosted.FinalBalance <= plain.FinalBalance {
+		t.Fatalf("взнос, работающий сразу, должен давать больший итог: %v <= %v",
+			boosted.FinalBalance, plain.FinalBalance)
+	}
+	if boosted.TotalInvested != plain.TotalInvested {
+		t.Fatal("вложенная сумма не должна зависеть от момента начисления")
+	}
+}
+
+func TestDepositRatePeriodsMatchBackToBack(t *testing.T) {
+	cfg := DefaultConfig()
+	variable, err := DepositSchedule(cfg, DepositParams{
+		InitialAmount: 100_000,
+		Months:        12,
+		Capitalize:    true,
+		RatePeriods: []RatePeriod{
+			{FromMonth: 1, AnnualRatePercent: 10},
+			{FromMonth: 7, AnnualRatePercent: 5},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Эталон: два последовательных вклада с постоянными ставками.
+	promo, err := DepositSchedule(cfg, DepositParams{
+		InitialAmount: 100_000, AnnualRatePercent: 10, Months: 6, Capitalize: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	base, err := DepositSchedule(cfg, DepositParams{
+		InitialAmount: promo.FinalBalance, AnnualRatePercent: 5, Months: 6, Capitalize: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	inDelta(t, variable.FinalBalance, base.FinalBalance, 0.01)
+	inDelta(t, variable.TotalInterest, promo.TotalInterest+base.TotalInterest, 0.01)
+	// Смешанная ставка — средневзвешенная по времени.
+	inDelta(t, variable.AnnualRatePercent, 7.5, 0.0001)
+}
+
+func TestDepositRatePeriodsValidation(t *testing.T) {
+	cfg := DefaultConfig()
+	p := DepositParams{InitialAmount: 100_000, Months: 12, Capitalize: true}
+	p.RatePeriods = []RatePeriod{{FromMonth: 2, AnnualRatePercent: 10}}
+	if _, err := DepositSchedule(cfg, p); err == nil {
+		t.Fatal("ожидалась ошибка: периоды не покрывают месяц 1")
+	}
+	p.RatePeriods = []RatePeriod{
+		{FromMonth: 1, AnnualRatePercent: 10},
+		{FromMonth: 7, AnnualRatePercent: 5},
+		{FromMonth: 4, AnnualRatePercent: 8},
+	}
+	if _, err := DepositSchedule(cfg, p); err == nil {
+		t.Fatal("ожидалась ошибка: периоды не упорядочены")
+	}
+	p.RatePeriods = []RatePeriod{{FromMonth: 1, AnnualRatePercent: -1}}
+	if _, err := DepositSchedule(cfg, p); err == nil {
+		t.Fatal("ожидалась ошибка для отрицательной ставки периода")
+	}
+}
+
+func TestDepositActualDayCountLeapFebruary(t *testing.T) {
+	cfg := DefaultConfig()
+	start := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	actual, err := DepositSchedule(cfg, DepositParams{
+		InitialAmount:     1_000_000,
+		AnnualRatePercent: 12,
+		Months:            3,
+		Capitalize:        true,
+		DayCount:          DayCountActual,
+		StartDate:         start,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	flat, err := DepositSchedule(cfg, DepositParams{
+		InitialAmount: 1_000_000, AnnualRatePercent: 12, Months: 3, Capitalize: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Февраль високосного 2024 года: 29 дней из 366 против плоских 1/12.
+	inDelta(t, actual.Schedule[0].Interest, Round(1_000_000*0.12*29/366, cfg.Precision), 0.01)
+	if actual.Schedule[0].Interest >= flat.Schedule[0].Interest {
+		t.Fatalf("короткий февраль должен давать меньше процентов: %v >= %v",
+			actual.Schedule[0].Interest, flat.Schedule[0].Interest)
+	}
+	// Март (31 день) — наоборот, больше плоского начисления.
+	if actual.Schedule[1].Interest <= flat.Schedule[1].Interest {
+		t.Fatalf("длинный март должен давать больше процентов: %v <= %v",
+			actual.Schedule[1].Interest, flat.Schedule[1].Interest)
+	}
+}
+
+func TestDepositDayCountValidation(t *testing.T) {
+	cfg := DefaultConfig()
+	p := DepositParams{InitialAmount: 100_000, AnnualRatePercent: 10, Months: 12, DayCount: DayCountActual}
+	if _, err := DepositSchedule(cfg, p); err == nil {
+		t.Fatal("ожидалась ошибка: actual_actual без даты открытия")
+	}
+	p.DayCount = "thirty_360"
+	if _, err := DepositSchedule(cfg, p); err == nil {
+		t.Fatal("ожидалась ошибка для неизвестной конвенции")
+	}
+}
+
+func TestDepositMaturityBonus(t *testing.T) {
+	cfg := DefaultConfig()
+	base := DepositParams{InitialAmount: 100_000, AnnualRatePercent: 10, Months: 12, Capitalize: true}
+	plain, err := DepositSchedule(cfg, base)
+	if err != nil {
+		t.Fatal(err)
+	}
+	withBonus := base
+	withBonus.MaturityBonus = 5_000
+	bonus, err := DepositSchedule(cfg, withBonus)
+	if err != nil {
+		t.Fatal(err)
+	}
+	inDelta(t, bonus.MaturityBonus, 5_000, 0.001)
+	inDelta(t, bonus.FinalBalance, plain.FinalBalance+5_000, 0.01)
+	// Бонус виден только в последней строке графика.
+	for i := range bonus.Schedule[:11] {
+		inDelta(t, bonus.Schedule[i].Balance, plain.Schedule[i].Balance, 0.001)
+	}
+	inDelta(t, bonus.Schedule[11].Balance, plain.Schedule[11].Balance+5_000, 0.01)
+	// Эффективная доходность (рост к вложенному) выше с бонусом.
+	plainYield := plain.FinalBalance / 100_000
+	bonusYield := bonus.FinalBalance / 100_000
+	if bonusYield <= plainYield {
+		t.Fatalf("бонус должен повышать доходность: %v <= %v", bonusYield, plainYield)
+	}
+	if _, err := DepositSchedule(cfg, DepositParams{
+		InitialAmount: 100_000, AnnualRatePercent: 10, Months: 12, MaturityBonus: -1,
+	}); err == nil {
+		t.Fatal("ожидалась ошибка для отрицательного бонуса")
+	}
+}
+
+func TestDepositBiweeklyBeatsMonthlyEquivalent(t *testing.T) {
+	cfg := DefaultConfig()
+	// Одинаковая годовая сумма взносов: 26 раз по 10 000 против 12 раз
+	// по 10 000*26/12. Двухнедельные взносы начинают работать раньше.
+	biweekly, err := DepositSchedule(cfg, DepositParams{
+		InitialAmount:         100_000,
+		AnnualRatePercent:     12,
+		Months:                12,
+		MonthlyContribution:   10_000,
+		Capitalize:            true,
+		ContributionFrequency: ContributionBiweekly,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	monthly, err := DepositSchedule(cfg, DepositParams{
+		InitialAmount:       100_000,
+		AnnualRatePercent:   12,
+		Months:              12,
+		MonthlyContribution: 10_000 * 26.0 / 12.0,
+		Capitalize:          true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	inDelta(t, biweekly.TotalContributions, monthly.TotalContributions, 0.5)
+	if biweekly.TotalInterest <= monthly.TotalInterest {
+		t.Fatalf("двухнедельные взносы должны давать больше процентов: %v <= %v",
+			biweekly.TotalInterest, monthly.TotalInterest)
+	}
+	if biweekly.FinalBalance <= monthly.FinalBalance {
+		t.Fatalf("итоговый баланс при biweekly должен быть выше: %v <= %v",
+			biweekly.FinalBalance, monthly.FinalBalance)
+	}
+}
+
+func TestDepositContributionFrequencyValidation(t *testing.T) {
+	cfg := DefaultConfig()
+	base := DepositParams{
+		InitialAmount:       100_000,
+		AnnualRatePercent:   10,
+		Months:              12,
+		MonthlyContribution: 5_000,
+		Capitalize:          true,
+	}
+	p := base
+	p.ContributionFrequency = "weekly"
+	if _, err := DepositSchedule(cfg, p); err == nil {
+		t.Fatal("ожидалась ошибка для неизвестной частоты пополнений")
+	}
+	p = base
+	p.ContributionFrequency = ContributionBiweekly
+	p.ContributionAtBeginning = true
+	if _, err := DepositSchedule(cfg, p); err == nil {
+		t.Fatal("ожидалась ошибка: biweekly несовместим с пополнением в начале месяца")
+	}
+}
+
+func TestDepositBalanceCapNamesOperation(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.MaxBalance = 1_000_000
+	// Огромное разовое пополнение пробивает предел на шаге пополнения.
+	_, err := DepositSchedule(cfg, DepositParams{
+		InitialAmount:           500_000,
+		AnnualRatePercent:       5,
+		Months:                  12,
+		MonthlyContribution:     600_000,
+		Capitalize:              true,
+		ContributionAtBeginning: true,
+	})
+	if err == nil || !strings.Contains(err.Error(), "после пополнения") {
+		t.Fatalf("ожидалась ошибка с указанием на пополнение, получено %v", err)
+	}
+	// Предел, пробитый капитализацией процентов, называет именно проценты.
+	_, err = DepositSchedule(cfg, DepositParams{
+		InitialAmount:     999_999,
+		AnnualRatePercent: 50,
+		Months:            12,
+		Capitalize:        true,
+	})
+	if err == nil || !strings.Contains(err.Error(), "после начисления процентов") {
+		t.Fatalf("ожидалась ошибка с указанием на проценты, получено %v", err)
+	}
+}
@@ -0,0 +1,71 @@
+package calculations
+
+// DepositVsFlexiblePoint — сравнение выхода из срочного вклада в конце
+// месяца с гибким счетом: что на руках в каждом из путей.
+type DepositVsFlexiblePoint struct {
+	Month int `json:"month"`
+	// DepositExitValue — сумма на руках при выходе из вклада в этом месяце:
+	// внутри штрафного окна проценты пересчитаны по штрафной ставке,
+	// после — действует договорная.
+	DepositExitValue float64 `json:"deposit_exit_value"`
+	FlexibleBalance  float64 `json:"flexible_balance"`
+	Advantage        float64 `json:"advantage"`
+}
+
+// DepositVsFlexible отвечает на вопрос «когда срочный вклад обгонит гибкий
+// счет»: помесячно сравнивается выход из вклада (со штрафным пересчетом
+// процентов в первые penaltyMonths месяцев, как при досрочном закрытии)
+// с балансом гибкого счета по меньшей ставке. CrossoverMonth — первый
+// месяц, в котором выход из вклада не хуже гибкого счета; -1, если вклад
+// так и не обгоняет гибкий счет за срок.
+func DepositVsFlexible(cfg Config, p DepositParams, penaltyRatePercent float64, penaltyMonths int, flexibleRatePercent float64) (map[string]any, error) {
+	if penaltyMonths < 0 || penaltyMonths > p.Months {
+		return nil, errOutOfRange("penalty_months", 0, p.Months)
+	}
+	if err := CheckRate(cfg, flexibleRatePercent); err != nil {
+		return nil, err
+	}
+	if err := cfg.CheckExpansion(p.Months); err != nil {
+		return nil, err
+	}
+	contract, err := DepositSchedule(cfg, p)
+	if err != nil {
+		return nil, err
+	}
+	penaltyParams := p
+	penaltyParams.AnnualRatePercent = penaltyRatePercent
+	penaltyParams.RatePeriods = nil
+	penalty, err := DepositSchedule(cfg, penaltyParams)
+	if err != nil {
+		return nil, err
+	}
+	flexParams := penaltyParams
+	flexParams.AnnualRatePercent = flexibleRatePercent
+	flexible, err := DepositSchedule(cfg, flexParams)
+	if err != nil {
+		return nil, err
+	}
+	points := make([]DepositVsFlexiblePoint, 0, p.Months)
+	crossover := -1
+	for i := range contract.Schedule {
+		m := contract.Schedule[i].Month
+		exit := contract.Schedule[i].Balance
+		if m <= penaltyMonths {
+			exit = penalty.Schedule[i].Balance
+		}
+		flex := flexible.Schedule[i].Balance
+		points = append(points, DepositVsFlexiblePoint{
+			Month:            m,
+			DepositExitValue: exit,
+			FlexibleBalance:  flex,
+			Advantage:        Round(exit-flex, cfg.Precision),
+		})
+		if crossover == -1 && exit >= flex {
+			crossover = m
+		}
+	}
+	return map[string]any{
+		"crossover_month": crossover,
+		"points":          points,
+	}, nil
+}
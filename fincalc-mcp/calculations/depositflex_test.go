@@ -0,0 +1,44 @@
+package calculations
+
+import "testing"
+
+func TestDepositVsFlexible(t *testing.T) {
+	cfg := DefaultConfig()
+	p := DepositParams{
+		InitialAmount:     1_000_000,
+		AnnualRatePercent: 10,
+		Months:            12,
+		Capitalize:        true,
+	}
+	// Штрафное окно 6 месяцев с полной потерей процентов: внутри окна
+	// гибкий счет под 4% впереди, после окна вклад сразу обгоняет.
+	out, err := DepositVsFlexible(cfg, p, 0, 6, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := out["crossover_month"].(int); got != 7 {
+		t.Fatalf("ожидался перелом в месяце 7, получен %d", got)
+	}
+	points := out["points"].([]DepositVsFlexiblePoint)
+	for _, pt := range points {
+		if pt.Month <= 6 && pt.Advantage >= 0 {
+			t.Fatalf("внутри штрафного окна вклад не должен быть впереди: месяц %d", pt.Month)
+		}
+		if pt.Month > 6 && pt.Advantage <= 0 {
+			t.Fatalf("после штрафного окна вклад должен быть впереди: месяц %d", pt.Month)
+		}
+	}
+
+	// Если гибкая ставка не уступает договорной, вклад не обгоняет никогда.
+	never, err := DepositVsFlexible(cfg, p, 0, 12, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := never["crossover_month"].(int); got != -1 {
+		t.Fatalf("ожидался -1 без перелома, получен %d", got)
+	}
+
+	if _, err := DepositVsFlexible(cfg, p, 0, 13, 4); err == nil {
+		t.Fatal("ожидалась ошибка для штрафного окна длиннее срока")
+	}
+}
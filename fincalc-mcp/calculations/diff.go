@@ -0,0 +1,65 @@
+package calculations
+
+import "fmt"
+
+// ScheduleDeltaEntry — помесячная разница двух графиков (второй минус
+// первый). Для месяцев за пределами короткого графика его значения
+// считаются нулевыми.
+type ScheduleDeltaEntry struct {
+	Month                   int     `json:"month"`
+	PaymentDelta            float64 `json:"payment_delta,omitempty"`
+	InterestDelta           float64 `json:"interest_delta"`
+	RemainingPrincipalDelta float64 `json:"remaining_principal_delta,omitempty"`
+	ContributionDelta       float64 `json:"contribution_delta,omitempty"`
+	BalanceDelta            float64 `json:"balance_delta,omitempty"`
+}
+
+// ScheduleDiff — разница двух сценариев одного вида: сводные дельты и
+// помесячная детализация для представления «до/после».
+type ScheduleDiff struct {
+	Kind               string               `json:"kind"`
+	Months             int                  `json:"months"`
+	TotalPaymentDelta  float64              `json:"total_payment_delta,omitempty"`
+	TotalInterestDelta float64              `json:"total_interest_delta"`
+	FinalBalanceDelta  float64              `json:"final_balance_delta,omitempty"`
+	Entries            []ScheduleDeltaEntry `json:"entries"`
+}
+
+// DiffSchedules считает помесячные и сводные дельты второго графика
+// относительно первого. Виды расчетов должны совпадать; выравнивание —
+// по длинному графику.
+func DiffSchedules(cfg Config, base, other *CalculationResult) (*ScheduleDiff, error) {
+	if base.Kind != other.Kind {
+		return nil, fmt.Errorf("нельзя сравнивать графики разных видов: %q и %q", base.Kind, other.Kind)
+	}
+	months := len(base.Schedule)
+	if len(other.Schedule) > months {
+		months = len(other.Schedule)
+	}
+	diff := &ScheduleDiff{
+		Kind:               base.Kind,
+		Months:             months,
+		TotalPaymentDelta:  Round(other.TotalPayment-base.TotalPayment, cfg.Precision),
+		TotalInterestDelta: Round(other.TotalInterest-base.TotalInterest, cfg.Precision),
+		FinalBalanceDelta:  Round(other.FinalBalance-base.FinalBalance, cfg.Precision),
+		Entries:            make([]ScheduleDeltaEntry, 0, months),
+	}
+	at := func(schedule []ScheduleEntry, i int) ScheduleEntry {
+		if i < len(schedule) {
+			return schedule[i]
+		}
+		return ScheduleEntry{}
+	}
+	for i := 0; i < months; i++ {
+		b, o := at(base.Schedule, i), at(other.Schedule, i)
+		diff.Entries = append(diff.Entries, ScheduleDeltaEntry{
+			Month:                   i + 1,
+			PaymentDelta:            Round(o.Payment-b.Payment, cfg.Precision),
+			InterestDelta:           Round(o.Interest-b.Interest, cfg.Precision),
+			RemainingPrincipalDelta: Round(o.RemainingPrincipal-b.RemainingPrincipal, cfg.Precision),
+			ContributionDelta:       Round(o.Contribution-b.Contribution, cfg.Precision),
+			BalanceDelta:            Round(o.Balance-b.Balance, cfg.Precision),
+		})
+	}
+	return diff, nil
+}
@@ -0,0 +1,64 @@
+package calculations
+
+import "testing"
+
+func TestDiffSchedulesLowerRate(t *testing.T) {
+	cfg := DefaultConfig()
+	base, err := AnnuitySchedule(cfg, LoanParams{Principal: 1_000_000, AnnualRatePercent: 12, Months: 60})
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := AnnuitySchedule(cfg, LoanParams{Principal: 1_000_000, AnnualRatePercent: 10, Months: 60})
+	if err != nil {
+		t.Fatal(err)
+	}
+	diff, err := DiffSchedules(cfg, base, other)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff.TotalInterestDelta >= 0 {
+		t.Fatalf("снижение ставки должно уменьшать переплату, получено %v", diff.TotalInterestDelta)
+	}
+	// Проценты ниже в каждом месяце.
+	for _, entry := range diff.Entries {
+		if entry.InterestDelta > 0 {
+			t.Fatalf("месяц %d: проценты при меньшей ставке не могут быть выше (%v)", entry.Month, entry.InterestDelta)
+		}
+	}
+}
+
+func TestDiffSchedulesAlignsOnLonger(t *testing.T) {
+	cfg := DefaultConfig()
+	short, err := AnnuitySchedule(cfg, LoanParams{Principal: 1_000_000, AnnualRatePercent: 12, Months: 12})
+	if err != nil {
+		t.Fatal(err)
+	}
+	long, err := AnnuitySchedule(cfg, LoanParams{Principal: 1_000_000, AnnualRatePercent: 12, Months: 18})
+	if err != nil {
+		t.Fatal(err)
+	}
+	diff, err := DiffSchedules(cfg, short, long)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff.Months != 18 || len(diff.Entries) != 18 {
+		t.Fatalf("выравнивание должно идти по длинному графику, получено %d", diff.Months)
+	}
+	// За пределами короткого графика дельта равна платежу длинного.
+	inDelta(t, diff.Entries[17].PaymentDelta, long.Schedule[17].Payment, 0.01)
+}
+
+func TestDiffSchedulesKindMismatch(t *testing.T) {
+	cfg := DefaultConfig()
+	loan, err := AnnuitySchedule(cfg, LoanParams{Principal: 1_000_000, AnnualRatePercent: 12, Months: 12})
+	if err != nil {
+		t.Fatal(err)
+	}
+	deposit, err := DepositSchedule(cfg, DepositParams{InitialAmount: 100_000, AnnualRatePercent: 10, Months: 12})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := DiffSchedules(cfg, loan, deposit); err == nil {
+		t.Fatal("ожидалась ошибка для графиков разных видов")
+	}
+}
@@ -0,0 +1,48 @@
+package calculations
+
+// DifferentialSchedule строит помесячный график дифференцированного кредита:
+// основной долг гасится равными долями, проценты начисляются на остаток.
+func DifferentialSchedule(cfg Config, p LoanParams) (*CalculationResult, error) {
+	if err := p.validate(cfg); err != nil {
+		return nil, err
+	}
+	acc := newAccruer(cfg, p.AnnualRatePercent)
+	principalPart := Round(p.Principal/float64(p.Months), cfg.Precision)
+	res := &CalculationResult{
+		Kind:              KindDifferential,
+		Principal:         p.Principal,
+		AnnualRatePercent: p.AnnualRatePercent,
+		Months:            p.Months,
+		Schedule:          make([]ScheduleEntry, 0, p.Months),
+	}
+	balance := p.Principal
+	for m := 1; m <= p.Months; m++ {
+		part := principalPart
+		// Последняя доля гасит остаток целиком, закрывая накопленную
+		// ошибку округления.
+		if m == p.Months {
+			part = Round(balance, cfg.Precision)
+		}
+		interest := acc.Interest(m, balance)
+		var err error
+		if balance, err = acc.ReduceDebt(m, balance, part); err != nil {
+			return nil, err
+		}
+		pay := Round(part+interest, cfg.Precision)
+		res.Schedule = append(res.Schedule, ScheduleEntry{
+			Month:              m,
+			Payment:            pay,
+			PrincipalComponent: part,
+			Interest:           interest,
+			RemainingPrincipal: balance,
+		})
+		res.TotalPayment += pay
+		res.TotalInterest += interest
+	}
+	res.FirstPayment = res.Schedule[0].Payment
+	res.LastPayment = res.Schedule[len(res.Schedule)-1].Payment
+	res.TotalPayment = Round(res.TotalPayment, cfg.Precision)
+	res.TotalInterest = Round(res.TotalInterest, cfg.Precision)
+	fillPaymentShares(cfg, res.Schedule)
+	return res, nil
+}
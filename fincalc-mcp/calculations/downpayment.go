@@ -0,0 +1,42 @@
+package calculations
+
+// DownPaymentPlan — план накопления первоначального взноса: требуемое
+// ежемесячное пополнение, прогнозный график и максимальная цена жилья
+// при целевом LTV (доле кредита в стоимости).
+type DownPaymentPlan struct {
+	TargetAmount         float64            `json:"target_amount"`
+	RequiredContribution float64            `json:"required_contribution"`
+	TargetLTVPercent     float64            `json:"target_ltv_percent"`
+	MaxHomePrice         float64            `json:"max_home_price"`
+	Projection           *CalculationResult `json:"projection"`
+}
+
+// PlanDownPayment подбирает ежемесячное пополнение для накопления
+// первоначального взноса и выводит максимальную цену жилья: взнос
+// покрывает (100 − LTV) процентов стоимости.
+func PlanDownPayment(cfg Config, targetAmount, currentSavings, annualRatePercent float64, months int, targetLTVPercent float64) (*DownPaymentPlan, error) {
+	if targetLTVPercent < 0 || targetLTVPercent >= 100 {
+		return nil, errOutOfRange("target_ltv_percent", 0, 99)
+	}
+	contribution, err := RequiredContribution(cfg, currentSavings, targetAmount, annualRatePercent, months, false)
+	if err != nil {
+		return nil, err
+	}
+	projection, err := DepositSchedule(cfg, DepositParams{
+		InitialAmount:       currentSavings,
+		AnnualRatePercent:   annualRatePercent,
+		Months:              months,
+		MonthlyContribution: contribution,
+		Capitalize:          true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &DownPaymentPlan{
+		TargetAmount:         targetAmount,
+		RequiredContribution: contribution,
+		TargetLTVPercent:     targetLTVPercent,
+		MaxHomePrice:         Round(targetAmount/(1-targetLTVPercent/100), cfg.Precision),
+		Projection:           projection,
+	}, nil
+}
@@ -0,0 +1,58 @@
+package calculations
+
+import "testing"
+
+func TestRequiredContributionReachesTarget(t *testing.T) {
+	cfg := DefaultConfig()
+	contribution, err := RequiredContribution(cfg, 100_000, 1_000_000, 8, 36, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if contribution <= 0 {
+		t.Fatalf("ожидалось положительное пополнение, получено %v", contribution)
+	}
+	res, err := DepositSchedule(cfg, DepositParams{
+		InitialAmount:       100_000,
+		AnnualRatePercent:   8,
+		Months:              36,
+		MonthlyContribution: contribution,
+		Capitalize:          true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Подобранное пополнение достигает цели с точностью до копеек.
+	inDelta(t, res.FinalBalance, 1_000_000, 1)
+}
+
+func TestRequiredContributionZeroWhenCovered(t *testing.T) {
+	cfg := DefaultConfig()
+	contribution, err := RequiredContribution(cfg, 1_000_000, 500_000, 8, 12, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if contribution != 0 {
+		t.Fatalf("цель уже покрыта начальной суммой, ожидалось 0, получено %v", contribution)
+	}
+}
+
+func TestPlanDownPayment(t *testing.T) {
+	cfg := DefaultConfig()
+	plan, err := PlanDownPayment(cfg, 2_000_000, 300_000, 8, 24, 80)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plan.RequiredContribution <= 0 {
+		t.Fatalf("ожидалось положительное пополнение, получено %v", plan.RequiredContribution)
+	}
+	inDelta(t, plan.Projection.FinalBalance, 2_000_000, 1)
+	// Взнос 2 млн при LTV 80% покрывает 20% стоимости: максимум 10 млн.
+	inDelta(t, plan.MaxHomePrice, 10_000_000, 0.01)
+}
+
+func TestPlanDownPaymentValidatesLTV(t *testing.T) {
+	cfg := DefaultConfig()
+	if _, err := PlanDownPayment(cfg, 2_000_000, 0, 8, 24, 100); err == nil {
+		t.Fatal("ожидалась ошибка для LTV 100%")
+	}
+}
@@ -0,0 +1,54 @@
+package calculations
+
+import "fmt"
+
+// DefaultDTIThresholdPercent — порог долговой нагрузки по умолчанию:
+// выше 43% от дохода кредиторы обычно считают заемщика перегруженным.
+const DefaultDTIThresholdPercent = 43.0
+
+// DTIResult — оценка долговой нагрузки домохозяйства: доля дохода,
+// уходящая на обслуживание долгов, и запас до порога.
+type DTIResult struct {
+	MonthlyIncome     float64 `json:"monthly_income"`
+	TotalDebtPayments float64 `json:"total_debt_payments"`
+	DTIPercent        float64 `json:"dti_percent"`
+	ThresholdPercent  float64 `json:"threshold_percent"`
+	ExceedsThreshold  bool    `json:"exceeds_threshold"`
+	// HeadroomPayment — на сколько еще можно увеличить ежемесячные платежи,
+	// не превысив порог; отрицательное значение — перебор.
+	HeadroomPayment float64 `json:"headroom_payment"`
+}
+
+// DebtToIncome считает отношение суммарных ежемесячных платежей по долгам
+// к валовому доходу (DTI) и сравнивает его с порогом. Платежи обычно
+// берутся из результатов кредитных калькуляторов (MonthlyPayment).
+func DebtToIncome(cfg Config, monthlyIncome float64, debtPayments []float64, thresholdPercent float64) (*DTIResult, error) {
+	if monthlyIncome <= 0 || monthlyIncome > cfg.MaxPrincipal {
+		return nil, &ValidationError{
+			Field:   "monthly_income",
+			Value:   monthlyIncome,
+			Min:     0,
+			Max:     cfg.MaxPrincipal,
+			Message: fmt.Sprintf("доход должен быть больше 0 и не более %.0f", cfg.MaxPrincipal),
+		}
+	}
+	if thresholdPercent <= 0 || thresholdPercent > 100 {
+		return nil, errOutOfRange("threshold_percent", 1, 100)
+	}
+	total := 0.0
+	for _, payment := range debtPayments {
+		if err := CheckAmount(cfg, payment); err != nil {
+			return nil, err
+		}
+		total += payment
+	}
+	dti := total / monthlyIncome * 100
+	return &DTIResult{
+		MonthlyIncome:     monthlyIncome,
+		TotalDebtPayments: Round(total, cfg.Precision),
+		DTIPercent:        Round(dti, cfg.Precision),
+		ThresholdPercent:  thresholdPercent,
+		ExceedsThreshold:  dti > thresholdPercent,
+		HeadroomPayment:   Round(monthlyIncome*thresholdPercent/100-total, cfg.Precision),
+	}, nil
+}
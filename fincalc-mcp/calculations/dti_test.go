@@ -0,0 +1,58 @@
+package calculations
+
+import "testing"
+
+func TestDebtToIncomeBelowThreshold(t *testing.T) {
+	cfg := DefaultConfig()
+	res, err := DebtToIncome(cfg, 100_000, []float64{20_000, 10_000}, DefaultDTIThresholdPercent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	inDelta(t, res.DTIPercent, 30, 0.01)
+	if res.ExceedsThreshold {
+		t.Fatal("нагрузка 30% не должна превышать порог 43%")
+	}
+	inDelta(t, res.HeadroomPayment, 13_000, 0.01)
+}
+
+func TestDebtToIncomeAtThreshold(t *testing.T) {
+	cfg := DefaultConfig()
+	// Ровно на пороге — еще не превышение.
+	res, err := DebtToIncome(cfg, 100_000, []float64{43_000}, DefaultDTIThresholdPercent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	inDelta(t, res.DTIPercent, 43, 0.01)
+	if res.ExceedsThreshold {
+		t.Fatal("нагрузка ровно на пороге не считается превышением")
+	}
+	inDelta(t, res.HeadroomPayment, 0, 0.01)
+}
+
+func TestDebtToIncomeAboveThreshold(t *testing.T) {
+	cfg := DefaultConfig()
+	res, err := DebtToIncome(cfg, 100_000, []float64{30_000, 20_000}, DefaultDTIThresholdPercent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	inDelta(t, res.DTIPercent, 50, 0.01)
+	if !res.ExceedsThreshold {
+		t.Fatal("нагрузка 50% должна превышать порог 43%")
+	}
+	if res.HeadroomPayment >= 0 {
+		t.Fatalf("запас при превышении должен быть отрицательным, получено %v", res.HeadroomPayment)
+	}
+}
+
+func TestDebtToIncomeValidation(t *testing.T) {
+	cfg := DefaultConfig()
+	if _, err := DebtToIncome(cfg, 0, []float64{10_000}, DefaultDTIThresholdPercent); err == nil {
+		t.Fatal("ожидалась ошибка для нулевого дохода")
+	}
+	if _, err := DebtToIncome(cfg, 100_000, []float64{-1}, DefaultDTIThresholdPercent); err == nil {
+		t.Fatal("ожидалась ошибка для отрицательного платежа")
+	}
+	if _, err := DebtToIncome(cfg, 100_000, []float64{10_000}, 0); err == nil {
+		t.Fatal("ожидалась ошибка для нулевого порога")
+	}
+}
@@ -0,0 +1,21 @@
+package calculations
+
+import "math"
+
+// LoanDuration возвращает дюрацию Маколея графика платежей в месяцах:
+// средневзвешенное по приведенной стоимости время платежей. Ставка
+// дисконтирования — годовая номинальная, в процентах. При нулевой ставке
+// результат вырождается в простое среднее время платежей.
+func LoanDuration(result *CalculationResult, annualRatePercent float64) float64 {
+	r := MonthlyRate(annualRatePercent)
+	var pvSum, weighted float64
+	for _, entry := range result.Schedule {
+		pv := entry.Payment / math.Pow(1+r, float64(entry.Month))
+		pvSum += pv
+		weighted += pv * float64(entry.Month)
+	}
+	if pvSum == 0 {
+		return 0
+	}
+	return weighted / pvSum
+}
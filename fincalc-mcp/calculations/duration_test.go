@@ -0,0 +1,31 @@
+package calculations
+
+import "testing"
+
+func TestLoanDurationZeroRate(t *testing.T) {
+	// Два равных платежа без дисконтирования: среднее время — 1.5 месяца.
+	res := &CalculationResult{
+		Kind: KindAnnuity,
+		Schedule: []ScheduleEntry{
+			{Month: 1, Payment: 100},
+			{Month: 2, Payment: 100},
+		},
+	}
+	inDelta(t, LoanDuration(res, 0), 1.5, 1e-9)
+}
+
+func TestLoanDurationKnownSchedule(t *testing.T) {
+	// Дисконтирование смещает вес к ранним платежам: дюрация меньше
+	// простого среднего, но положительна.
+	res := &CalculationResult{
+		Kind: KindAnnuity,
+		Schedule: []ScheduleEntry{
+			{Month: 1, Payment: 100},
+			{Month: 2, Payment: 100},
+		},
+	}
+	d := LoanDuration(res, 12)
+	if d >= 1.5 || d <= 1 {
+		t.Fatalf("дюрация %v должна лежать между 1 и 1.5", d)
+	}
+}
@@ -0,0 +1,88 @@
+package calculations
+
+import (
+	"math/big"
+	"strconv"
+)
+
+// ratRounded переводит точное рациональное значение в float64 с денежным
+// округлением. Поскольку исходное значение точное, ошибка ограничена одним
+// преобразованием и не накапливается.
+func ratRounded(x *big.Rat, precision int) float64 {
+	f, _ := x.Float64()
+	return Round(f, precision)
+}
+
+// ratFromFloat строит рациональное число из десятичной записи float64:
+// 13.7 превращается в 137/10, а не в двоичную дробь с огромным
+// знаменателем — это соответствует десятичной семантике ввода и держит
+// знаменатели маленькими.
+func ratFromFloat(f float64) *big.Rat {
+	x, _ := new(big.Rat).SetString(strconv.FormatFloat(f, 'f', -1, 64))
+	return x
+}
+
+// AnnuityScheduleExact строит график аннуитетного кредита в точной
+// рациональной арифметике (math/big.Rat): баланс и платеж считаются без
+// накопленного дрейфа float64, округление происходит только при выводе.
+// Путь заметно медленнее и предназначен для аудиторской сверки.
+func AnnuityScheduleExact(cfg Config, p LoanParams) (*CalculationResult, error) {
+	if err := p.validate(cfg); err != nil {
+		return nil, err
+	}
+	principal := ratFromFloat(p.Principal)
+	months := int64(p.Months)
+	// Месячная ставка как точная дробь: процент / 1200.
+	r := ratFromFloat(p.AnnualRatePercent)
+	r.Quo(r, big.NewRat(1200, 1))
+
+	payment := new(big.Rat)
+	if r.Sign() == 0 {
+		payment.Quo(principal, big.NewRat(months, 1))
+	} else {
+		// payment = P * r * (1+r)^n / ((1+r)^n - 1) — рационально точно.
+		growth := new(big.Rat).Add(big.NewRat(1, 1), r)
+		pow := new(big.Rat).SetInt(new(big.Int).Exp(growth.Num(), big.NewInt(months), nil))
+		pow.Quo(pow, new(big.Rat).SetInt(new(big.Int).Exp(growth.Denom(), big.NewInt(months), nil)))
+		payment.Mul(principal, r)
+		payment.Mul(payment, pow)
+		payment.Quo(payment, new(big.Rat).Sub(pow, big.NewRat(1, 1)))
+	}
+
+	res := &CalculationResult{
+		Kind:              KindAnnuity,
+		Principal:         p.Principal,
+		AnnualRatePercent: p.AnnualRatePercent,
+		Months:            p.Months,
+		MonthlyPayment:    ratRounded(payment, cfg.Precision),
+		Schedule:          make([]ScheduleEntry, 0, p.Months),
+	}
+	balance := new(big.Rat).Set(principal)
+	totalPayment := new(big.Rat)
+	totalInterest := new(big.Rat)
+	for m := 1; m <= p.Months; m++ {
+		interest := new(big.Rat).Mul(balance, r)
+		principalPart := new(big.Rat).Sub(payment, interest)
+		// Последний платеж гасит остаток целиком — в точной арифметике
+		// расхождение нулевое, но форма вывода совпадает с float64-путем.
+		if m == p.Months {
+			principalPart.Set(balance)
+		}
+		balance.Sub(balance, principalPart)
+		pay := new(big.Rat).Add(principalPart, interest)
+		res.Schedule = append(res.Schedule, ScheduleEntry{
+			Month:              m,
+			Payment:            ratRounded(pay, cfg.Precision),
+			PrincipalComponent: ratRounded(principalPart, cfg.Precision),
+			Interest:           ratRounded(interest, cfg.Precision),
+			RemainingPrincipal: ratRounded(balance, cfg.Precision),
+		})
+		totalPayment.Add(totalPayment, pay)
+		totalInterest.Add(totalInterest, interest)
+	}
+	res.TotalPayment = ratRounded(totalPayment, cfg.Precision)
+	res.TotalInterest = ratRounded(totalInterest, cfg.Precision)
+	res.CrossoverMonth = CrossoverMonth(res.Schedule)
+	fillPaymentShares(cfg, res.Schedule)
+	return res, nil
+}
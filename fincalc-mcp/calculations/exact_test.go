@@ -0,0 +1,64 @@
+package calculations
+
+import (
+	"math"
+	"testing"
+)
+
+func TestAnnuityScheduleExactMatchesFloat(t *testing.T) {
+	cfg := DefaultConfig()
+	p := LoanParams{Principal: 5_000_000, AnnualRatePercent: 13.7, Months: 360}
+	fast, err := AnnuitySchedule(cfg, p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exact, err := AnnuityScheduleExact(cfg, p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Платеж совпадает в пределах единицы округления; строки графика
+	// могут расходиться на накопленную за прошедшие месяцы ошибку
+	// округления float64-пути: до половины единицы в месяц, причем
+	// расхождение остатка само обрастает процентами.
+	unit := math.Pow(10, -float64(cfg.Precision))
+	r := p.AnnualRatePercent / 100 / 12
+	inDelta(t, exact.MonthlyPayment, fast.MonthlyPayment, unit)
+	for i := range exact.Schedule {
+		allowed := unit * float64(i+1) * math.Pow(1+r, float64(i+1))
+		if diff := math.Abs(exact.Schedule[i].Interest - fast.Schedule[i].Interest); diff > allowed {
+			t.Fatalf("месяц %d: расхождение процентов %v превышает накопленный допуск %v", i+1, diff, allowed)
+		}
+		if diff := math.Abs(exact.Schedule[i].RemainingPrincipal - fast.Schedule[i].RemainingPrincipal); diff > allowed {
+			t.Fatalf("месяц %d: расхождение остатка %v превышает накопленный допуск %v", i+1, diff, allowed)
+		}
+	}
+	// Суммарные проценты совпадают с относительной точностью 1e-5 —
+	// расхождение целиком объясняется дрейфом float64-пути.
+	if rel := math.Abs(exact.TotalInterest-fast.TotalInterest) / exact.TotalInterest; rel > 1e-5 {
+		t.Fatalf("относительное расхождение суммарных процентов %v больше 1e-5", rel)
+	}
+}
+
+func TestAnnuityScheduleExactNoDrift(t *testing.T) {
+	cfg := DefaultConfig()
+	exact, err := AnnuityScheduleExact(cfg, LoanParams{Principal: 5_000_000, AnnualRatePercent: 13.7, Months: 360})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Точный баланс обнуляется ровно, без накопленной ошибки.
+	if got := exact.Schedule[len(exact.Schedule)-1].RemainingPrincipal; got != 0 {
+		t.Fatalf("остаток после последнего платежа должен быть ровно 0, получено %v", got)
+	}
+	// Сумма платежей точно равна долгу плюс процентам (до вывода).
+	inDelta(t, exact.TotalPayment, exact.Principal+exact.TotalInterest, 0.011)
+}
+
+func TestAnnuityScheduleExactZeroRate(t *testing.T) {
+	cfg := DefaultConfig()
+	exact, err := AnnuityScheduleExact(cfg, LoanParams{Principal: 120_000, AnnualRatePercent: 0, Months: 12})
+	if err != nil {
+		t.Fatal(err)
+	}
+	inDelta(t, exact.MonthlyPayment, 10_000, 1e-9)
+	inDelta(t, exact.TotalInterest, 0, 1e-9)
+}
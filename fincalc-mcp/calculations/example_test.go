@@ -0,0 +1,46 @@
+package calculations_test
+
+import (
+	"fmt"
+
+	"fincalc-mcp/calculations"
+)
+
+// Пример встраивания пакета без MCP-слоя: ни метрик, ни логирования —
+// только чистые функции и конкретные типы результатов.
+func ExampleAnnuitySchedule() {
+	cfg := calculations.DefaultConfig()
+	res, err := calculations.AnnuitySchedule(cfg, calculations.LoanParams{
+		Principal:         1_000_000,
+		AnnualRatePercent: 12,
+		Months:            12,
+	})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(res.Kind)
+	fmt.Println(res.MonthlyPayment)
+	// Output:
+	// annuity
+	// 88848.79
+}
+
+func ExampleDepositSchedule() {
+	cfg := calculations.DefaultConfig()
+	res, err := calculations.DepositSchedule(cfg, calculations.DepositParams{
+		InitialAmount:     100_000,
+		AnnualRatePercent: 0,
+		Months:            6,
+	})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	// Kind различает виды расчетов без type assertion по interface{}.
+	fmt.Println(res.Kind == calculations.KindDeposit)
+	fmt.Println(res.FinalBalance)
+	// Output:
+	// true
+	// 100000
+}
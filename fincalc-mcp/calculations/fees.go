@@ -0,0 +1,65 @@
+package calculations
+
+import "fmt"
+
+// FeePeriod — участок срока со своей ежемесячной комиссией за обслуживание:
+// действует с месяца FromMonth включительно до начала следующего участка
+// (или до конца срока). Используется для продуктов «промо-период без
+// комиссии, затем полная».
+type FeePeriod struct {
+	FromMonth  int     `json:"from_month"`
+	MonthlyFee float64 `json:"monthly_fee"`
+}
+
+// validateFeePeriods проверяет, что участки покрывают месяц 1, строго
+// упорядочены по FromMonth и каждая комиссия в допустимых пределах.
+func validateFeePeriods(cfg Config, periods []FeePeriod, months int) error {
+	if periods[0].FromMonth != 1 {
+		return fmt.Errorf("периоды комиссий должны начинаться с месяца 1, получен %d", periods[0].FromMonth)
+	}
+	for i, period := range periods {
+		if err := CheckAmount(cfg, period.MonthlyFee); err != nil {
+			return err
+		}
+		if period.FromMonth > months {
+			return errOutOfRange("from_month", 1, months)
+		}
+		if i > 0 && period.FromMonth <= periods[i-1].FromMonth {
+			return fmt.Errorf("периоды комиссий должны быть строго упорядочены по from_month")
+		}
+	}
+	return nil
+}
+
+// feeForMonth возвращает комиссию, действующую в месяце m.
+func feeForMonth(periods []FeePeriod, m int) float64 {
+	fee := periods[0].MonthlyFee
+	for _, period := range periods {
+		if period.FromMonth > m {
+			break
+		}
+		fee = period.MonthlyFee
+	}
+	return fee
+}
+
+// ApplyFeePeriods накладывает на готовый кредитный график переменную
+// комиссию за обслуживание: каждая строка получает действующую в своем
+// месяце комиссию (Fees), сводка — их сумму (TotalFees). Сам платеж и
+// проценты не меняются — комиссия показывается отдельной строкой расходов.
+func ApplyFeePeriods(cfg Config, res *CalculationResult, periods []FeePeriod) error {
+	if len(periods) == 0 {
+		return nil
+	}
+	if err := validateFeePeriods(cfg, periods, res.Months); err != nil {
+		return err
+	}
+	total := 0.0
+	for i := range res.Schedule {
+		fee := Round(feeForMonth(periods, res.Schedule[i].Month), cfg.Precision)
+		res.Schedule[i].Fees = fee
+		total += fee
+	}
+	res.TotalFees = Round(total, cfg.Precision)
+	return nil
+}
@@ -0,0 +1,42 @@
+package calculations
+
+import "testing"
+
+func TestApplyFeePeriods(t *testing.T) {
+	cfg := DefaultConfig()
+	res, err := AnnuitySchedule(cfg, LoanParams{Principal: 1_000_000, AnnualRatePercent: 12, Months: 24})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Комиссия вырастает после промо-года: 100 в месяцы 1-12, 300 дальше.
+	err = ApplyFeePeriods(cfg, res, []FeePeriod{
+		{FromMonth: 1, MonthlyFee: 100},
+		{FromMonth: 13, MonthlyFee: 300},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range res.Schedule {
+		want := 100.0
+		if entry.Month >= 13 {
+			want = 300
+		}
+		if entry.Fees != want {
+			t.Fatalf("месяц %d: комиссия %v, ожидалась %v", entry.Month, entry.Fees, want)
+		}
+	}
+	inDelta(t, res.TotalFees, 12*100+12*300, 0.01)
+
+	// Периоды должны покрывать месяц 1 и быть упорядочены.
+	if err := ApplyFeePeriods(cfg, res, []FeePeriod{{FromMonth: 2, MonthlyFee: 100}}); err == nil {
+		t.Fatal("ожидалась ошибка для периодов без месяца 1")
+	}
+	bad := []FeePeriod{
+		{FromMonth: 1, MonthlyFee: 100},
+		{FromMonth: 13, MonthlyFee: 300},
+		{FromMonth: 13, MonthlyFee: 500},
+	}
+	if err := ApplyFeePeriods(cfg, res, bad); err == nil {
+		t.Fatal("ожидалась ошибка для неупорядоченных периодов")
+	}
+}
@@ -0,0 +1,42 @@
+package calculations
+
+import "fmt"
+
+// FinancedFeeResult — аннуитетный график с разовой комиссией, включенной
+// в тело кредита, вместе с ценой такого финансирования.
+type FinancedFeeResult struct {
+	*CalculationResult
+	Fee float64 `json:"fee"`
+	// GrossPrincipal — тело кредита после включения комиссии; именно оно
+	// амортизируется, и на комиссию тоже начисляются проценты.
+	GrossPrincipal float64 `json:"gross_principal"`
+	// ExtraInterest — прирост суммарных процентов относительно оплаты
+	// комиссии сразу из своих денег.
+	ExtraInterest float64 `json:"extra_interest"`
+}
+
+// AnnuityScheduleFinancedFee строит аннуитетный график кредита, в тело
+// которого включена разовая комиссия за выдачу: амортизируется валовая
+// сумма, так что заемщик платит проценты и на комиссию. В сводке — сама
+// комиссия, валовое тело и переплата против оплаты комиссии сразу.
+func AnnuityScheduleFinancedFee(cfg Config, p LoanParams, fee float64) (*FinancedFeeResult, error) {
+	if fee < 0 {
+		return nil, fmt.Errorf("комиссия за выдачу не может быть отрицательной")
+	}
+	baseline, err := AnnuitySchedule(cfg, p)
+	if err != nil {
+		return nil, err
+	}
+	gross := p
+	gross.Principal = p.Principal + fee
+	res, err := AnnuitySchedule(cfg, gross)
+	if err != nil {
+		return nil, err
+	}
+	return &FinancedFeeResult{
+		CalculationResult: res,
+		Fee:               Round(fee, cfg.Precision),
+		GrossPrincipal:    Round(gross.Principal, cfg.Precision),
+		ExtraInterest:     Round(res.TotalInterest-baseline.TotalInterest, cfg.Precision),
+	}, nil
+}
@@ -0,0 +1,29 @@
+package calculations
+
+import "testing"
+
+func TestAnnuityScheduleFinancedFee(t *testing.T) {
+	cfg := DefaultConfig()
+	p := LoanParams{Principal: 1_000_000, AnnualRatePercent: 12, Months: 120}
+	res, err := AnnuityScheduleFinancedFee(cfg, p, 30_000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	inDelta(t, res.GrossPrincipal, 1_030_000, 0.01)
+	// Финансирование комиссии дороже оплаты сразу: проценты идут и на нее.
+	baseline, err := AnnuitySchedule(cfg, p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.TotalInterest <= baseline.TotalInterest {
+		t.Fatalf("проценты с комиссией в теле %v должны превышать базовые %v", res.TotalInterest, baseline.TotalInterest)
+	}
+	inDelta(t, res.ExtraInterest, res.TotalInterest-baseline.TotalInterest, 0.01)
+	if res.ExtraInterest <= 0 {
+		t.Fatalf("переплата за финансирование комиссии должна быть положительной: %v", res.ExtraInterest)
+	}
+
+	if _, err := AnnuityScheduleFinancedFee(cfg, p, -1); err == nil {
+		t.Fatal("ожидалась ошибка для отрицательной комиссии")
+	}
+}
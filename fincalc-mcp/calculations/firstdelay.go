@@ -0,0 +1,75 @@
+package calculations
+
+// FirstPaymentDelayResult — аннуитетный график с отложенным первым платежом
+// и ценой удлиненного первого периода начисления.
+type FirstPaymentDelayResult struct {
+	*CalculationResult
+	FirstPaymentDelayDays int `json:"first_payment_delay_days"`
+	// ExtraCost — удорожание против графика с обычным первым периодом.
+	ExtraCost float64 `json:"extra_cost"`
+}
+
+// AnnuityScheduleWithFirstDelay строит аннуитетный график, у которого первый
+// платеж отложен на delayDays сверх стандартного месяца: проценты первого
+// периода начисляются за 30+delayDays дней (пропорционально месячной ставке),
+// дальше график обычный. Это не льготный период — платеж не меняется, просто
+// первый период длиннее, и кредит выходит немного дороже; разница раскрыта
+// в ExtraCost.
+func AnnuityScheduleWithFirstDelay(cfg Config, p LoanParams, delayDays int) (*FirstPaymentDelayResult, error) {
+	if err := p.validate(cfg); err != nil {
+		return nil, err
+	}
+	if delayDays < 0 || delayDays > 365 {
+		return nil, errOutOfRange("first_payment_delay_days", 0, 365)
+	}
+	acc := newAccruer(cfg, p.AnnualRatePercent)
+	payment := AnnuityPayment(p.Principal, p.AnnualRatePercent, p.Months)
+	res := &CalculationResult{
+		Kind:              KindAnnuity,
+		Principal:         p.Principal,
+		AnnualRatePercent: p.AnnualRatePercent,
+		Months:            p.Months,
+		MonthlyPayment:    Round(payment, cfg.Precision),
+		Schedule:          make([]ScheduleEntry, 0, p.Months),
+	}
+	balance := p.Principal
+	for m := 1; m <= p.Months; m++ {
+		interest := acc.Interest(m, balance)
+		if m == 1 {
+			// Первый период: 30 стандартных дней плюс отсрочка.
+			interest = Round(balance*MonthlyRate(p.AnnualRatePercent)*float64(30+delayDays)/30, cfg.Precision)
+		}
+		principalPart := Round(payment-interest, cfg.Precision)
+		if m == p.Months {
+			principalPart = Round(balance, cfg.Precision)
+		}
+		var err error
+		if balance, err = acc.ReduceDebt(m, balance, principalPart); err != nil {
+			return nil, err
+		}
+		pay := Round(principalPart+interest, cfg.Precision)
+		res.Schedule = append(res.Schedule, ScheduleEntry{
+			Month:              m,
+			Payment:            pay,
+			PrincipalComponent: principalPart,
+			Interest:           interest,
+			RemainingPrincipal: balance,
+		})
+		res.TotalPayment += pay
+		res.TotalInterest += interest
+	}
+	res.TotalPayment = Round(res.TotalPayment, cfg.Precision)
+	res.TotalInterest = Round(res.TotalInterest, cfg.Precision)
+	res.CrossoverMonth = CrossoverMonth(res.Schedule)
+	fillPaymentShares(cfg, res.Schedule)
+
+	baseline, err := AnnuitySchedule(cfg, p)
+	if err != nil {
+		return nil, err
+	}
+	return &FirstPaymentDelayResult{
+		CalculationResult:     res,
+		FirstPaymentDelayDays: delayDays,
+		ExtraCost:             Round(res.TotalInterest-baseline.TotalInterest, cfg.Precision),
+	}, nil
+}
@@ -0,0 +1,34 @@
+package calculations
+
+import "testing"
+
+func TestAnnuityScheduleWithFirstDelay(t *testing.T) {
+	cfg := DefaultConfig()
+	p := LoanParams{Principal: 1_000_000, AnnualRatePercent: 12, Months: 36}
+	// Первый период 30 дней (без отсрочки) против 60 дней.
+	standard, err := AnnuityScheduleWithFirstDelay(cfg, p, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	delayed, err := AnnuityScheduleWithFirstDelay(cfg, p, 30)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Без отсрочки график совпадает с обычным аннуитетом.
+	baseline, err := AnnuitySchedule(cfg, p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	inDelta(t, standard.TotalInterest, baseline.TotalInterest, 0.01)
+	inDelta(t, standard.ExtraCost, 0, 0.01)
+	// Удвоенный первый период: проценты первого месяца вдвое больше.
+	inDelta(t, delayed.Schedule[0].Interest, 2*standard.Schedule[0].Interest, 0.01)
+	if delayed.TotalInterest <= standard.TotalInterest {
+		t.Fatalf("отсрочка должна удорожать кредит: %v <= %v", delayed.TotalInterest, standard.TotalInterest)
+	}
+	inDelta(t, delayed.ExtraCost, delayed.TotalInterest-standard.TotalInterest, 0.01)
+	// Долг полностью погашен.
+	if last := delayed.Schedule[len(delayed.Schedule)-1].RemainingPrincipal; last != 0 {
+		t.Fatalf("остаток долга после последнего платежа: %v", last)
+	}
+}
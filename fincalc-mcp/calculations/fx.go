@@ -0,0 +1,55 @@
+package calculations
+
+import "fmt"
+
+// FXScenario — платежи валютного кредита в местной валюте при одном
+// сценарии курса.
+type FXScenario struct {
+	// ChangePercent — изменение курса относительно базового в процентах.
+	ChangePercent       float64 `json:"change_percent"`
+	ExchangeRate        float64 `json:"exchange_rate"`
+	MonthlyPaymentLocal float64 `json:"monthly_payment_local"`
+	TotalPaymentLocal   float64 `json:"total_payment_local"`
+	TotalInterestLocal  float64 `json:"total_interest_local"`
+}
+
+// FXLoanScenarios иллюстрирует валютный риск кредита, номинированного в
+// иностранной валюте: аннуитетный график строится один раз в валюте
+// кредита, затем платеж и полная стоимость пересчитываются в местную
+// валюту по каждому сценарию курса (базовый курс ± сдвиги в процентах).
+func FXLoanScenarios(cfg Config, p LoanParams, baseExchangeRate float64, scenarioChangesPercent []float64) (map[string]any, error) {
+	if baseExchangeRate <= 0 {
+		return nil, fmt.Errorf("базовый курс должен быть положительным")
+	}
+	if len(scenarioChangesPercent) == 0 {
+		return nil, fmt.Errorf("нужен хотя бы один сценарий курса")
+	}
+	if err := cfg.CheckExpansion(len(scenarioChangesPercent)); err != nil {
+		return nil, err
+	}
+	res, err := AnnuitySchedule(cfg, p)
+	if err != nil {
+		return nil, err
+	}
+	scenarios := make([]FXScenario, 0, len(scenarioChangesPercent)+1)
+	for _, change := range append([]float64{0}, scenarioChangesPercent...) {
+		rate := baseExchangeRate * (1 + change/100)
+		if rate <= 0 {
+			return nil, fmt.Errorf("сценарий %+.1f%% дает неположительный курс", change)
+		}
+		scenarios = append(scenarios, FXScenario{
+			ChangePercent:       change,
+			ExchangeRate:        Round(rate, 4),
+			MonthlyPaymentLocal: Round(res.MonthlyPayment*rate, cfg.Precision),
+			TotalPaymentLocal:   Round(res.TotalPayment*rate, cfg.Precision),
+			TotalInterestLocal:  Round(res.TotalInterest*rate, cfg.Precision),
+		})
+	}
+	return map[string]any{
+		"monthly_payment_foreign": res.MonthlyPayment,
+		"total_payment_foreign":   res.TotalPayment,
+		"total_interest_foreign":  res.TotalInterest,
+		"base_exchange_rate":      baseExchangeRate,
+		"scenarios":               scenarios,
+	}, nil
+}
@@ -0,0 +1,33 @@
+package calculations
+
+import "testing"
+
+func TestFXLoanScenarios(t *testing.T) {
+	cfg := DefaultConfig()
+	p := LoanParams{Principal: 10_000, AnnualRatePercent: 8, Months: 60}
+	out, err := FXLoanScenarios(cfg, p, 90, []float64{-20, -10, 10, 20})
+	if err != nil {
+		t.Fatal(err)
+	}
+	scenarios := out["scenarios"].([]FXScenario)
+	if len(scenarios) != 5 {
+		t.Fatalf("ожидалось 5 сценариев вместе с базовым, получено %d", len(scenarios))
+	}
+	base := scenarios[0]
+	if base.ChangePercent != 0 {
+		t.Fatalf("первый сценарий должен быть базовым, получен %v%%", base.ChangePercent)
+	}
+	// Итоги в местной валюте масштабируются вместе с курсом.
+	for _, sc := range scenarios[1:] {
+		factor := 1 + sc.ChangePercent/100
+		inDelta(t, sc.TotalPaymentLocal, base.TotalPaymentLocal*factor, 0.01)
+		inDelta(t, sc.MonthlyPaymentLocal, base.MonthlyPaymentLocal*factor, 0.01)
+	}
+
+	if _, err := FXLoanScenarios(cfg, p, 0, []float64{10}); err == nil {
+		t.Fatal("ожидалась ошибка для нулевого базового курса")
+	}
+	if _, err := FXLoanScenarios(cfg, p, 90, []float64{-100}); err == nil {
+		t.Fatal("ожидалась ошибка для сценария с неположительным курсом")
+	}
+}
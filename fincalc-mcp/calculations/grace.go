@@ -0,0 +1,112 @@
+package calculations
+
+import "fmt"
+
+// Режимы льготного периода.
+const (
+	GraceInterestOnly = "interest_only" // платятся только проценты
+	GraceCapitalize   = "capitalize"    // платежей нет, проценты в тело долга
+)
+
+// GraceResult — график кредита с льготным периодом и реальная стоимость:
+// заявленная ставка занижает ее, потому что во время льготы проценты
+// капитализируются или долг не убывает.
+type GraceResult struct {
+	*CalculationResult
+	GraceMonths             int     `json:"grace_months"`
+	GraceMode               string  `json:"grace_mode"`
+	NominalRatePercent      float64 `json:"nominal_rate_percent"`
+	GraceAdjustedAPRPercent float64 `json:"grace_adjusted_apr_percent"`
+	CashFlowIRRPercent      float64 `json:"cash_flow_irr_percent"`
+	// WeightedAverageRatePercent — средневзвешенная по остатку долга
+	// фактическая ставка графика (см. WeightedAverageRate).
+	WeightedAverageRatePercent float64 `json:"weighted_average_rate_percent"`
+}
+
+// GraceSchedule строит график аннуитетного кредита с льготным периодом:
+// первые graceMonths месяцев основной долг не гасится, затем остаток
+// амортизируется на оставшийся срок. Сводная GraceAdjustedAPR — ставка,
+// при которой обычный аннуитет на весь срок стоил бы столько же процентов.
+func GraceSchedule(cfg Config, p LoanParams, graceMonths int, mode string) (*GraceResult, error) {
+	if err := p.validate(cfg); err != nil {
+		return nil, err
+	}
+	if graceMonths < 1 || graceMonths >= p.Months {
+		return nil, errOutOfRange("grace_months", 1, p.Months-1)
+	}
+	if mode != GraceInterestOnly && mode != GraceCapitalize {
+		return nil, fmt.Errorf("параметр %q должен быть %q или %q", "grace_mode", GraceInterestOnly, GraceCapitalize)
+	}
+	r := MonthlyRate(p.AnnualRatePercent)
+	res := &CalculationResult{
+		Kind:              KindAnnuity,
+		Principal:         p.Principal,
+		AnnualRatePercent: p.AnnualRatePercent,
+		Months:            p.Months,
+		Schedule:          make([]ScheduleEntry, 0, p.Months),
+	}
+	out := &GraceResult{
+		CalculationResult:  res,
+		GraceMonths:        graceMonths,
+		GraceMode:          mode,
+		NominalRatePercent: p.AnnualRatePercent,
+	}
+	flows := []float64{p.Principal}
+	balance := p.Principal
+	for m := 1; m <= graceMonths; m++ {
+		interest := Round(balance*r, cfg.Precision)
+		entry := ScheduleEntry{Month: m, Interest: interest, AccruedInterest: interest}
+		if mode == GraceInterestOnly {
+			entry.Payment = interest
+			entry.PaidInterest = interest
+		} else {
+			// Капитализация: проценты начислены, но не уплачены —
+			// прибавляются к телу долга.
+			entry.PrincipalComponent = -interest
+			balance = Round(balance+interest, cfg.Precision)
+		}
+		entry.RemainingPrincipal = balance
+		res.Schedule = append(res.Schedule, entry)
+		res.TotalPayment += entry.Payment
+		res.TotalInterest += interest
+		flows = append(flows, -entry.Payment)
+	}
+	remaining := p.Months - graceMonths
+	payment := AnnuityPayment(balance, p.AnnualRatePercent, remaining)
+	res.MonthlyPayment = Round(payment, cfg.Precision)
+	for m := graceMonths + 1; m <= p.Months; m++ {
+		interest := Round(balance*r, cfg.Precision)
+		principalPart := Round(payment-interest, cfg.Precision)
+		if m == p.Months {
+			principalPart = Round(balance, cfg.Precision)
+		}
+		balance = Round(balance-principalPart, cfg.Precision)
+		if balance < 0 {
+			balance = 0
+		}
+		pay := Round(principalPart+interest, cfg.Precision)
+		res.Schedule = append(res.Schedule, ScheduleEntry{
+			Month:              m,
+			Payment:            pay,
+			PrincipalComponent: principalPart,
+			Interest:           interest,
+			AccruedInterest:    interest,
+			PaidInterest:       interest,
+			RemainingPrincipal: balance,
+		})
+		res.TotalPayment += pay
+		res.TotalInterest += interest
+		flows = append(flows, -pay)
+	}
+	res.TotalPayment = Round(res.TotalPayment, cfg.Precision)
+	res.TotalInterest = Round(res.TotalInterest, cfg.Precision)
+
+	irr, err := IRR(flows)
+	if err != nil {
+		return nil, err
+	}
+	out.CashFlowIRRPercent = Round(irr*12*100, 4)
+	out.GraceAdjustedAPRPercent = equivalentAnnuityRate(p.Principal, p.Months, res.TotalInterest, 0, cfg.MaxRate)
+	out.WeightedAverageRatePercent = WeightedAverageRate(res.Schedule, p.Principal)
+	return out, nil
+}
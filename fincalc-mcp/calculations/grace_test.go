@@ -0,0 +1,93 @@
+package calculations
+
+import "testing"
+
+func TestGraceScheduleCapitalizeRaisesAPR(t *testing.T) {
+	cfg := DefaultConfig()
+	p := LoanParams{Principal: 1_000_000, AnnualRatePercent: 12, Months: 36}
+	res, err := GraceSchedule(cfg, p, 6, GraceCapitalize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Нетривиальная льгота с капитализацией дороже заявленной ставки.
+	if res.GraceAdjustedAPRPercent <= res.NominalRatePercent {
+		t.Fatalf("сводная ставка %v должна превышать номинальную %v", res.GraceAdjustedAPRPercent, res.NominalRatePercent)
+	}
+	// Во время льготы платежей нет, тело долга растет.
+	if res.Schedule[0].Payment != 0 {
+		t.Fatalf("в льготный месяц платеж должен быть 0, получено %v", res.Schedule[0].Payment)
+	}
+	if res.Schedule[5].RemainingPrincipal <= p.Principal {
+		t.Fatalf("капитализация должна увеличить долг, получено %v", res.Schedule[5].RemainingPrincipal)
+	}
+	inDelta(t, res.Schedule[len(res.Schedule)-1].RemainingPrincipal, 0, 0.01)
+}
+
+func TestGraceScheduleInterestOnly(t *testing.T) {
+	cfg := DefaultConfig()
+	p := LoanParams{Principal: 1_000_000, AnnualRatePercent: 12, Months: 36}
+	res, err := GraceSchedule(cfg, p, 6, GraceInterestOnly)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Долг в льготные месяцы не меняется, платятся только проценты.
+	inDelta(t, res.Schedule[0].Payment, 10_000, 0.01)
+	inDelta(t, res.Schedule[5].RemainingPrincipal, 1_000_000, 0.01)
+	// Лишние месяцы на полном долге тоже дороже обычного аннуитета.
+	if res.GraceAdjustedAPRPercent <= res.NominalRatePercent {
+		t.Fatalf("сводная ставка %v должна превышать номинальную %v", res.GraceAdjustedAPRPercent, res.NominalRatePercent)
+	}
+	inDelta(t, res.Schedule[len(res.Schedule)-1].RemainingPrincipal, 0, 0.01)
+}
+
+func TestGraceScheduleValidation(t *testing.T) {
+	cfg := DefaultConfig()
+	p := LoanParams{Principal: 1_000_000, AnnualRatePercent: 12, Months: 12}
+	if _, err := GraceSchedule(cfg, p, 0, GraceCapitalize); err == nil {
+		t.Fatal("ожидалась ошибка для нулевой льготы")
+	}
+	if _, err := GraceSchedule(cfg, p, 12, GraceCapitalize); err == nil {
+		t.Fatal("ожидалась ошибка: льгота не короче срока")
+	}
+	if _, err := GraceSchedule(cfg, p, 3, "вечная"); err == nil {
+		t.Fatal("ожидалась ошибка для неизвестного режима")
+	}
+}
+
+func TestGraceCapitalizeAccruedVsPaid(t *testing.T) {
+	cfg := DefaultConfig()
+	p := LoanParams{Principal: 1_000_000, AnnualRatePercent: 12, Months: 24}
+	res, err := GraceSchedule(cfg, p, 3, GraceCapitalize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// В льготные месяцы проценты начислены, но не уплачены.
+	for _, entry := range res.Schedule[:3] {
+		if entry.AccruedInterest <= entry.PaidInterest {
+			t.Fatalf("месяц %d: начислено %v должно превышать уплачено %v", entry.Month, entry.AccruedInterest, entry.PaidInterest)
+		}
+		if entry.PaidInterest != 0 {
+			t.Fatalf("месяц %d: при капитализации проценты не платятся, получено %v", entry.Month, entry.PaidInterest)
+		}
+	}
+	// После льготы начислено и уплачено совпадают.
+	for _, entry := range res.Schedule[3:] {
+		if entry.AccruedInterest != entry.PaidInterest {
+			t.Fatalf("месяц %d: вне льготы начислено %v и уплачено %v должны совпадать", entry.Month, entry.AccruedInterest, entry.PaidInterest)
+		}
+	}
+}
+
+func TestPlainLoanOmitsAccrualSplit(t *testing.T) {
+	cfg := DefaultConfig()
+	res, err := AnnuitySchedule(cfg, LoanParams{Principal: 1_000_000, AnnualRatePercent: 12, Months: 12})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// У обычного кредита разбивка не заполняется: начислено равно уплачено.
+	for _, entry := range res.Schedule {
+		if entry.AccruedInterest != 0 || entry.PaidInterest != 0 {
+			t.Fatalf("месяц %d: у обычного кредита поля разбивки должны быть опущены", entry.Month)
+		}
+	}
+}
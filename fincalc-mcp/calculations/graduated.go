@@ -0,0 +1,95 @@
+package calculations
+
+import "math"
+
+// GraduatedPaymentResult — ипотека с растущим платежом: платеж увеличивается
+// раз в год на заданный процент, а стартовый платеж подобран так, чтобы долг
+// был полностью погашен к концу срока.
+type GraduatedPaymentResult struct {
+	*CalculationResult
+	PaymentGrowthPercent float64 `json:"payment_growth_percent"`
+	InitialPayment       float64 `json:"initial_payment"`
+	FinalPayment         float64 `json:"final_payment"`
+}
+
+// graduatedFinalBalance возвращает остаток долга в конце срока при заданном
+// стартовом платеже: платеж каждого года выше предыдущего на growthPercent.
+func graduatedFinalBalance(p LoanParams, growthPercent, initialPayment float64) float64 {
+	rate := MonthlyRate(p.AnnualRatePercent)
+	balance := p.Principal
+	for m := 1; m <= p.Months; m++ {
+		payment := initialPayment * math.Pow(1+growthPercent/100, float64((m-1)/12))
+		balance += balance*rate - payment
+	}
+	return balance
+}
+
+// GraduatedPaymentSchedule строит график ипотеки с растущим платежом
+// (graduated-payment mortgage): платеж индексируется раз в год на
+// growthPercent — например, в темп инфляции, удерживая реальную нагрузку
+// постоянной. Стартовый платеж подбирается бисекцией до полного погашения
+// долга к концу срока; в первые годы возможна отрицательная амортизация.
+func GraduatedPaymentSchedule(cfg Config, p LoanParams, growthPercent float64) (*GraduatedPaymentResult, error) {
+	if err := p.validate(cfg); err != nil {
+		return nil, err
+	}
+	if err := CheckRate(cfg, growthPercent); err != nil {
+		return nil, err
+	}
+	// Аннуитетный платеж гасит долг при нулевом росте; с ростом стартовый
+	// платеж только ниже, поэтому [0, аннуитет] гарантированно содержит корень.
+	lo, hi := 0.0, AnnuityPayment(p.Principal, p.AnnualRatePercent, p.Months)
+	for i := 0; i < bisectionSteps; i++ {
+		mid := (lo + hi) / 2
+		if graduatedFinalBalance(p, growthPercent, mid) > 0 {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	initial := hi
+
+	acc := newAccruer(cfg, p.AnnualRatePercent)
+	res := &CalculationResult{
+		Kind:              KindAnnuity,
+		Principal:         p.Principal,
+		AnnualRatePercent: p.AnnualRatePercent,
+		Months:            p.Months,
+		Schedule:          make([]ScheduleEntry, 0, p.Months),
+	}
+	balance := p.Principal
+	for m := 1; m <= p.Months; m++ {
+		payment := Round(initial*math.Pow(1+growthPercent/100, float64((m-1)/12)), cfg.Precision)
+		interest := acc.Interest(m, balance)
+		principalPart := Round(payment-interest, cfg.Precision)
+		if m == p.Months {
+			principalPart = Round(balance, cfg.Precision)
+			payment = Round(principalPart+interest, cfg.Precision)
+		}
+		var err error
+		if balance, err = acc.ReduceDebt(m, balance, principalPart); err != nil {
+			return nil, err
+		}
+		res.Schedule = append(res.Schedule, ScheduleEntry{
+			Month:              m,
+			Payment:            payment,
+			PrincipalComponent: principalPart,
+			Interest:           interest,
+			RemainingPrincipal: balance,
+		})
+		res.TotalPayment += payment
+		res.TotalInterest += interest
+	}
+	res.TotalPayment = Round(res.TotalPayment, cfg.Precision)
+	res.TotalInterest = Round(res.TotalInterest, cfg.Precision)
+	res.FirstPayment = res.Schedule[0].Payment
+	res.LastPayment = res.Schedule[len(res.Schedule)-1].Payment
+	res.CrossoverMonth = CrossoverMonth(res.Schedule)
+	fillPaymentShares(cfg, res.Schedule)
+	return &GraduatedPaymentResult{
+		CalculationResult:    res,
+		PaymentGrowthPercent: growthPercent,
+		InitialPayment:       res.FirstPayment,
+		FinalPayment:         res.LastPayment,
+	}, nil
+}
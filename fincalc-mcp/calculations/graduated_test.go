@@ -0,0 +1,33 @@
+package calculations
+
+import "testing"
+
+func TestGraduatedPaymentSchedule(t *testing.T) {
+	cfg := DefaultConfig()
+	p := LoanParams{Principal: 5_000_000, AnnualRatePercent: 11, Months: 240}
+	res, err := GraduatedPaymentSchedule(cfg, p, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Долг полностью погашен к концу срока.
+	if last := res.Schedule[len(res.Schedule)-1].RemainingPrincipal; last != 0 {
+		t.Fatalf("остаток долга после последнего платежа: %v", last)
+	}
+	// Платеж растет год от года, стартовый ниже обычного аннуитета.
+	if res.InitialPayment >= res.FinalPayment {
+		t.Fatalf("платеж должен расти: %v >= %v", res.InitialPayment, res.FinalPayment)
+	}
+	annuity := AnnuityPayment(p.Principal, p.AnnualRatePercent, p.Months)
+	if res.InitialPayment >= annuity {
+		t.Fatalf("стартовый платеж %v должен быть ниже аннуитетного %v", res.InitialPayment, annuity)
+	}
+	// Платеж второго года ровно на 5%% выше платежа первого.
+	inDelta(t, res.Schedule[12].Payment, Round2(res.Schedule[0].Payment*1.05), 0.02)
+
+	// Нулевой рост вырождается в обычный аннуитет.
+	flat, err := GraduatedPaymentSchedule(cfg, p, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	inDelta(t, flat.InitialPayment, Round2(annuity), 0.02)
+}
@@ -0,0 +1,50 @@
+package calculations
+
+// GrowthPoint — накопленный итог на конец месяца: сколько в балансе
+// собственных денег и сколько — начисленного дохода.
+type GrowthPoint struct {
+	Month                   int     `json:"month"`
+	CumulativeContributions float64 `json:"cumulative_contributions"`
+	CumulativeInterest      float64 `json:"cumulative_interest"`
+	Balance                 float64 `json:"balance"`
+}
+
+// GrowthBreakdown — ряд «свои деньги против роста» и итоговое разделение
+// финального баланса в процентах.
+type GrowthBreakdown struct {
+	InitialAmount   float64       `json:"initial_amount"`
+	Series          []GrowthPoint `json:"series"`
+	OwnMoneyPercent float64       `json:"own_money_percent"`
+	GrowthPercent   float64       `json:"growth_percent"`
+	FinalOwnMoney   float64       `json:"final_own_money"`
+	FinalGrowth     float64       `json:"final_growth"`
+}
+
+// GrowthSeries собирает из графика накоплений помесячный ряд накопленных
+// взносов и дохода. Для графика с капитализацией на каждом месяце
+// выполняется initialAmount + взносы + доход = баланс.
+func GrowthSeries(cfg Config, res *CalculationResult, initialAmount float64) *GrowthBreakdown {
+	out := &GrowthBreakdown{
+		InitialAmount: initialAmount,
+		Series:        make([]GrowthPoint, 0, len(res.Schedule)),
+	}
+	contributions := 0.0
+	interest := 0.0
+	for _, entry := range res.Schedule {
+		contributions += entry.Contribution
+		interest += entry.Interest
+		out.Series = append(out.Series, GrowthPoint{
+			Month:                   entry.Month,
+			CumulativeContributions: Round2(contributions),
+			CumulativeInterest:      Round2(interest),
+			Balance:                 entry.Balance,
+		})
+	}
+	out.FinalOwnMoney = Round2(initialAmount + contributions)
+	out.FinalGrowth = Round2(interest)
+	if res.FinalBalance > 0 {
+		out.OwnMoneyPercent = RoundPercent(cfg, out.FinalOwnMoney/res.FinalBalance*100)
+		out.GrowthPercent = RoundPercent(cfg, out.FinalGrowth/res.FinalBalance*100)
+	}
+	return out
+}
@@ -0,0 +1,67 @@
+package calculations
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGrowthSeriesBalancesEachMonth(t *testing.T) {
+	cfg := DefaultConfig()
+	inv, err := CalculateInvestment(cfg, InvestmentParams{
+		InitialAmount:       100_000,
+		MonthlyContribution: 10_000,
+		AnnualReturnPercent: 10,
+		Months:              24,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	breakdown := GrowthSeries(cfg, inv.CalculationResult, 100_000)
+	if len(breakdown.Series) != 24 {
+		t.Fatalf("ожидалось 24 точки, получено %d", len(breakdown.Series))
+	}
+	// Начальная сумма + взносы + доход сходятся с балансом каждый месяц.
+	for _, point := range breakdown.Series {
+		sum := breakdown.InitialAmount + point.CumulativeContributions + point.CumulativeInterest
+		if diff := math.Abs(sum - point.Balance); diff > 0.02 {
+			t.Fatalf("месяц %d: %v + %v + %v не сходится с балансом %v", point.Month, breakdown.InitialAmount, point.CumulativeContributions, point.CumulativeInterest, point.Balance)
+		}
+	}
+	inDelta(t, breakdown.OwnMoneyPercent+breakdown.GrowthPercent, 100, 0.02)
+	if breakdown.GrowthPercent <= 0 {
+		t.Fatalf("при положительной доходности доля роста должна быть больше 0, получено %v", breakdown.GrowthPercent)
+	}
+}
+
+func TestPercentPrecisionIndependentOfMoney(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.PercentPrecision = 4
+	inv, err := CalculateInvestment(cfg, InvestmentParams{
+		InitialAmount:       1_000_000,
+		AnnualReturnPercent: 7,
+		Months:              7,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Процентные поля следуют процентной точности...
+	roi := inv.FinalBalance/inv.TotalInvested - 1
+	inDelta(t, inv.ROIPercent, Round(roi*100, 4), 1e-12)
+	if inv.ROIPercent == Round2(inv.ROIPercent) {
+		t.Fatalf("ROI %v не должен обрезаться до денежных двух знаков", inv.ROIPercent)
+	}
+	// ...а денежные остаются с денежной.
+	if inv.CapitalGain != Round2(inv.CapitalGain) {
+		t.Fatalf("денежное поле должно округляться до %d знаков, получено %v", cfg.Precision, inv.CapitalGain)
+	}
+	// Доли платежа в графике — тоже проценты и следуют PercentPrecision.
+	loan, err := AnnuitySchedule(cfg, LoanParams{Principal: 1_000_000, AnnualRatePercent: 7, Months: 7})
+	if err != nil {
+		t.Fatal(err)
+	}
+	first := loan.Schedule[0]
+	inDelta(t, first.InterestShare, Round(first.Interest/first.Payment*100, 4), 1e-12)
+	if first.InterestShare == Round2(first.InterestShare) {
+		t.Fatalf("доля процентов %v не должна обрезаться до денежных двух знаков", first.InterestShare)
+	}
+}
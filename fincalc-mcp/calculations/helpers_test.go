@@ -0,0 +1,14 @@
+package calculations
+
+import (
+	"math"
+	"testing"
+)
+
+// inDelta проверяет, что got отличается от want не более чем на delta.
+func inDelta(t *testing.T, got, want, delta float64) {
+	t.Helper()
+	if math.Abs(got-want) > delta {
+		t.Fatalf("получено %v, ожидалось %v (допуск %v)", got, want, delta)
+	}
+}
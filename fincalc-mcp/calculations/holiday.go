@@ -0,0 +1,129 @@
+package calculations
+
+import "fmt"
+
+// Режимы восстановления графика после платежных каникул.
+const (
+	HolidayKeepTerm   = "keep_term"   // выше платеж, исходная дата окончания
+	HolidayExtendTerm = "extend_term" // прежний платеж, срок продлевается
+)
+
+// HolidayResult — график кредита с одним пропущенным платежом и цена
+// каникул в дополнительных процентах.
+type HolidayResult struct {
+	*CalculationResult
+	HolidayMonth      int     `json:"holiday_month"`
+	Mode              string  `json:"mode"`
+	NewMonthlyPayment float64 `json:"new_monthly_payment,omitempty"`
+	ExtraMonths       int     `json:"extra_months,omitempty"`
+	BaselineInterest  float64 `json:"baseline_interest"`
+	AddedInterest     float64 `json:"added_interest"`
+}
+
+// PaymentHoliday моделирует каникулы в один месяц: платеж не вносится,
+// проценты капитализируются в тело долга, после чего график либо
+// пересчитывается под исходную дату окончания (keep_term), либо
+// продлевается при прежнем платеже (extend_term).
+func PaymentHoliday(cfg Config, p LoanParams, holidayMonth int, mode string) (*HolidayResult, error) {
+	baseline, err := AnnuitySchedule(cfg, p)
+	if err != nil {
+		return nil, err
+	}
+	if holidayMonth < 1 || holidayMonth > p.Months {
+		return nil, errOutOfRange("holiday_month", 1, p.Months)
+	}
+	if mode != HolidayKeepTerm && mode != HolidayExtendTerm {
+		return nil, fmt.Errorf("параметр %q должен быть %q или %q", "mode", HolidayKeepTerm, HolidayExtendTerm)
+	}
+	r := MonthlyRate(p.AnnualRatePercent)
+	payment := AnnuityPayment(p.Principal, p.AnnualRatePercent, p.Months)
+	res := &CalculationResult{
+		Kind:              KindAnnuity,
+		Principal:         p.Principal,
+		AnnualRatePercent: p.AnnualRatePercent,
+		Schedule:          make([]ScheduleEntry, 0, p.Months),
+	}
+	out := &HolidayResult{CalculationResult: res, HolidayMonth: holidayMonth, Mode: mode}
+
+	appendEntry := func(m int, pay, principalPart, interest, balance float64) {
+		// В месяц каникул проценты начислены, но не уплачены.
+		paid := interest
+		if pay == 0 {
+			paid = 0
+		}
+		res.Schedule = append(res.Schedule, ScheduleEntry{
+			Month:              m,
+			Payment:            pay,
+			PrincipalComponent: principalPart,
+			Interest:           interest,
+			AccruedInterest:    interest,
+			PaidInterest:       paid,
+			RemainingPrincipal: balance,
+		})
+		res.TotalPayment += pay
+		res.TotalInterest += interest
+	}
+
+	balance := p.Principal
+	// Обычные платежи до каникул.
+	for m := 1; m < holidayMonth; m++ {
+		interest := Round(balance*r, cfg.Precision)
+		principalPart := Round(payment-interest, cfg.Precision)
+		balance = Round(balance-principalPart, cfg.Precision)
+		appendEntry(m, Round(principalPart+interest, cfg.Precision), principalPart, interest, balance)
+	}
+	// Месяц каникул: платежа нет, проценты капитализируются в тело долга.
+	holidayInterest := Round(balance*r, cfg.Precision)
+	balance = Round(balance+holidayInterest, cfg.Precision)
+	appendEntry(holidayMonth, 0, -holidayInterest, holidayInterest, balance)
+
+	switch mode {
+	case HolidayKeepTerm:
+		remaining := p.Months - holidayMonth
+		if remaining == 0 {
+			// Каникулы в последний месяц: долг гасится одним платежом
+			// месяцем позже, срок формально растет на один месяц.
+			out.ExtraMonths = 1
+			remaining = 1
+		}
+		newPayment := AnnuityPayment(balance, p.AnnualRatePercent, remaining)
+		out.NewMonthlyPayment = Round(newPayment, cfg.Precision)
+		for m := holidayMonth + 1; m <= holidayMonth+remaining; m++ {
+			interest := Round(balance*r, cfg.Precision)
+			principalPart := Round(newPayment-interest, cfg.Precision)
+			if m == holidayMonth+remaining {
+				principalPart = Round(balance, cfg.Precision)
+			}
+			balance = Round(balance-principalPart, cfg.Precision)
+			if balance < 0 {
+				balance = 0
+			}
+			appendEntry(m, Round(principalPart+interest, cfg.Precision), principalPart, interest, balance)
+		}
+	case HolidayExtendTerm:
+		for m := holidayMonth + 1; balance > 0; m++ {
+			if m > cfg.MaxMonths+1 {
+				return nil, fmt.Errorf("платеж не гасит долг в пределах %d месяцев", cfg.MaxMonths)
+			}
+			interest := Round(balance*r, cfg.Precision)
+			if payment <= interest {
+				return nil, fmt.Errorf("платеж %.2f не покрывает проценты после капитализации", payment)
+			}
+			principalPart := Round(payment-interest, cfg.Precision)
+			if principalPart >= balance {
+				principalPart = Round(balance, cfg.Precision)
+			}
+			balance = Round(balance-principalPart, cfg.Precision)
+			appendEntry(m, Round(principalPart+interest, cfg.Precision), principalPart, interest, balance)
+		}
+		out.ExtraMonths = len(res.Schedule) - p.Months
+	}
+
+	res.Months = len(res.Schedule)
+	res.MonthlyPayment = Round(payment, cfg.Precision)
+	res.TotalPayment = Round(res.TotalPayment, cfg.Precision)
+	res.TotalInterest = Round(res.TotalInterest, cfg.Precision)
+	out.BaselineInterest = baseline.TotalInterest
+	out.AddedInterest = Round(res.TotalInterest-baseline.TotalInterest, cfg.Precision)
+	return out, nil
+}
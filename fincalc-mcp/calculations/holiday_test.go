@@ -0,0 +1,58 @@
+package calculations
+
+import "testing"
+
+func TestPaymentHolidayKeepTerm(t *testing.T) {
+	cfg := DefaultConfig()
+	p := LoanParams{Principal: 1_000_000, AnnualRatePercent: 12, Months: 12}
+	res, err := PaymentHoliday(cfg, p, 3, HolidayKeepTerm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Months != 12 {
+		t.Fatalf("срок должен сохраниться, получено %d месяцев", res.Months)
+	}
+	if res.Schedule[2].Payment != 0 {
+		t.Fatalf("в месяц каникул платеж должен быть 0, получено %v", res.Schedule[2].Payment)
+	}
+	if res.NewMonthlyPayment <= res.MonthlyPayment {
+		t.Fatalf("новый платеж %v должен превышать исходный %v", res.NewMonthlyPayment, res.MonthlyPayment)
+	}
+	if res.AddedInterest <= 0 {
+		t.Fatalf("каникулы должны стоить дополнительных процентов, получено %v", res.AddedInterest)
+	}
+	inDelta(t, res.Schedule[len(res.Schedule)-1].RemainingPrincipal, 0, 0.01)
+}
+
+func TestPaymentHolidayExtendTerm(t *testing.T) {
+	cfg := DefaultConfig()
+	p := LoanParams{Principal: 1_000_000, AnnualRatePercent: 12, Months: 12}
+	res, err := PaymentHoliday(cfg, p, 3, HolidayExtendTerm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.ExtraMonths < 1 {
+		t.Fatalf("срок должен продлиться минимум на месяц, получено %d", res.ExtraMonths)
+	}
+	if res.Months != 12+res.ExtraMonths {
+		t.Fatalf("длина графика %d не согласуется с продлением %d", res.Months, res.ExtraMonths)
+	}
+	if res.AddedInterest <= 0 {
+		t.Fatalf("каникулы должны стоить дополнительных процентов, получено %v", res.AddedInterest)
+	}
+	inDelta(t, res.Schedule[len(res.Schedule)-1].RemainingPrincipal, 0, 0.01)
+}
+
+func TestPaymentHolidayValidation(t *testing.T) {
+	cfg := DefaultConfig()
+	p := LoanParams{Principal: 1_000_000, AnnualRatePercent: 12, Months: 12}
+	if _, err := PaymentHoliday(cfg, p, 0, HolidayKeepTerm); err == nil {
+		t.Fatal("ожидалась ошибка для месяца каникул вне диапазона")
+	}
+	if _, err := PaymentHoliday(cfg, p, 13, HolidayKeepTerm); err == nil {
+		t.Fatal("ожидалась ошибка для месяца каникул после окончания срока")
+	}
+	if _, err := PaymentHoliday(cfg, p, 3, "ни то ни се"); err == nil {
+		t.Fatal("ожидалась ошибка для неизвестного режима")
+	}
+}
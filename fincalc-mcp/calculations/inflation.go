@@ -0,0 +1,61 @@
+package calculations
+
+import "math"
+
+// RealPaymentYear — платежи одного года кредита в номинале и в реальном
+// выражении (в ценах месяца выдачи).
+type RealPaymentYear struct {
+	Year        int     `json:"year"`
+	NominalPaid float64 `json:"nominal_paid"`
+	RealPaid    float64 `json:"real_paid"`
+}
+
+// RealBurdenResult — реальная (дефлированная) нагрузка кредита: фиксированный
+// платеж при положительной инфляции дешевеет год от года.
+type RealBurdenResult struct {
+	AnnualInflationPercent float64           `json:"annual_inflation_percent"`
+	Years                  []RealPaymentYear `json:"years"`
+	TotalNominalCost       float64           `json:"total_nominal_cost"`
+	TotalRealCost          float64           `json:"total_real_cost"`
+	// RealSavings — насколько реальная стоимость кредита ниже номинальной.
+	RealSavings float64 `json:"real_savings"`
+}
+
+// RealPaymentBurden дефлирует платежи аннуитетного графика накопленной
+// инфляцией и агрегирует их по годам: сколько платеж «стоит» в ценах
+// месяца выдачи и какова реальная суммарная стоимость кредита.
+func RealPaymentBurden(cfg Config, p LoanParams, annualInflationPercent float64) (*RealBurdenResult, error) {
+	if annualInflationPercent < 0 || annualInflationPercent > cfg.MaxRate {
+		return nil, errOutOfRange("annual_inflation_percent", 0, int(cfg.MaxRate))
+	}
+	res, err := AnnuitySchedule(cfg, p)
+	if err != nil {
+		return nil, err
+	}
+	monthlyFactor := math.Pow(1+annualInflationPercent/100, 1.0/12)
+	out := &RealBurdenResult{
+		AnnualInflationPercent: annualInflationPercent,
+		Years:                  make([]RealPaymentYear, 0, (p.Months+11)/12),
+	}
+	deflator := 1.0
+	var year RealPaymentYear
+	for _, entry := range res.Schedule {
+		deflator *= monthlyFactor
+		real := entry.Payment / deflator
+		year.NominalPaid += entry.Payment
+		year.RealPaid += real
+		out.TotalNominalCost += entry.Payment
+		out.TotalRealCost += real
+		if entry.Month%12 == 0 || entry.Month == p.Months {
+			year.Year = (entry.Month + 11) / 12
+			year.NominalPaid = Round2(year.NominalPaid)
+			year.RealPaid = Round2(year.RealPaid)
+			out.Years = append(out.Years, year)
+			year = RealPaymentYear{}
+		}
+	}
+	out.TotalNominalCost = Round2(out.TotalNominalCost)
+	out.TotalRealCost = Round2(out.TotalRealCost)
+	out.RealSavings = Round2(out.TotalNominalCost - out.TotalRealCost)
+	return out, nil
+}
@@ -0,0 +1,31 @@
+package calculations
+
+import "testing"
+
+func TestRealPaymentBurdenDeclines(t *testing.T) {
+	cfg := DefaultConfig()
+	res, err := RealPaymentBurden(cfg, LoanParams{Principal: 5_000_000, AnnualRatePercent: 10, Months: 120}, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Years) != 10 {
+		t.Fatalf("ожидалось 10 годовых строк, получено %d", len(res.Years))
+	}
+	// При положительной инфляции реальная нагрузка падает год от года.
+	for i := 1; i < len(res.Years); i++ {
+		if res.Years[i].RealPaid >= res.Years[i-1].RealPaid {
+			t.Fatalf("год %d: реальные платежи должны убывать (%v -> %v)",
+				res.Years[i].Year, res.Years[i-1].RealPaid, res.Years[i].RealPaid)
+		}
+	}
+	if res.TotalRealCost >= res.TotalNominalCost {
+		t.Fatalf("реальная стоимость должна быть ниже номинальной: %v >= %v",
+			res.TotalRealCost, res.TotalNominalCost)
+	}
+	// Нулевая инфляция: реальные платежи совпадают с номинальными.
+	flat, err := RealPaymentBurden(cfg, LoanParams{Principal: 5_000_000, AnnualRatePercent: 10, Months: 120}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	inDelta(t, flat.TotalRealCost, flat.TotalNominalCost, 0.01)
+}
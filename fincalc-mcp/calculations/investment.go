@@ -0,0 +1,65 @@
+package calculations
+
+import "math"
+
+// InvestmentParams — входные параметры расчета регулярных инвестиций.
+type InvestmentParams struct {
+	InitialAmount           float64
+	MonthlyContribution     float64
+	AnnualReturnPercent     float64
+	Months                  int
+	ContributionAtBeginning bool
+	// ContributionEarnsImmediately — взнос участвует в доходе своего месяца
+	// независимо от момента внесения (см. DepositParams).
+	ContributionEarnsImmediately bool
+}
+
+// InvestmentResult дополняет помесячный график итоговыми метриками
+// доходности.
+type InvestmentResult struct {
+	*CalculationResult
+	TotalInvested           float64 `json:"total_invested"`
+	CapitalGain             float64 `json:"capital_gain"`
+	ROIPercent              float64 `json:"roi_percent"`
+	AnnualizedReturnPercent float64 `json:"annualized_return_percent"`
+}
+
+// GrowthMetrics вычисляет итоговые метрики доходности по графику:
+// абсолютный прирост, ROI и годовую доходность в процентах. Денежные поля
+// округляются денежной точностью, процентные — процентной.
+func GrowthMetrics(cfg Config, res *CalculationResult, totalInvested float64) (capitalGain, roiPercent, annualizedPercent float64) {
+	capitalGain = Round(res.FinalBalance-totalInvested, cfg.Precision)
+	if totalInvested > 0 {
+		roi := res.FinalBalance/totalInvested - 1
+		roiPercent = RoundPercent(cfg, roi*100)
+		years := float64(res.Months) / 12
+		if years > 0 {
+			annualizedPercent = RoundPercent(cfg, (math.Pow(1+roi, 1/years)-1)*100)
+		}
+	}
+	return capitalGain, roiPercent, annualizedPercent
+}
+
+// CalculateInvestment моделирует регулярные инвестиции через модель вклада
+// с капитализацией дохода.
+func CalculateInvestment(cfg Config, p InvestmentParams) (*InvestmentResult, error) {
+	res, err := DepositSchedule(cfg, DepositParams{
+		InitialAmount:                p.InitialAmount,
+		AnnualRatePercent:            p.AnnualReturnPercent,
+		Months:                       p.Months,
+		MonthlyContribution:          p.MonthlyContribution,
+		Capitalize:                   true,
+		ContributionAtBeginning:      p.ContributionAtBeginning,
+		ContributionEarnsImmediately: p.ContributionEarnsImmediately,
+	})
+	if err != nil {
+		return nil, err
+	}
+	res.Kind = KindInvestment
+	inv := &InvestmentResult{
+		CalculationResult: res,
+		TotalInvested:     Round2(p.InitialAmount + res.TotalContributions),
+	}
+	inv.CapitalGain, inv.ROIPercent, inv.AnnualizedReturnPercent = GrowthMetrics(cfg, res, inv.TotalInvested)
+	return inv, nil
+}
@@ -0,0 +1,103 @@
+package calculations
+
+// IOThenAmortizingResult — кредит «сначала только проценты, затем полная
+// амортизация» с раскрытием скачка платежа на переходе и ценой льготной
+// фазы против обычного аннуитета на тот же общий срок.
+type IOThenAmortizingResult struct {
+	*CalculationResult
+	InterestOnlyMonths int     `json:"interest_only_months"`
+	AmortizingMonths   int     `json:"amortizing_months"`
+	InterestOnlyPay    float64 `json:"interest_only_payment"`
+	AmortizingPayment  float64 `json:"amortizing_payment"`
+	// PaymentJump — на сколько вырастает платеж при переходе к амортизации.
+	PaymentJump float64 `json:"payment_jump"`
+	// InterestPremium — переплата относительно полностью амортизируемого
+	// кредита на тот же общий срок.
+	InterestPremium float64 `json:"interest_premium"`
+	// WeightedAverageRatePercent — средневзвешенная по остатку долга
+	// фактическая ставка графика (см. WeightedAverageRate).
+	WeightedAverageRatePercent float64 `json:"weighted_average_rate_percent"`
+}
+
+// IOThenAmortizing строит единый график кредита с длинной фазой
+// «только проценты» (долг не убывает) и последующей полной амортизацией
+// остатка аннуитетом. Распространенная структура коммерческого
+// кредитования; льгота оплачивается переплатой, которая раскрывается
+// в InterestPremium.
+func IOThenAmortizing(cfg Config, principal, annualRatePercent float64, interestOnlyMonths, amortizingMonths int) (*IOThenAmortizingResult, error) {
+	if interestOnlyMonths < 1 {
+		return nil, errOutOfRange("interest_only_months", 1, cfg.MaxMonths)
+	}
+	if amortizingMonths < 1 {
+		return nil, errOutOfRange("amortizing_months", 1, cfg.MaxMonths)
+	}
+	total := interestOnlyMonths + amortizingMonths
+	p := LoanParams{Principal: principal, AnnualRatePercent: annualRatePercent, Months: total}
+	if err := p.validate(cfg); err != nil {
+		return nil, err
+	}
+	acc := newAccruer(cfg, annualRatePercent)
+	res := &CalculationResult{
+		Kind:              KindAnnuity,
+		Principal:         principal,
+		AnnualRatePercent: annualRatePercent,
+		Months:            total,
+		Schedule:          make([]ScheduleEntry, 0, total),
+	}
+	balance := principal
+	ioPayment := 0.0
+	for m := 1; m <= interestOnlyMonths; m++ {
+		interest := acc.Interest(m, balance)
+		ioPayment = interest
+		res.Schedule = append(res.Schedule, ScheduleEntry{
+			Month:              m,
+			Payment:            interest,
+			Interest:           interest,
+			RemainingPrincipal: balance,
+		})
+		res.TotalPayment += interest
+		res.TotalInterest += interest
+	}
+	payment := AnnuityPayment(balance, annualRatePercent, amortizingMonths)
+	res.MonthlyPayment = Round(payment, cfg.Precision)
+	for m := interestOnlyMonths + 1; m <= total; m++ {
+		interest := acc.Interest(m, balance)
+		principalPart := Round(payment-interest, cfg.Precision)
+		if m == total {
+			principalPart = Round(balance, cfg.Precision)
+		}
+		var err error
+		if balance, err = acc.ReduceDebt(m, balance, principalPart); err != nil {
+			return nil, err
+		}
+		pay := Round(principalPart+interest, cfg.Precision)
+		res.Schedule = append(res.Schedule, ScheduleEntry{
+			Month:              m,
+			Payment:            pay,
+			PrincipalComponent: principalPart,
+			Interest:           interest,
+			RemainingPrincipal: balance,
+		})
+		res.TotalPayment += pay
+		res.TotalInterest += interest
+	}
+	res.TotalPayment = Round(res.TotalPayment, cfg.Precision)
+	res.TotalInterest = Round(res.TotalInterest, cfg.Precision)
+	res.CrossoverMonth = CrossoverMonth(res.Schedule)
+	fillPaymentShares(cfg, res.Schedule)
+
+	baseline, err := AnnuitySchedule(cfg, p)
+	if err != nil {
+		return nil, err
+	}
+	return &IOThenAmortizingResult{
+		CalculationResult:          res,
+		InterestOnlyMonths:         interestOnlyMonths,
+		AmortizingMonths:           amortizingMonths,
+		InterestOnlyPay:            ioPayment,
+		AmortizingPayment:          res.MonthlyPayment,
+		PaymentJump:                Round(res.MonthlyPayment-ioPayment, cfg.Precision),
+		InterestPremium:            Round(res.TotalInterest-baseline.TotalInterest, cfg.Precision),
+		WeightedAverageRatePercent: WeightedAverageRate(res.Schedule, principal),
+	}, nil
+}
@@ -0,0 +1,30 @@
+package calculations
+
+import "testing"
+
+func TestIOThenAmortizingPaymentJump(t *testing.T) {
+	cfg := DefaultConfig()
+	res, err := IOThenAmortizing(cfg, 10_000_000, 12, 36, 84)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Months != 120 || len(res.Schedule) != 120 {
+		t.Fatalf("ожидался общий срок 120 месяцев, получено %d", res.Months)
+	}
+	// Во время льготной фазы платеж равен процентам и долг не убывает.
+	inDelta(t, res.Schedule[0].Payment, 100_000, 0.01)
+	inDelta(t, res.Schedule[35].RemainingPrincipal, 10_000_000, 0.01)
+	// На переходе платеж скачет вверх.
+	if res.Schedule[36].Payment <= res.Schedule[35].Payment {
+		t.Fatalf("платеж должен вырасти на переходе: %v <= %v",
+			res.Schedule[36].Payment, res.Schedule[35].Payment)
+	}
+	inDelta(t, res.PaymentJump, res.AmortizingPayment-res.InterestOnlyPay, 0.01)
+	// Льготная фаза оплачивается переплатой против обычного аннуитета.
+	if res.InterestPremium <= 0 {
+		t.Fatalf("переплата против полного аннуитета должна быть положительной, получено %v", res.InterestPremium)
+	}
+	if last := res.Schedule[119].RemainingPrincipal; last != 0 {
+		t.Fatalf("долг должен быть погашен к концу срока, получено %v", last)
+	}
+}
@@ -0,0 +1,39 @@
+package calculations
+
+import "fmt"
+
+// NPV возвращает чистую приведенную стоимость денежного потока при ставке
+// за период rate. cashflows[0] — поток в момент 0 (без дисконтирования).
+func NPV(cashflows []float64, rate float64) float64 {
+	factor := 1.0
+	npv := 0.0
+	for _, flow := range cashflows {
+		npv += flow / factor
+		factor *= 1 + rate
+	}
+	return npv
+}
+
+// IRR возвращает внутреннюю норму доходности денежного потока — ставку за
+// период, при которой NPV обращается в ноль. Поток должен содержать хотя бы
+// одну смену знака; при нескольких сменах возвращается один из корней.
+func IRR(cashflows []float64) (float64, error) {
+	if len(cashflows) < 2 {
+		return 0, fmt.Errorf("денежный поток должен содержать минимум два периода")
+	}
+	lo, hi := -0.95, 10.0
+	npvLo, npvHi := NPV(cashflows, lo), NPV(cashflows, hi)
+	if npvLo*npvHi > 0 {
+		return 0, fmt.Errorf("IRR не найдена: NPV не меняет знак на разумном диапазоне ставок")
+	}
+	for i := 0; i < bisectionSteps; i++ {
+		mid := (lo + hi) / 2
+		if NPV(cashflows, mid)*npvLo <= 0 {
+			hi = mid
+		} else {
+			lo = mid
+			npvLo = NPV(cashflows, lo)
+		}
+	}
+	return (lo + hi) / 2, nil
+}
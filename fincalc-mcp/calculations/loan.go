@@ -0,0 +1,71 @@
+package calculations
+
+import (
+	"fmt"
+	"math"
+)
+
+// LoanParams — входные параметры кредитного расчета.
+type LoanParams struct {
+	Principal         float64
+	AnnualRatePercent float64
+	Months            int
+}
+
+func (p LoanParams) validate(cfg Config) error {
+	if err := CheckPrincipal(cfg, p.Principal); err != nil {
+		return err
+	}
+	if err := CheckRate(cfg, p.AnnualRatePercent); err != nil {
+		return err
+	}
+	return CheckMonths(cfg, p.Months)
+}
+
+// RemainingBalance возвращает остаток основного долга аннуитетного кредита
+// после paidMonths внесенных платежей.
+func RemainingBalance(cfg Config, p LoanParams, paidMonths int) (float64, error) {
+	if paidMonths < 0 || paidMonths > p.Months {
+		return 0, errOutOfRange("paid_months", 0, p.Months)
+	}
+	if paidMonths == 0 {
+		return p.Principal, nil
+	}
+	res, err := AnnuitySchedule(cfg, p)
+	if err != nil {
+		return 0, err
+	}
+	return res.Schedule[paidMonths-1].RemainingPrincipal, nil
+}
+
+// LoanFairValue считает справедливую цену кредита на вторичном рынке:
+// текущую стоимость платежей с месяца fromMonth до конца графика по
+// рыночной ставке дисконтирования. Нулевая ставка дает простую сумму
+// оставшихся платежей.
+func LoanFairValue(result *CalculationResult, fromMonth int, discountAnnualPercent float64) (float64, error) {
+	if fromMonth < 1 || fromMonth > len(result.Schedule) {
+		return 0, errOutOfRange("from_month", 1, len(result.Schedule))
+	}
+	if discountAnnualPercent < 0 {
+		return 0, fmt.Errorf("ставка дисконтирования не может быть отрицательной")
+	}
+	d := MonthlyRate(discountAnnualPercent)
+	value := 0.0
+	for i, entry := range result.Schedule[fromMonth-1:] {
+		value += entry.Payment / math.Pow(1+d, float64(i+1))
+	}
+	return Round2(value), nil
+}
+
+// CumulativeInterestBy возвращает сумму процентов, уплаченных по графику
+// за первые month месяцев — «сколько процентов я уже заплатил к месяцу k».
+func CumulativeInterestBy(result *CalculationResult, month int) (float64, error) {
+	if month < 1 || month > len(result.Schedule) {
+		return 0, errOutOfRange("month", 1, len(result.Schedule))
+	}
+	total := 0.0
+	for _, entry := range result.Schedule[:month] {
+		total += entry.Interest
+	}
+	return Round2(total), nil
+}
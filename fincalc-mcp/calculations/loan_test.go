@@ -0,0 +1,61 @@
+package calculations
+
+import "testing"
+
+func TestCumulativeInterestByMatchesSchedule(t *testing.T) {
+	cfg := DefaultConfig()
+	res, err := AnnuitySchedule(cfg, LoanParams{Principal: 3_000_000, AnnualRatePercent: 11, Months: 120})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, month := range []int{1, 12, 60, 120} {
+		got, err := CumulativeInterestBy(res, month)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := 0.0
+		for _, entry := range res.Schedule[:month] {
+			want += entry.Interest
+		}
+		inDelta(t, got, want, 0.01)
+	}
+	if _, err := CumulativeInterestBy(res, 0); err == nil {
+		t.Fatal("ожидалась ошибка для месяца 0")
+	}
+	if _, err := CumulativeInterestBy(res, 121); err == nil {
+		t.Fatal("ожидалась ошибка для месяца за пределами графика")
+	}
+}
+
+func TestLoanFairValueDiscounting(t *testing.T) {
+	cfg := DefaultConfig()
+	res, err := AnnuitySchedule(cfg, LoanParams{Principal: 1_000_000, AnnualRatePercent: 12, Months: 60})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Нулевая ставка дисконтирования — простая сумма оставшихся платежей.
+	flat, err := LoanFairValue(res, 13, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := 0.0
+	for _, entry := range res.Schedule[12:] {
+		want += entry.Payment
+	}
+	inDelta(t, flat, want, 0.01)
+	// Чем выше рыночная ставка, тем дешевле кредит для покупателя.
+	low, err := LoanFairValue(res, 13, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	high, err := LoanFairValue(res, 13, 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !(high < low && low < flat) {
+		t.Fatalf("рост ставки должен снижать цену: %v, %v, %v", flat, low, high)
+	}
+	if _, err := LoanFairValue(res, 61, 10); err == nil {
+		t.Fatal("ожидалась ошибка для месяца за пределами графика")
+	}
+}
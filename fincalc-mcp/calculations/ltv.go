@@ -0,0 +1,59 @@
+package calculations
+
+import "fmt"
+
+// LTVMilestone — месяц, в котором отношение долга к стоимости актива
+// впервые опускается до целевого уровня (снятие страховки, учет капитала).
+type LTVMilestone struct {
+	TargetLTVPercent  float64 `json:"target_ltv_percent"`
+	MilestoneMonth    int     `json:"milestone_month"` // -1, если в пределах срока не достигается
+	RemainingDebt     float64 `json:"remaining_debt,omitempty"`
+	AssetValue        float64 `json:"asset_value,omitempty"`
+	LTVPercent        float64 `json:"ltv_percent,omitempty"`
+	InitialLTVPercent float64 `json:"initial_ltv_percent"`
+}
+
+// FindLTVMilestone возвращает первый месяц аннуитетного кредита, в котором
+// LTV (долг к стоимости актива) не превышает целевой. Стоимость актива
+// опционально растет каждый месяц на annualAppreciationPercent/12.
+// Если целевой LTV не достигается в пределах срока, MilestoneMonth равен -1.
+func FindLTVMilestone(cfg Config, p LoanParams, assetPrice, annualAppreciationPercent, targetLTVPercent float64) (*LTVMilestone, error) {
+	if assetPrice <= 0 || assetPrice > cfg.MaxBalance {
+		return nil, &ValidationError{
+			Field:   "asset_price",
+			Value:   assetPrice,
+			Min:     0,
+			Max:     cfg.MaxBalance,
+			Message: fmt.Sprintf("стоимость актива должна быть больше 0 и не более %.0f", cfg.MaxBalance),
+		}
+	}
+	if err := CheckRate(cfg, annualAppreciationPercent); err != nil {
+		return nil, err
+	}
+	if targetLTVPercent <= 0 || targetLTVPercent >= 100 {
+		return nil, errOutOfRange("target_ltv_percent", 1, 99)
+	}
+	res, err := AnnuitySchedule(cfg, p)
+	if err != nil {
+		return nil, err
+	}
+	out := &LTVMilestone{
+		TargetLTVPercent:  targetLTVPercent,
+		MilestoneMonth:    -1,
+		InitialLTVPercent: Round(p.Principal/assetPrice*100, 4),
+	}
+	growth := 1 + MonthlyRate(annualAppreciationPercent)
+	value := assetPrice
+	for _, entry := range res.Schedule {
+		value *= growth
+		ltv := entry.RemainingPrincipal / value * 100
+		if ltv <= targetLTVPercent {
+			out.MilestoneMonth = entry.Month
+			out.RemainingDebt = entry.RemainingPrincipal
+			out.AssetValue = Round(value, cfg.Precision)
+			out.LTVPercent = Round(ltv, 4)
+			break
+		}
+	}
+	return out, nil
+}
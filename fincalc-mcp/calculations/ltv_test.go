@@ -0,0 +1,75 @@
+package calculations
+
+import "testing"
+
+func TestFindLTVMilestoneWithoutAppreciation(t *testing.T) {
+	cfg := DefaultConfig()
+	p := LoanParams{Principal: 8_000_000, AnnualRatePercent: 10, Months: 240}
+	res, err := FindLTVMilestone(cfg, p, 10_000_000, 0, 70)
+	if err != nil {
+		t.Fatal(err)
+	}
+	inDelta(t, res.InitialLTVPercent, 80, 0.01)
+	if res.MilestoneMonth <= 0 {
+		t.Fatalf("порог 70%% должен достигаться в пределах срока, получено %d", res.MilestoneMonth)
+	}
+	// Достигнутый LTV не выше целевого, а месяцем раньше — выше.
+	if res.LTVPercent > 70 {
+		t.Fatalf("LTV в месяц достижения %v не должен превышать 70", res.LTVPercent)
+	}
+	schedule, err := AnnuitySchedule(cfg, p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	prev := schedule.Schedule[res.MilestoneMonth-2].RemainingPrincipal / 10_000_000 * 100
+	if prev <= 70 {
+		t.Fatalf("месяцем раньше LTV %v уже был не выше цели", prev)
+	}
+}
+
+func TestFindLTVMilestoneAppreciationSpeedsUp(t *testing.T) {
+	cfg := DefaultConfig()
+	p := LoanParams{Principal: 8_000_000, AnnualRatePercent: 10, Months: 240}
+	flat, err := FindLTVMilestone(cfg, p, 10_000_000, 0, 70)
+	if err != nil {
+		t.Fatal(err)
+	}
+	growing, err := FindLTVMilestone(cfg, p, 10_000_000, 5, 70)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if growing.MilestoneMonth >= flat.MilestoneMonth {
+		t.Fatalf("рост актива должен приближать цель: %d >= %d", growing.MilestoneMonth, flat.MilestoneMonth)
+	}
+}
+
+func TestFindLTVMilestoneTightTargetHitsTermEnd(t *testing.T) {
+	cfg := DefaultConfig()
+	// Аннуитет гасится полностью, поэтому даже жесткая цель достигается
+	// ближе к концу срока (в последний месяц долг равен нулю).
+	p := LoanParams{Principal: 9_000_000, AnnualRatePercent: 15, Months: 12}
+	res, err := FindLTVMilestone(cfg, p, 10_000_000, 0, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.MilestoneMonth < 11 || res.MilestoneMonth > 12 {
+		t.Fatalf("жесткая цель должна достигаться в самом конце срока, получено %d", res.MilestoneMonth)
+	}
+	if res.LTVPercent > 1 {
+		t.Fatalf("LTV в месяц достижения %v не должен превышать 1", res.LTVPercent)
+	}
+}
+
+func TestFindLTVMilestoneValidation(t *testing.T) {
+	cfg := DefaultConfig()
+	p := LoanParams{Principal: 8_000_000, AnnualRatePercent: 10, Months: 240}
+	if _, err := FindLTVMilestone(cfg, p, 0, 0, 70); err == nil {
+		t.Fatal("ожидалась ошибка для нулевой стоимости актива")
+	}
+	if _, err := FindLTVMilestone(cfg, p, 10_000_000, 0, 0); err == nil {
+		t.Fatal("ожидалась ошибка для нулевого целевого LTV")
+	}
+	if _, err := FindLTVMilestone(cfg, p, 10_000_000, 0, 100); err == nil {
+		t.Fatal("ожидалась ошибка для целевого LTV 100")
+	}
+}
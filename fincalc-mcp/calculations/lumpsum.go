@@ -0,0 +1,51 @@
+package calculations
+
+import (
+	"fmt"
+	"math"
+)
+
+// PresentValue возвращает сумму, которую нужно разместить сегодня под
+// ежемесячную капитализацию, чтобы через заданный срок получить futureAmount.
+func PresentValue(futureAmount, annualRatePercent float64, months int) float64 {
+	monthlyRate := annualRatePercent / 12 / 100
+	return futureAmount / math.Pow(1+monthlyRate, float64(months))
+}
+
+// LumpSumForGoal считает разовый вклад «сегодня», достаточный для достижения
+// целевой суммы за срок без дальнейших пополнений. Если пополнения все же
+// планируются, из цели сначала вычитается их будущая стоимость. Ошибка, если
+// требуемый вклад превышает cfg.MaxPrincipal.
+func LumpSumForGoal(cfg Config, targetAmount, annualRatePercent float64, months int, monthlyContribution float64) (float64, error) {
+	if targetAmount <= 0 || targetAmount > cfg.MaxBalance {
+		return 0, fmt.Errorf("целевая сумма должна быть в диапазоне от 0 до %.0f", cfg.MaxBalance)
+	}
+	if err := CheckRate(cfg, annualRatePercent); err != nil {
+		return 0, err
+	}
+	if err := CheckMonths(cfg, months); err != nil {
+		return 0, err
+	}
+	remaining := targetAmount
+	if monthlyContribution > 0 {
+		res, err := DepositSchedule(cfg, DepositParams{
+			InitialAmount:       0,
+			AnnualRatePercent:   annualRatePercent,
+			Months:              months,
+			MonthlyContribution: monthlyContribution,
+			Capitalize:          true,
+		})
+		if err != nil {
+			return 0, err
+		}
+		remaining -= res.FinalBalance
+	}
+	if remaining <= 0 {
+		return 0, nil
+	}
+	upfront := PresentValue(remaining, annualRatePercent, months)
+	if upfront > cfg.MaxPrincipal {
+		return 0, fmt.Errorf("требуемый вклад %.2f превышает максимум %.0f", upfront, cfg.MaxPrincipal)
+	}
+	return Round2(upfront), nil
+}
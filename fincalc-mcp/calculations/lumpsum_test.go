@@ -0,0 +1,40 @@
+package calculations
+
+import "testing"
+
+func TestLumpSumForGoal(t *testing.T) {
+	cfg := DefaultConfig()
+	target := 1_000_000.0
+	lump, err := LumpSumForGoal(cfg, target, 12, 12, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Размещенный сегодня вклад должен дорасти до цели.
+	res, err := DepositSchedule(cfg, DepositParams{
+		InitialAmount:     lump,
+		AnnualRatePercent: 12,
+		Months:            12,
+		Capitalize:        true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	inDelta(t, res.FinalBalance, target, 1)
+
+	withContrib, err := LumpSumForGoal(cfg, target, 12, 12, 10_000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if withContrib >= lump {
+		t.Fatalf("пополнения должны снижать разовый вклад: %v >= %v", withContrib, lump)
+	}
+
+	// Пополнения сами покрывают цель — вклад не нужен.
+	zero, err := LumpSumForGoal(cfg, 100_000, 12, 120, 10_000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if zero != 0 {
+		t.Fatalf("ожидался нулевой вклад, получено %v", zero)
+	}
+}
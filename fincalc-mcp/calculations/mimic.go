@@ -0,0 +1,65 @@
+package calculations
+
+// annuityInterestWithExtra возвращает суммарные проценты аннуитетного
+// кредита при фиксированной ежемесячной доплате в тело сверх платежа
+// (платеж сохраняется, срок сокращается). Без округления — для решателя.
+func annuityInterestWithExtra(p LoanParams, extra float64) float64 {
+	r := MonthlyRate(p.AnnualRatePercent)
+	payment := AnnuityPayment(p.Principal, p.AnnualRatePercent, p.Months)
+	balance := p.Principal
+	total := 0.0
+	for m := 1; m <= p.Months && balance > 1e-9; m++ {
+		interest := balance * r
+		principalPart := payment - interest + extra
+		if principalPart > balance {
+			principalPart = balance
+		}
+		balance -= principalPart
+		total += interest
+	}
+	return total
+}
+
+// MimicDifferential подбирает фиксированную ежемесячную доплату к
+// аннуитетному платежу, при которой суммарные проценты аннуитета
+// сравниваются с дифференцированной схемой: предсказуемый платеж при
+// экономии дифференциального. Проценты монотонно убывают по доплате,
+// доплата ищется бисекцией; при нулевой ставке схемы совпадают и
+// доплата равна нулю.
+func MimicDifferential(cfg Config, p LoanParams) (map[string]any, error) {
+	annuity, err := AnnuitySchedule(cfg, p)
+	if err != nil {
+		return nil, err
+	}
+	differential, err := DifferentialSchedule(cfg, p)
+	if err != nil {
+		return nil, err
+	}
+	target := differential.TotalInterest
+	out := map[string]any{
+		"annuity_interest":      annuity.TotalInterest,
+		"differential_interest": target,
+	}
+	if annuity.TotalInterest <= target {
+		out["required_extra_payment"] = 0.0
+		out["mimic_total_interest"] = annuity.TotalInterest
+		out["interest_saved"] = 0.0
+		return out, nil
+	}
+	// Доплата, равная дифференциальной доле тела (principal/months), гасит
+	// долг быстрее дифференциального графика — верхняя граница поиска.
+	lo, hi := 0.0, p.Principal/float64(p.Months)
+	for i := 0; i < bisectionSteps; i++ {
+		mid := (lo + hi) / 2
+		if annuityInterestWithExtra(p, mid) > target {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	extra := (lo + hi) / 2
+	out["required_extra_payment"] = Round2(extra)
+	out["mimic_total_interest"] = Round2(annuityInterestWithExtra(p, extra))
+	out["interest_saved"] = Round2(annuity.TotalInterest - annuityInterestWithExtra(p, extra))
+	return out, nil
+}
@@ -0,0 +1,37 @@
+package calculations
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMimicDifferential(t *testing.T) {
+	cfg := DefaultConfig()
+	p := LoanParams{Principal: 1_000_000, AnnualRatePercent: 12, Months: 120}
+	out, err := MimicDifferential(cfg, p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	extra := out["required_extra_payment"].(float64)
+	if extra <= 0 {
+		t.Fatalf("при положительной ставке нужна положительная доплата, получено %v", extra)
+	}
+	// Аннуитет с найденной доплатой стоит примерно как дифференциальный.
+	mimic := out["mimic_total_interest"].(float64)
+	target := out["differential_interest"].(float64)
+	if math.Abs(mimic-target) > 0.001*target {
+		t.Fatalf("проценты с доплатой %v далеки от дифференциальных %v", mimic, target)
+	}
+	if mimic >= out["annuity_interest"].(float64) {
+		t.Fatal("доплата должна экономить проценты против чистого аннуитета")
+	}
+
+	// При нулевой ставке обе схемы бесплатны — доплата не нужна.
+	free, err := MimicDifferential(cfg, LoanParams{Principal: 1_000_000, AnnualRatePercent: 0, Months: 120})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if free["required_extra_payment"].(float64) != 0 {
+		t.Fatalf("при нулевой ставке доплата должна быть нулевой: %v", free["required_extra_payment"])
+	}
+}
@@ -0,0 +1,65 @@
+package calculations
+
+import "math"
+
+// DatedFlow — датированное движение средств инвестора: положительная
+// сумма — пополнение, отрицательная — снятие в указанном месяце.
+type DatedFlow struct {
+	Month  int     `json:"month"`
+	Amount float64 `json:"amount"`
+}
+
+// MoneyWeightedResult — истинная денежно-взвешенная доходность портфеля
+// с пополнениями и снятиями (в отличие от аналитической годовой доходности
+// CalculateInvestment, которая не учитывает снятия).
+type MoneyWeightedResult struct {
+	MonthlyIRRPercent    float64 `json:"monthly_irr_percent"`
+	AnnualizedIRRPercent float64 `json:"annualized_irr_percent"`
+	TotalContributions   float64 `json:"total_contributions"`
+	TotalWithdrawals     float64 `json:"total_withdrawals"`
+	FinalBalance         float64 `json:"final_balance"`
+}
+
+// MoneyWeightedReturn решает месячную IRR потока инвестора: начальный
+// взнос, датированные пополнения и снятия, итоговый баланс в последнем
+// месяце. Годовая доходность — капитализация месячной ставки.
+// Возвращает ошибку, если поток не меняет знак и IRR не существует.
+func MoneyWeightedReturn(cfg Config, initialAmount float64, flows []DatedFlow, finalBalance float64, months int) (*MoneyWeightedResult, error) {
+	if err := CheckAmount(cfg, initialAmount); err != nil {
+		return nil, err
+	}
+	if err := CheckMonths(cfg, months); err != nil {
+		return nil, err
+	}
+	if finalBalance < 0 || finalBalance > cfg.MaxBalance {
+		return nil, errOutOfRange("final_balance", 0, int(cfg.MaxBalance))
+	}
+	cashflows := make([]float64, months+1)
+	cashflows[0] = -initialAmount
+	out := &MoneyWeightedResult{FinalBalance: finalBalance}
+	for _, flow := range flows {
+		if flow.Month < 1 || flow.Month > months {
+			return nil, errOutOfRange("month", 1, months)
+		}
+		if math.Abs(flow.Amount) > cfg.MaxPrincipal {
+			return nil, errOutOfRange("amount", -int(cfg.MaxPrincipal), int(cfg.MaxPrincipal))
+		}
+		// Пополнение — отток денег инвестора, снятие — приток.
+		cashflows[flow.Month] -= flow.Amount
+		if flow.Amount > 0 {
+			out.TotalContributions += flow.Amount
+		} else {
+			out.TotalWithdrawals -= flow.Amount
+		}
+	}
+	cashflows[months] += finalBalance
+	irr, err := IRR(cashflows)
+	if err != nil {
+		return nil, err
+	}
+	out.MonthlyIRRPercent = Round(irr*100, 4)
+	out.AnnualizedIRRPercent = Round((math.Pow(1+irr, 12)-1)*100, 4)
+	out.TotalContributions = Round(out.TotalContributions, cfg.Precision)
+	out.TotalWithdrawals = Round(out.TotalWithdrawals, cfg.Precision)
+	return out, nil
+}
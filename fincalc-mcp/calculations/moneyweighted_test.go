@@ -0,0 +1,48 @@
+package calculations
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMoneyWeightedReturnRecoversRate(t *testing.T) {
+	cfg := DefaultConfig()
+	// Симулируем портфель с ростом ровно 1% в месяц, пополнением и снятием.
+	const monthly = 0.01
+	balance := 100_000.0
+	flows := []DatedFlow{{Month: 6, Amount: 50_000}, {Month: 9, Amount: -30_000}}
+	for m := 1; m <= 12; m++ {
+		balance *= 1 + monthly
+		for _, flow := range flows {
+			if flow.Month == m {
+				balance += flow.Amount
+			}
+		}
+	}
+	res, err := MoneyWeightedReturn(cfg, 100_000, flows, balance, 12)
+	if err != nil {
+		t.Fatal(err)
+	}
+	inDelta(t, res.MonthlyIRRPercent, 1, 0.001)
+	inDelta(t, res.AnnualizedIRRPercent, (math.Pow(1.01, 12)-1)*100, 0.01)
+	inDelta(t, res.TotalContributions, 50_000, 0.01)
+	inDelta(t, res.TotalWithdrawals, 30_000, 0.01)
+}
+
+func TestMoneyWeightedReturnNoRoot(t *testing.T) {
+	cfg := DefaultConfig()
+	// Только притоки инвестору: знак потока не меняется, IRR не существует.
+	if _, err := MoneyWeightedReturn(cfg, 0, []DatedFlow{{Month: 3, Amount: -10_000}}, 50_000, 12); err == nil {
+		t.Fatal("ожидалась ошибка: поток без смены знака")
+	}
+}
+
+func TestMoneyWeightedReturnValidation(t *testing.T) {
+	cfg := DefaultConfig()
+	if _, err := MoneyWeightedReturn(cfg, 100_000, []DatedFlow{{Month: 13, Amount: 1000}}, 110_000, 12); err == nil {
+		t.Fatal("ожидалась ошибка: месяц за пределами горизонта")
+	}
+	if _, err := MoneyWeightedReturn(cfg, 100_000, nil, -1, 12); err == nil {
+		t.Fatal("ожидалась ошибка для отрицательного итогового баланса")
+	}
+}
@@ -0,0 +1,61 @@
+package calculations
+
+// NetWorthPoint — чистое состояние домохозяйства в конце месяца:
+// накопления минус остаток долга.
+type NetWorthPoint struct {
+	Month          int     `json:"month"`
+	SavingsBalance float64 `json:"savings_balance"`
+	LoanBalance    float64 `json:"loan_balance"`
+	NetWorth       float64 `json:"net_worth"`
+}
+
+// NetWorthProjection строит совместную траекторию кредита и вклада:
+// помесячная разница баланса накоплений и остатка долга на общем
+// горизонте (максимум из двух сроков). После закрытия кредита долг равен
+// нулю, после окончания вклада накопления остаются на достигнутом уровне.
+// PositiveFromMonth — первый месяц с неотрицательным чистым состоянием,
+// -1, если оно так и не выходит в плюс.
+func NetWorthProjection(cfg Config, loan LoanParams, deposit DepositParams) (map[string]any, error) {
+	loanRes, err := AnnuitySchedule(cfg, loan)
+	if err != nil {
+		return nil, err
+	}
+	depositRes, err := DepositSchedule(cfg, deposit)
+	if err != nil {
+		return nil, err
+	}
+	horizon := loan.Months
+	if deposit.Months > horizon {
+		horizon = deposit.Months
+	}
+	if err := cfg.CheckExpansion(horizon); err != nil {
+		return nil, err
+	}
+	points := make([]NetWorthPoint, 0, horizon)
+	positiveFrom := -1
+	for m := 1; m <= horizon; m++ {
+		savings := depositRes.FinalBalance
+		if m <= deposit.Months {
+			savings = depositRes.Schedule[m-1].Balance
+		}
+		debt := 0.0
+		if m <= loan.Months {
+			debt = loanRes.Schedule[m-1].RemainingPrincipal
+		}
+		net := Round(savings-debt, cfg.Precision)
+		points = append(points, NetWorthPoint{
+			Month:          m,
+			SavingsBalance: savings,
+			LoanBalance:    debt,
+			NetWorth:       net,
+		})
+		if positiveFrom == -1 && net >= 0 {
+			positiveFrom = m
+		}
+	}
+	return map[string]any{
+		"points":              points,
+		"final_net_worth":     points[len(points)-1].NetWorth,
+		"positive_from_month": positiveFrom,
+	}, nil
+}
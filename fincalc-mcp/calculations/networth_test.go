@@ -0,0 +1,47 @@
+package calculations
+
+import "testing"
+
+func TestNetWorthProjection(t *testing.T) {
+	cfg := DefaultConfig()
+	loan := LoanParams{Principal: 1_000_000, AnnualRatePercent: 12, Months: 60}
+	deposit := DepositParams{
+		InitialAmount:       100_000,
+		AnnualRatePercent:   8,
+		Months:              60,
+		MonthlyContribution: 25_000,
+		Capitalize:          true,
+	}
+	out, err := NetWorthProjection(cfg, loan, deposit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	points := out["points"].([]NetWorthPoint)
+	if len(points) != 60 {
+		t.Fatalf("ожидалось 60 точек, получено %d", len(points))
+	}
+	// Серия совпадает с независимыми графиками: накопления минус долг.
+	loanRes, err := AnnuitySchedule(cfg, loan)
+	if err != nil {
+		t.Fatal(err)
+	}
+	depositRes, err := DepositSchedule(cfg, deposit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, pt := range points {
+		want := depositRes.Schedule[i].Balance - loanRes.Schedule[i].RemainingPrincipal
+		inDelta(t, pt.NetWorth, want, 0.01)
+	}
+	// Стартуем в минусе, к концу выходим в плюс где-то внутри срока.
+	if points[0].NetWorth >= 0 {
+		t.Fatal("с таким долгом старт должен быть в минусе")
+	}
+	positive := out["positive_from_month"].(int)
+	if positive <= 1 || positive > 60 {
+		t.Fatalf("месяц выхода в плюс %d вне срока", positive)
+	}
+	if points[positive-1].NetWorth < 0 || points[positive-2].NetWorth >= 0 {
+		t.Fatalf("месяц %d не является первым неотрицательным", positive)
+	}
+}
@@ -0,0 +1,83 @@
+package calculations
+
+import (
+	"fmt"
+	"sort"
+)
+
+// LoanOffer — условия одного предложения: ставка, срок и комиссии.
+// Разовая комиссия либо платится при выдаче (UpfrontFee), либо
+// добавляется к телу кредита (FinancedFee).
+type LoanOffer struct {
+	Name              string  `json:"name,omitempty"`
+	AnnualRatePercent float64 `json:"annual_rate_percent"`
+	Months            int     `json:"months"`
+	UpfrontFee        float64 `json:"upfront_fee,omitempty"`
+	FinancedFee       float64 `json:"financed_fee,omitempty"`
+}
+
+// OfferResult — оценка предложения: платеж, полная стоимость для заемщика
+// и истинная APR с учетом комиссий (IRR по чистым потокам).
+type OfferResult struct {
+	LoanOffer
+	MonthlyPayment float64 `json:"monthly_payment"`
+	TotalCost      float64 `json:"total_cost"`
+	APRPercent     float64 `json:"apr_percent"`
+}
+
+// CompareOffers оценивает предложения для одной и той же суммы кредита и
+// ранжирует их по истинной APR: комиссия при выдаче уменьшает фактически
+// полученную сумму, финансируемая — увеличивает тело кредита. Возвращает
+// ранжированный список, самое дешевое предложение и отрыв по полной
+// стоимости от следующего.
+func CompareOffers(cfg Config, principal float64, offers []LoanOffer) (map[string]any, error) {
+	if len(offers) == 0 {
+		return nil, fmt.Errorf("список offers не должен быть пустым")
+	}
+	if err := cfg.CheckExpansion(len(offers)); err != nil {
+		return nil, err
+	}
+	ranked := make([]OfferResult, 0, len(offers))
+	for i, offer := range offers {
+		if offer.UpfrontFee < 0 || offer.FinancedFee < 0 {
+			return nil, fmt.Errorf("комиссии предложения %d не должны быть отрицательными", i+1)
+		}
+		res, err := AnnuitySchedule(cfg, LoanParams{
+			Principal:         principal + offer.FinancedFee,
+			AnnualRatePercent: offer.AnnualRatePercent,
+			Months:            offer.Months,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("предложение %d: %w", i+1, err)
+		}
+		flows := make([]float64, 0, offer.Months+1)
+		flows = append(flows, principal-offer.UpfrontFee)
+		for _, entry := range res.Schedule {
+			flows = append(flows, -entry.Payment)
+		}
+		apr, err := IRR(flows)
+		if err != nil {
+			return nil, fmt.Errorf("предложение %d: %w", i+1, err)
+		}
+		ranked = append(ranked, OfferResult{
+			LoanOffer:      offer,
+			MonthlyPayment: res.MonthlyPayment,
+			TotalCost:      Round(res.TotalPayment+offer.UpfrontFee, cfg.Precision),
+			APRPercent:     Round(apr*12*100, 4),
+		})
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].APRPercent != ranked[j].APRPercent {
+			return ranked[i].APRPercent < ranked[j].APRPercent
+		}
+		return ranked[i].TotalCost < ranked[j].TotalCost
+	})
+	out := map[string]any{
+		"offers": ranked,
+		"best":   ranked[0],
+	}
+	if len(ranked) > 1 {
+		out["cost_delta_to_next"] = Round(ranked[1].TotalCost-ranked[0].TotalCost, cfg.Precision)
+	}
+	return out, nil
+}
@@ -0,0 +1,53 @@
+package calculations
+
+import "testing"
+
+func TestCompareOffersLowerFeeBeatsLowerRate(t *testing.T) {
+	cfg := DefaultConfig()
+	out, err := CompareOffers(cfg, 1_000_000, []LoanOffer{
+		{Name: "низкая ставка", AnnualRatePercent: 11, Months: 12, UpfrontFee: 60_000},
+		{Name: "без комиссии", AnnualRatePercent: 12, Months: 12},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	best := out["best"].(OfferResult)
+	// На коротком сроке крупная комиссия перевешивает процент ставки.
+	if best.Name != "без комиссии" {
+		t.Fatalf("ожидалась победа предложения без комиссии, выбрано %q (APR %v)", best.Name, best.APRPercent)
+	}
+	ranked := out["offers"].([]OfferResult)
+	if ranked[0].APRPercent >= ranked[1].APRPercent {
+		t.Fatalf("ранжирование не по APR: %v >= %v", ranked[0].APRPercent, ranked[1].APRPercent)
+	}
+	if _, ok := out["cost_delta_to_next"]; !ok {
+		t.Fatal("в ответе нет cost_delta_to_next")
+	}
+}
+
+func TestCompareOffersFinancedFeeRaisesAPR(t *testing.T) {
+	cfg := DefaultConfig()
+	out, err := CompareOffers(cfg, 1_000_000, []LoanOffer{
+		{Name: "чистое", AnnualRatePercent: 12, Months: 60},
+		{Name: "комиссия в теле", AnnualRatePercent: 12, Months: 60, FinancedFee: 50_000},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	best := out["best"].(OfferResult)
+	if best.Name != "чистое" {
+		t.Fatalf("финансируемая комиссия должна повышать APR, выбрано %q", best.Name)
+	}
+	// Без комиссий APR совпадает с номинальной ставкой.
+	inDelta(t, best.APRPercent, 12, 0.01)
+}
+
+func TestCompareOffersValidation(t *testing.T) {
+	cfg := DefaultConfig()
+	if _, err := CompareOffers(cfg, 1_000_000, nil); err == nil {
+		t.Fatal("ожидалась ошибка для пустого списка предложений")
+	}
+	if _, err := CompareOffers(cfg, 1_000_000, []LoanOffer{{AnnualRatePercent: 12, Months: 12, UpfrontFee: -1}}); err == nil {
+		t.Fatal("ожидалась ошибка для отрицательной комиссии")
+	}
+}
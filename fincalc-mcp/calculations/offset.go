@@ -0,0 +1,78 @@
+package calculations
+
+// OffsetResult — итог кредита с зачетным (offset) счетом: проценты
+// начисляются на остаток долга за вычетом баланса накоплений.
+type OffsetResult struct {
+	*CalculationResult
+	StandardTotalInterest float64 `json:"standard_total_interest"`
+	InterestSaved         float64 `json:"interest_saved"`
+	FinalOffsetBalance    float64 `json:"final_offset_balance"`
+	PayoffMonth           int     `json:"payoff_month"`
+}
+
+// OffsetMortgage моделирует ипотеку с зачетным счетом: каждый месяц проценты
+// начисляются на (остаток долга - баланс накоплений), но не ниже нуля, при
+// неизменном аннуитетном платеже. За счет ускоренного гашения кредит может
+// закрыться раньше срока. Возвращает также экономию процентов относительно
+// обычного кредита.
+func OffsetMortgage(cfg Config, p LoanParams, offsetBalance, monthlySavings float64) (*OffsetResult, error) {
+	if err := CheckAmount(cfg, offsetBalance); err != nil {
+		return nil, err
+	}
+	if err := CheckAmount(cfg, monthlySavings); err != nil {
+		return nil, err
+	}
+	standard, err := AnnuitySchedule(cfg, p)
+	if err != nil {
+		return nil, err
+	}
+	r := MonthlyRate(p.AnnualRatePercent)
+	payment := AnnuityPayment(p.Principal, p.AnnualRatePercent, p.Months)
+	res := &CalculationResult{
+		Kind:              KindAnnuity,
+		Principal:         p.Principal,
+		AnnualRatePercent: p.AnnualRatePercent,
+		Months:            p.Months,
+		MonthlyPayment:    Round(payment, cfg.Precision),
+	}
+	balance := p.Principal
+	offset := offsetBalance
+	payoffMonth := p.Months
+	for m := 1; m <= p.Months; m++ {
+		base := balance - offset
+		if base < 0 {
+			base = 0
+		}
+		interest := Round(base*r, cfg.Precision)
+		principalPart := Round(payment-interest, cfg.Precision)
+		if principalPart > balance || m == p.Months {
+			principalPart = Round(balance, cfg.Precision)
+		}
+		balance = Round(balance-principalPart, cfg.Precision)
+		pay := Round(principalPart+interest, cfg.Precision)
+		res.Schedule = append(res.Schedule, ScheduleEntry{
+			Month:              m,
+			Payment:            pay,
+			PrincipalComponent: principalPart,
+			Interest:           interest,
+			RemainingPrincipal: balance,
+		})
+		res.TotalPayment += pay
+		res.TotalInterest += interest
+		offset = Round(offset+monthlySavings, cfg.Precision)
+		if balance == 0 {
+			payoffMonth = m
+			break
+		}
+	}
+	res.Months = payoffMonth
+	res.TotalPayment = Round(res.TotalPayment, cfg.Precision)
+	res.TotalInterest = Round(res.TotalInterest, cfg.Precision)
+	return &OffsetResult{
+		CalculationResult:     res,
+		StandardTotalInterest: standard.TotalInterest,
+		InterestSaved:         Round2(standard.TotalInterest - res.TotalInterest),
+		FinalOffsetBalance:    offset,
+		PayoffMonth:           payoffMonth,
+	}, nil
+}
@@ -0,0 +1,36 @@
+package calculations
+
+import "testing"
+
+func TestOffsetMortgageFullCoverage(t *testing.T) {
+	cfg := DefaultConfig()
+	p := LoanParams{Principal: 1_000_000, AnnualRatePercent: 12, Months: 24}
+	res, err := OffsetMortgage(cfg, p, 1_000_000, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Накопления полностью перекрывают долг — процентов нет вовсе.
+	inDelta(t, res.TotalInterest, 0, 1e-9)
+	if res.InterestSaved != res.StandardTotalInterest {
+		t.Fatalf("экономия %v должна равняться переплате обычного кредита %v",
+			res.InterestSaved, res.StandardTotalInterest)
+	}
+	if res.PayoffMonth >= p.Months {
+		t.Fatalf("кредит без процентов должен закрыться раньше срока, получено %d", res.PayoffMonth)
+	}
+}
+
+func TestOffsetMortgagePartialSavings(t *testing.T) {
+	cfg := DefaultConfig()
+	p := LoanParams{Principal: 1_000_000, AnnualRatePercent: 12, Months: 24}
+	res, err := OffsetMortgage(cfg, p, 200_000, 10_000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.InterestSaved <= 0 {
+		t.Fatalf("частичный зачет должен экономить проценты, получено %v", res.InterestSaved)
+	}
+	if res.TotalInterest >= res.StandardTotalInterest {
+		t.Fatal("переплата с зачетом должна быть меньше обычной")
+	}
+}
@@ -0,0 +1,32 @@
+package calculations
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPaymentDateSavingsEarlierIsCheaper(t *testing.T) {
+	cfg := DefaultConfig()
+	p := LoanParams{Principal: 3_000_000, AnnualRatePercent: 12, Months: 60}
+	start := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	// Перенос с 28-го на 5-е число: начисление идет меньше дней.
+	res, err := PaymentDateSavings(cfg, p, start, 28, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.TotalSavings <= 0 {
+		t.Fatalf("ранняя дата платежа должна экономить проценты, получено %v", res.TotalSavings)
+	}
+	if res.ShiftedInterest >= res.BaselineInterest {
+		t.Fatalf("проценты при раннем дне должны быть ниже: %v >= %v",
+			res.ShiftedInterest, res.BaselineInterest)
+	}
+	// Тот же день — нулевая экономия.
+	same, err := PaymentDateSavings(cfg, p, start, 15, 15)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if same.TotalSavings != 0 {
+		t.Fatalf("одинаковые дни должны давать нулевую экономию, получено %v", same.TotalSavings)
+	}
+}
@@ -0,0 +1,57 @@
+package calculations
+
+import (
+	"fmt"
+	"math"
+)
+
+// PortfolioSummary считает каждую инвестицию портфеля по модели
+// CalculateInvestment и агрегирует итоги: вложено, итоговая стоимость,
+// прирост капитала и денежно-взвешенная годовая доходность портфеля (IRR
+// по объединенным потокам). Горизонты выравниваются по самой длинной
+// инвестиции: итог короткой поступает в поток в месяце ее окончания.
+func PortfolioSummary(cfg Config, items []InvestmentParams) (map[string]any, error) {
+	if len(items) == 0 {
+		return nil, fmt.Errorf("список инвестиций не должен быть пустым")
+	}
+	if err := cfg.CheckExpansion(len(items)); err != nil {
+		return nil, err
+	}
+	maxMonths := 0
+	for _, item := range items {
+		if item.Months > maxMonths {
+			maxMonths = item.Months
+		}
+	}
+	flows := make([]float64, maxMonths+1)
+	results := make([]*InvestmentResult, 0, len(items))
+	totalInvested := 0.0
+	finalValue := 0.0
+	for i, item := range items {
+		res, err := CalculateInvestment(cfg, item)
+		if err != nil {
+			return nil, fmt.Errorf("инвестиция %d: %w", i+1, err)
+		}
+		flows[0] -= item.InitialAmount
+		for m := 1; m <= item.Months; m++ {
+			flows[m] -= item.MonthlyContribution
+		}
+		flows[item.Months] += res.FinalBalance
+		totalInvested += res.TotalInvested
+		finalValue += res.FinalBalance
+		results = append(results, res)
+	}
+	out := map[string]any{
+		"investments":    results,
+		"months":         maxMonths,
+		"total_invested": Round2(totalInvested),
+		"final_value":    Round2(finalValue),
+		"capital_gain":   Round2(finalValue - totalInvested),
+	}
+	irr, err := IRR(flows)
+	if err != nil {
+		return nil, err
+	}
+	out["money_weighted_annual_percent"] = RoundPercent(cfg, (math.Pow(1+irr, 12)-1)*100)
+	return out, nil
+}
@@ -0,0 +1,35 @@
+package calculations
+
+import "testing"
+
+func TestPortfolioSummary(t *testing.T) {
+	cfg := DefaultConfig()
+	items := []InvestmentParams{
+		{InitialAmount: 100_000, MonthlyContribution: 10_000, AnnualReturnPercent: 12, Months: 36},
+		{InitialAmount: 500_000, AnnualReturnPercent: 7, Months: 24},
+	}
+	out, err := PortfolioSummary(cfg, items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first, err := CalculateInvestment(cfg, items[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := CalculateInvestment(cfg, items[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Агрегаты сходятся с суммой по инвестициям.
+	inDelta(t, out["total_invested"].(float64), first.TotalInvested+second.TotalInvested, 0.01)
+	inDelta(t, out["final_value"].(float64), first.FinalBalance+second.FinalBalance, 0.01)
+	inDelta(t, out["capital_gain"].(float64), out["final_value"].(float64)-out["total_invested"].(float64), 0.01)
+	if out["months"].(int) != 36 {
+		t.Fatalf("горизонт портфеля должен равняться самой длинной инвестиции, получено %v", out["months"])
+	}
+	// Денежно-взвешенная доходность лежит между ставками инвестиций.
+	mwr := out["money_weighted_annual_percent"].(float64)
+	if mwr <= 7 || mwr >= 12.7 {
+		t.Fatalf("денежно-взвешенная доходность %v вне правдоподобного диапазона", mwr)
+	}
+}
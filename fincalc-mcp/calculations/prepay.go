@@ -0,0 +1,149 @@
+package calculations
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Prepayment — частичное досрочное погашение: дополнительная сумма в
+// заданном месяце сверх обычного платежа.
+type Prepayment struct {
+	Month  int     `json:"month"`
+	Amount float64 `json:"amount"`
+}
+
+// RealizedRateResult — фактическая стоимость кредита после досрочных
+// погашений. CashFlowIRR — IRR реальных потоков: пока досрочные платежи
+// бесплатны, она совпадает с номинальной ставкой. RealizedRate — сводная
+// («смешанная») ставка: та, при которой кредит на исходный срок без
+// досрочных погашений стоил бы столько же процентов, сколько уплачено
+// фактически.
+type RealizedRateResult struct {
+	NominalRatePercent  float64 `json:"nominal_rate_percent"`
+	CashFlowIRRPercent  float64 `json:"cash_flow_irr_percent"`
+	RealizedRatePercent float64 `json:"realized_rate_percent"`
+	TotalInterest       float64 `json:"total_interest"`
+	BaselineInterest    float64 `json:"baseline_interest"`
+	InterestSaved       float64 `json:"interest_saved"`
+	MonthsShortened     int     `json:"months_shortened"`
+}
+
+// equivalentAnnuityRate решает бисекцией обратную задачу: при какой ставке
+// обычный аннуитет на тот же срок стоил бы столько же процентов, сколько
+// уплачено фактически. Проценты монотонно растут по ставке, границы поиска
+// задает вызывающая сторона.
+func equivalentAnnuityRate(principal float64, months int, totalInterest, loPercent, hiPercent float64) float64 {
+	interestAt := func(ratePercent float64) float64 {
+		return AnnuityPayment(principal, ratePercent, months)*float64(months) - principal
+	}
+	lo, hi := loPercent, hiPercent
+	for i := 0; i < bisectionSteps; i++ {
+		mid := (lo + hi) / 2
+		if interestAt(mid) < totalInterest {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return Round((lo+hi)/2, 4)
+}
+
+// RealizedRate строит фактический график аннуитетного кредита с частичными
+// досрочными погашениями (платеж сохраняется, срок сокращается), считает IRR
+// реальных потоков и сводную ставку относительно исходного срока.
+func RealizedRate(cfg Config, p LoanParams, prepayments []Prepayment) (*RealizedRateResult, error) {
+	baseline, err := AnnuitySchedule(cfg, p)
+	if err != nil {
+		return nil, err
+	}
+	prepayAt := make(map[int]float64, len(prepayments))
+	for i, pp := range prepayments {
+		if pp.Month < 1 || pp.Month > p.Months {
+			return nil, errOutOfRange("prepayments.month", 1, p.Months)
+		}
+		if pp.Amount <= 0 || pp.Amount > cfg.MaxPrincipal {
+			return nil, fmt.Errorf("сумма досрочного погашения %d должна быть положительной", i+1)
+		}
+		prepayAt[pp.Month] += pp.Amount
+	}
+
+	r := MonthlyRate(p.AnnualRatePercent)
+	payment := AnnuityPayment(p.Principal, p.AnnualRatePercent, p.Months)
+	flows := []float64{p.Principal}
+	balance := p.Principal
+	totalInterest := 0.0
+	months := 0
+	for m := 1; m <= p.Months && balance > 0; m++ {
+		interest := Round(balance*r, cfg.Precision)
+		principalPart := Round(payment-interest, cfg.Precision)
+		if principalPart >= balance || m == p.Months {
+			principalPart = Round(balance, cfg.Precision)
+		}
+		balance = Round(balance-principalPart, cfg.Precision)
+		outflow := principalPart + interest
+		// Досрочное погашение вносится после обычного платежа месяца.
+		if extra := prepayAt[m]; extra > 0 && balance > 0 {
+			if extra > balance {
+				extra = balance
+			}
+			balance = Round(balance-extra, cfg.Precision)
+			outflow += extra
+		}
+		flows = append(flows, -Round(outflow, cfg.Precision))
+		totalInterest += interest
+		months = m
+	}
+	totalInterest = Round(totalInterest, cfg.Precision)
+
+	irr, err := IRR(flows)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RealizedRateResult{
+		NominalRatePercent:  p.AnnualRatePercent,
+		CashFlowIRRPercent:  Round(irr*12*100, 4),
+		RealizedRatePercent: equivalentAnnuityRate(p.Principal, p.Months, totalInterest, 0, p.AnnualRatePercent),
+		TotalInterest:       totalInterest,
+		BaselineInterest:    baseline.TotalInterest,
+		InterestSaved:       Round(baseline.TotalInterest-totalInterest, cfg.Precision),
+		MonthsShortened:     p.Months - months,
+	}, nil
+}
+
+// PrepaymentTimingOption — экономия от одного и того же досрочного платежа,
+// внесенного в конкретном месяце.
+type PrepaymentTimingOption struct {
+	Month           int     `json:"month"`
+	InterestSaved   float64 `json:"interest_saved"`
+	MonthsShortened int     `json:"months_shortened"`
+}
+
+// PrepaymentTiming отвечает на вопрос «когда выгоднее внести разовый
+// досрочный платеж»: для каждого месяца-кандидата считается фактический
+// график с этим платежом, варианты сортируются по экономии процентов
+// по убыванию. При положительной ставке ранний платеж всегда выгоднее.
+func PrepaymentTiming(cfg Config, p LoanParams, extraAmount float64, candidateMonths []int) ([]PrepaymentTimingOption, error) {
+	if len(candidateMonths) == 0 {
+		return nil, fmt.Errorf("нужен хотя бы один месяц-кандидат")
+	}
+	if err := cfg.CheckExpansion(len(candidateMonths)); err != nil {
+		return nil, err
+	}
+	options := make([]PrepaymentTimingOption, 0, len(candidateMonths))
+	for _, m := range candidateMonths {
+		res, err := RealizedRate(cfg, p, []Prepayment{{Month: m, Amount: extraAmount}})
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, PrepaymentTimingOption{
+			Month:           m,
+			InterestSaved:   res.InterestSaved,
+			MonthsShortened: res.MonthsShortened,
+		})
+	}
+	sort.Slice(options, func(i, j int) bool {
+		return options[i].InterestSaved > options[j].InterestSaved
+	})
+	return options, nil
+}
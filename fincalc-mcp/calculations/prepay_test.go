@@ -0,0 +1,81 @@
+package calculations
+
+import "testing"
+
+func TestRealizedRateWithPrepayments(t *testing.T) {
+	cfg := DefaultConfig()
+	p := LoanParams{Principal: 1_000_000, AnnualRatePercent: 12, Months: 36}
+	res, err := RealizedRate(cfg, p, []Prepayment{{Month: 3, Amount: 200_000}, {Month: 6, Amount: 100_000}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Избежанные проценты опускают сводную ставку ниже номинальной.
+	if res.RealizedRatePercent >= res.NominalRatePercent {
+		t.Fatalf("сводная ставка %v должна быть ниже номинальной %v", res.RealizedRatePercent, res.NominalRatePercent)
+	}
+	if res.InterestSaved <= 0 {
+		t.Fatalf("ожидалась экономия процентов, получено %v", res.InterestSaved)
+	}
+	if res.MonthsShortened < 1 {
+		t.Fatalf("срок должен сократиться, получено %d", res.MonthsShortened)
+	}
+	// IRR реальных потоков остается около номинала: досрочные платежи
+	// бесплатны и вносятся по той же ставке.
+	inDelta(t, res.CashFlowIRRPercent, 12, 0.1)
+}
+
+func TestRealizedRateWithoutPrepayments(t *testing.T) {
+	cfg := DefaultConfig()
+	p := LoanParams{Principal: 1_000_000, AnnualRatePercent: 12, Months: 12}
+	res, err := RealizedRate(cfg, p, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	inDelta(t, res.RealizedRatePercent, 12, 0.01)
+	inDelta(t, res.InterestSaved, 0, 0.01)
+	if res.MonthsShortened != 0 {
+		t.Fatalf("без досрочных погашений срок не меняется, получено %d", res.MonthsShortened)
+	}
+}
+
+func TestRealizedRateValidation(t *testing.T) {
+	cfg := DefaultConfig()
+	p := LoanParams{Principal: 1_000_000, AnnualRatePercent: 12, Months: 12}
+	if _, err := RealizedRate(cfg, p, []Prepayment{{Month: 13, Amount: 1000}}); err == nil {
+		t.Fatal("ожидалась ошибка для месяца вне срока")
+	}
+	if _, err := RealizedRate(cfg, p, []Prepayment{{Month: 3, Amount: 0}}); err == nil {
+		t.Fatal("ожидалась ошибка для нулевой суммы")
+	}
+}
+
+func TestPrepaymentTimingEarlierSavesMore(t *testing.T) {
+	cfg := DefaultConfig()
+	p := LoanParams{Principal: 3_000_000, AnnualRatePercent: 12, Months: 120}
+	options, err := PrepaymentTiming(cfg, p, 300_000, []int{36, 6, 60})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(options) != 3 {
+		t.Fatalf("ожидалось 3 варианта, получено %d", len(options))
+	}
+	// Сортировка по экономии: при положительной ставке ранний платеж выгоднее.
+	wantOrder := []int{6, 36, 60}
+	for i, want := range wantOrder {
+		if options[i].Month != want {
+			t.Fatalf("позиция %d: ожидался месяц %d, получен %d", i, want, options[i].Month)
+		}
+	}
+	if options[0].InterestSaved <= options[1].InterestSaved || options[1].InterestSaved <= options[2].InterestSaved {
+		t.Fatalf("экономия должна убывать: %v", options)
+	}
+}
+
+func TestPrepaymentTimingExpansionLimit(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.MaxExpandedCalculations = 2
+	p := LoanParams{Principal: 3_000_000, AnnualRatePercent: 12, Months: 120}
+	if _, err := PrepaymentTiming(cfg, p, 300_000, []int{6, 12, 24}); err == nil {
+		t.Fatal("ожидалась ошибка превышения лимита расчетов")
+	}
+}
@@ -0,0 +1,52 @@
+package calculations
+
+import "fmt"
+
+// RatePeriod — участок срока со своей годовой ставкой: действует с месяца
+// FromMonth включительно до начала следующего участка (или до конца срока).
+// Используется для продуктов «приветственная ставка, затем базовая».
+type RatePeriod struct {
+	FromMonth         int     `json:"from_month"`
+	AnnualRatePercent float64 `json:"annual_rate_percent"`
+}
+
+// validateRatePeriods проверяет, что участки покрывают месяц 1, строго
+// упорядочены по FromMonth и каждая ставка в допустимых пределах.
+func validateRatePeriods(cfg Config, periods []RatePeriod, months int) error {
+	if periods[0].FromMonth != 1 {
+		return fmt.Errorf("периоды ставок должны начинаться с месяца 1, получен %d", periods[0].FromMonth)
+	}
+	for i, period := range periods {
+		if err := CheckRate(cfg, period.AnnualRatePercent); err != nil {
+			return err
+		}
+		if period.FromMonth > months {
+			return errOutOfRange("from_month", 1, months)
+		}
+		if i > 0 && period.FromMonth <= periods[i-1].FromMonth {
+			return fmt.Errorf("периоды ставок должны быть строго упорядочены по from_month")
+		}
+	}
+	return nil
+}
+
+// annualRateForMonth возвращает годовую ставку, действующую в месяце m.
+func annualRateForMonth(periods []RatePeriod, m int) float64 {
+	rate := periods[0].AnnualRatePercent
+	for _, period := range periods {
+		if period.FromMonth > m {
+			break
+		}
+		rate = period.AnnualRatePercent
+	}
+	return rate
+}
+
+// blendedRatePercent возвращает средневзвешенную по времени годовую ставку.
+func blendedRatePercent(periods []RatePeriod, months int) float64 {
+	sum := 0.0
+	for m := 1; m <= months; m++ {
+		sum += annualRateForMonth(periods, m)
+	}
+	return Round(sum/float64(months), 4)
+}
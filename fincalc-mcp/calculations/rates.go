@@ -0,0 +1,148 @@
+package calculations
+
+import (
+	"fmt"
+	"math"
+)
+
+// Частоты начисления процентов, поддерживаемые конвертером ставок.
+const (
+	FreqAnnual     = "annual"
+	FreqSemiannual = "semiannual"
+	FreqQuarterly  = "quarterly"
+	FreqMonthly    = "monthly"
+	FreqWeekly     = "weekly"
+	FreqDaily      = "daily"
+	FreqContinuous = "continuous"
+)
+
+// PeriodsPerYear возвращает число периодов начисления в году для частоты.
+// Для FreqContinuous возвращает 0 — непрерывное начисление обрабатывается
+// отдельно через экспоненту.
+func PeriodsPerYear(frequency string) (int, error) {
+	switch frequency {
+	case FreqAnnual:
+		return 1, nil
+	case FreqSemiannual:
+		return 2, nil
+	case FreqQuarterly:
+		return 4, nil
+	case FreqMonthly:
+		return 12, nil
+	case FreqWeekly:
+		return 52, nil
+	case FreqDaily:
+		return 365, nil
+	case FreqContinuous:
+		return 0, nil
+	}
+	return 0, fmt.Errorf("неизвестная частота начисления %q", frequency)
+}
+
+// PeriodicRate переводит годовую номинальную ставку (в процентах) в долю
+// за один период начисления заданной частоты.
+func PeriodicRate(annualRatePercent float64, frequency string) (float64, error) {
+	n, err := PeriodsPerYear(frequency)
+	if err != nil {
+		return 0, err
+	}
+	if n == 0 {
+		return 0, fmt.Errorf("периодическая ставка не определена для частоты %q", FreqContinuous)
+	}
+	return annualRatePercent / 100 / float64(n), nil
+}
+
+// MonthlyRate переводит годовую номинальную ставку (в процентах) в месячную
+// долю. Это частный случай PeriodicRate для ежемесячного начисления.
+func MonthlyRate(annualRatePercent float64) float64 {
+	return annualRatePercent / 100 / 12
+}
+
+// EffectiveAnnualYield возвращает эффективную годовую доходность (долю)
+// номинальной годовой ставки, начисляемой с заданной частотой.
+func EffectiveAnnualYield(ratePercent float64, frequency string) (float64, error) {
+	if frequency == FreqContinuous {
+		return math.Expm1(ratePercent / 100), nil
+	}
+	n, err := PeriodsPerYear(frequency)
+	if err != nil {
+		return 0, err
+	}
+	return math.Pow(1+ratePercent/100/float64(n), float64(n)) - 1, nil
+}
+
+// RateFromEffective возвращает номинальную годовую ставку (в процентах),
+// которая при начислении с заданной частотой дает эффективную годовую
+// доходность eay (долю).
+func RateFromEffective(eay float64, frequency string) (float64, error) {
+	if eay <= -1 {
+		return 0, fmt.Errorf("эффективная доходность должна быть больше -100%%")
+	}
+	if frequency == FreqContinuous {
+		return math.Log1p(eay) * 100, nil
+	}
+	n, err := PeriodsPerYear(frequency)
+	if err != nil {
+		return 0, err
+	}
+	return float64(n) * (math.Pow(1+eay, 1/float64(n)) - 1) * 100, nil
+}
+
+// NominalFromEffectiveAnnual переводит ставку, заявленную как эффективная
+// годовая доходность (в процентах, как в рекламе вкладов), в номинальную
+// годовую ставку с помесячным начислением: месячная доля получается как
+// (1+eay)^(1/12)-1. С такой номинальной ставкой график вклада воспроизводит
+// рекламный итоговый баланс.
+func NominalFromEffectiveAnnual(eayPercent float64) (float64, error) {
+	return RateFromEffective(eayPercent/100, FreqMonthly)
+}
+
+// ConvertRate пересчитывает номинальную годовую ставку из одной частоты
+// начисления в другую, сохраняя эффективную годовую доходность.
+func ConvertRate(ratePercent float64, fromFrequency, toFrequency string) (float64, error) {
+	eay, err := EffectiveAnnualYield(ratePercent, fromFrequency)
+	if err != nil {
+		return 0, err
+	}
+	return RateFromEffective(eay, toFrequency)
+}
+
+// WeightedAverageRate возвращает средневзвешенную по остатку долга
+// фактическую ставку графика в годовых процентах: месячные начисления
+// взвешиваются балансом на начало месяца. Для многофазных кредитов
+// (льгота, переменная ставка, сброс) это единое сравнимое число вместо
+// потерявшей смысл номинальной ставки.
+func WeightedAverageRate(schedule []ScheduleEntry, principal float64) float64 {
+	totalInterest := 0.0
+	totalBalance := 0.0
+	balance := principal
+	for _, entry := range schedule {
+		totalInterest += entry.Interest
+		totalBalance += balance
+		balance = entry.RemainingPrincipal
+	}
+	if totalBalance <= 0 {
+		return 0
+	}
+	return Round(totalInterest/totalBalance*12*100, 4)
+}
+
+// EquivalentSimpleRate пересчитывает стоимость аннуитетного кредита в
+// простую годовую ставку на исходное тело: totalInterest / principal,
+// приведенное к году. Это регуляторная цифра раскрытия в некоторых
+// юрисдикциях; она заметно ниже APR, потому что игнорирует амортизацию.
+// Вырожденное тело дает 0.
+func EquivalentSimpleRate(principal, totalInterest float64, months int) float64 {
+	if principal <= 0 || months <= 0 {
+		return 0
+	}
+	return Round(totalInterest/principal/(float64(months)/12)*100, 4)
+}
+
+// TaxEquivalentYield возвращает доналоговую доходность, эквивалентную
+// необлагаемой: nominal / (1 - tax). Так облагаемый вклад честно
+// сравнивается с безналоговым инструментом. Границы налоговой ставки
+// (0 <= tax < 100) проверяет вызывающий слой.
+func TaxEquivalentYield(nominalPercent, taxRatePercent float64) float64 {
+	return Round(nominalPercent/(1-taxRatePercent/100), 4)
+}
@@ -0,0 +1,135 @@
+package calculations
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMonthlyRate(t *testing.T) {
+	inDelta(t, MonthlyRate(12), 0.01, 1e-12)
+}
+
+func TestConvertRateMonthlyToEffectiveAnnual(t *testing.T) {
+	// 12% номинальных при ежемесячном начислении — это 12.6825% эффективных.
+	got, err := ConvertRate(12, FreqMonthly, FreqAnnual)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := (math.Pow(1.01, 12) - 1) * 100
+	inDelta(t, got, want, 1e-9)
+	inDelta(t, got, 12.6825, 1e-4)
+}
+
+func TestConvertRateRoundTrip(t *testing.T) {
+	quarterly, err := ConvertRate(12, FreqMonthly, FreqQuarterly)
+	if err != nil {
+		t.Fatal(err)
+	}
+	back, err := ConvertRate(quarterly, FreqQuarterly, FreqMonthly)
+	if err != nil {
+		t.Fatal(err)
+	}
+	inDelta(t, back, 12, 1e-9)
+}
+
+func TestConvertRateContinuous(t *testing.T) {
+	// Непрерывное начисление: EAY = e^r - 1.
+	got, err := ConvertRate(12, FreqContinuous, FreqAnnual)
+	if err != nil {
+		t.Fatal(err)
+	}
+	inDelta(t, got, math.Expm1(0.12)*100, 1e-9)
+
+	back, err := ConvertRate(got, FreqAnnual, FreqContinuous)
+	if err != nil {
+		t.Fatal(err)
+	}
+	inDelta(t, back, 12, 1e-9)
+}
+
+func TestConvertRateUnknownFrequency(t *testing.T) {
+	if _, err := ConvertRate(12, "hourly", FreqAnnual); err == nil {
+		t.Fatal("ожидалась ошибка для неизвестной частоты")
+	}
+}
+
+func TestWeightedAverageRateBetweenPhases(t *testing.T) {
+	cfg := DefaultConfig()
+	res, err := VariableRateSchedule(cfg, VariableLoanParams{
+		Principal: 1_000_000,
+		Months:    60,
+		RatePeriods: []RatePeriod{
+			{FromMonth: 1, AnnualRatePercent: 6},
+			{FromMonth: 31, AnnualRatePercent: 12},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	weighted := res.WeightedAverageRatePercent
+	if weighted <= 6 || weighted >= 12 {
+		t.Fatalf("средневзвешенная ставка должна лежать между фазами: %v", weighted)
+	}
+	// Для однофазного кредита взвешенная ставка совпадает с номинальной.
+	flat, err := AnnuitySchedule(cfg, LoanParams{Principal: 1_000_000, AnnualRatePercent: 9, Months: 60})
+	if err != nil {
+		t.Fatal(err)
+	}
+	inDelta(t, WeightedAverageRate(flat.Schedule, 1_000_000), 9, 0.01)
+}
+
+func TestTaxEquivalentYield(t *testing.T) {
+	// Хрестоматийный случай: 6% без налога при ставке налога 25%
+	// эквивалентны 8% до налога.
+	inDelta(t, TaxEquivalentYield(6, 25), 8, 1e-9)
+	// Нулевой налог ничего не меняет.
+	inDelta(t, TaxEquivalentYield(12, 0), 12, 1e-9)
+}
+
+func TestNominalFromEffectiveAnnualReproducesMaturityBalance(t *testing.T) {
+	cfg := DefaultConfig()
+	// Реклама обещает 10.4713% эффективных годовых — это номинальные 10%
+	// с помесячной капитализацией.
+	eay, err := EffectiveAnnualYield(10, FreqMonthly)
+	if err != nil {
+		t.Fatal(err)
+	}
+	nominal, err := NominalFromEffectiveAnnual(eay * 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	inDelta(t, nominal, 10, 1e-9)
+	res, err := DepositSchedule(cfg, DepositParams{
+		InitialAmount:     100_000,
+		AnnualRatePercent: nominal,
+		Months:            12,
+		Capitalize:        true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Итог графика совпадает с рекламным 100000*(1+eay) с точностью до копеек.
+	inDelta(t, res.FinalBalance, 100_000*(1+eay), 0.15)
+
+	if _, err := NominalFromEffectiveAnnual(-150); err == nil {
+		t.Fatal("ожидалась ошибка для доходности ниже -100%")
+	}
+}
+
+func TestEquivalentSimpleRate(t *testing.T) {
+	cfg := DefaultConfig()
+	res, err := AnnuitySchedule(cfg, LoanParams{Principal: 1_000_000, AnnualRatePercent: 12, Months: 60})
+	if err != nil {
+		t.Fatal(err)
+	}
+	simple := EquivalentSimpleRate(1_000_000, res.TotalInterest, 60)
+	// Сверка с итогами графика: переплата к телу, приведенная к году.
+	inDelta(t, simple, res.TotalInterest/1_000_000/5*100, 0.0001)
+	// Простая ставка ниже номинальной: амортизация игнорируется.
+	if simple >= 12 {
+		t.Fatalf("простая ставка %v должна быть ниже номинальной 12", simple)
+	}
+	if EquivalentSimpleRate(0, 1000, 12) != 0 {
+		t.Fatal("вырожденное тело должно давать 0")
+	}
+}
@@ -0,0 +1,39 @@
+package calculations
+
+import "math"
+
+// RealReturnDeposit отвечает на вопрос «сохраняет ли вклад покупательную
+// способность»: итоговый баланс дефлируется накопленной инфляцией (в
+// ценах месяца открытия), а реальная годовая доходность считается как
+// эффективная доходность графика за вычетом инфляции по формуле Фишера.
+// NegativeRealReturn поднимается, когда вклад в реальном выражении
+// приносит убыток.
+func RealReturnDeposit(cfg Config, p DepositParams, annualInflationPercent float64) (map[string]any, error) {
+	if annualInflationPercent < 0 || annualInflationPercent > cfg.MaxRate {
+		return nil, errOutOfRange("annual_inflation_percent", 0, int(cfg.MaxRate))
+	}
+	res, err := DepositSchedule(cfg, p)
+	if err != nil {
+		return nil, err
+	}
+	invested := Round(p.InitialAmount+res.TotalContributions, cfg.Precision)
+	years := float64(p.Months) / 12
+	deflator := math.Pow(1+annualInflationPercent/100, years)
+	realBalance := Round(res.FinalBalance/deflator, cfg.Precision)
+	// Номинальная эффективная доходность графика за весь срок, годовая.
+	nominalYield := 0.0
+	if invested > 0 && years > 0 {
+		nominalYield = math.Pow(res.FinalBalance/invested, 1/years) - 1
+	}
+	// Формула Фишера: (1+номинальная)/(1+инфляция)-1.
+	realYield := (1+nominalYield)/(1+annualInflationPercent/100) - 1
+	return map[string]any{
+		"final_balance":         res.FinalBalance,
+		"real_final_balance":    realBalance,
+		"total_invested":        invested,
+		"nominal_yield_percent": RoundPercent(cfg, nominalYield*100),
+		"real_yield_percent":    RoundPercent(cfg, realYield*100),
+		"negative_real_return":  realBalance < invested,
+		"purchasing_power_loss": Round(res.FinalBalance-realBalance, cfg.Precision),
+	}, nil
+}
@@ -0,0 +1,51 @@
+package calculations
+
+import "testing"
+
+func TestRealReturnDeposit(t *testing.T) {
+	cfg := DefaultConfig()
+	// Низкая ставка при высокой инфляции: номинальный плюс, реальный минус.
+	p := DepositParams{
+		InitialAmount:     1_000_000,
+		AnnualRatePercent: 5,
+		Months:            24,
+		Capitalize:        true,
+	}
+	out, err := RealReturnDeposit(cfg, p, 15)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out["final_balance"].(float64) <= 1_000_000 {
+		t.Fatal("номинальный итог должен расти")
+	}
+	if !out["negative_real_return"].(bool) {
+		t.Fatal("при инфляции 15% вклад под 5% должен терять покупательную способность")
+	}
+	if out["real_yield_percent"].(float64) >= 0 {
+		t.Fatalf("реальная доходность должна быть отрицательной: %v", out["real_yield_percent"])
+	}
+	if out["real_final_balance"].(float64) >= 1_000_000 {
+		t.Fatal("реальный итог должен быть ниже вложенного")
+	}
+
+	// Ставка выше инфляции сохраняет покупательную способность.
+	ok, err := RealReturnDeposit(cfg, DepositParams{
+		InitialAmount:     1_000_000,
+		AnnualRatePercent: 12,
+		Months:            24,
+		Capitalize:        true,
+	}, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok["negative_real_return"].(bool) {
+		t.Fatal("вклад выше инфляции не должен быть убыточным в реальном выражении")
+	}
+	if ok["real_yield_percent"].(float64) <= 0 {
+		t.Fatalf("реальная доходность должна быть положительной: %v", ok["real_yield_percent"])
+	}
+
+	if _, err := RealReturnDeposit(cfg, p, -1); err == nil {
+		t.Fatal("ожидалась ошибка для отрицательной инфляции")
+	}
+}
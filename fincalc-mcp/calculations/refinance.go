@@ -0,0 +1,109 @@
+package calculations
+
+// RefinanceResult — итог сравнения текущего кредита с рефинансированием.
+type RefinanceResult struct {
+	RemainingPrincipal float64 `json:"remaining_principal"`
+	OldRemainingCost   float64 `json:"old_remaining_cost"`
+	NewMonthlyPayment  float64 `json:"new_monthly_payment"`
+	NewTotalCost       float64 `json:"new_total_cost"`
+	Savings            float64 `json:"savings"`
+}
+
+// CompareRefinance сравнивает продолжение текущего аннуитетного кредита
+// с рефинансированием остатка по новой ставке, с учетом разовой комиссии.
+// Положительный Savings означает выгоду рефинансирования.
+func CompareRefinance(cfg Config, p LoanParams, paidMonths int, newRatePercent float64, newMonths int, fee float64) (*RefinanceResult, error) {
+	if err := CheckAmount(cfg, fee); err != nil {
+		return nil, err
+	}
+	current, err := AnnuitySchedule(cfg, p)
+	if err != nil {
+		return nil, err
+	}
+	if paidMonths < 0 || paidMonths >= p.Months {
+		return nil, errOutOfRange("paid_months", 0, p.Months-1)
+	}
+	remaining := p.Principal
+	oldCost := 0.0
+	for _, entry := range current.Schedule {
+		if entry.Month <= paidMonths {
+			remaining = entry.RemainingPrincipal
+			continue
+		}
+		oldCost += entry.Payment
+	}
+	refinanced, err := AnnuitySchedule(cfg, LoanParams{
+		Principal:         remaining,
+		AnnualRatePercent: newRatePercent,
+		Months:            newMonths,
+	})
+	if err != nil {
+		return nil, err
+	}
+	newCost := Round2(refinanced.TotalPayment + fee)
+	return &RefinanceResult{
+		RemainingPrincipal: remaining,
+		OldRemainingCost:   Round2(oldCost),
+		NewMonthlyPayment:  refinanced.MonthlyPayment,
+		NewTotalCost:       newCost,
+		Savings:            Round2(oldCost - newCost),
+	}, nil
+}
+
+// RefinanceTiming — полная стоимость кредита при рефинансировании
+// в конкретном месяце.
+type RefinanceTiming struct {
+	Month     int     `json:"month"`
+	TotalCost float64 `json:"total_cost"`
+}
+
+// OptimalRefinance — итог перебора месяцев рефинансирования.
+type OptimalRefinance struct {
+	BestMonth     int               `json:"best_month"`
+	BestTotalCost float64           `json:"best_total_cost"`
+	BaselineCost  float64           `json:"baseline_cost"`
+	Savings       float64           `json:"savings"`
+	Options       []RefinanceTiming `json:"options"`
+}
+
+// OptimalRefinanceMonth перебирает рефинансирование после каждого из
+// оставшихся месяцев (остаток гасится по новой ставке на оставшийся срок,
+// комиссия разовая) и возвращает месяц с минимальной полной стоимостью.
+// Число итераций ограничено оставшимся сроком кредита.
+func OptimalRefinanceMonth(cfg Config, p LoanParams, newRatePercent, fee float64) (*OptimalRefinance, error) {
+	if err := CheckRate(cfg, newRatePercent); err != nil {
+		return nil, err
+	}
+	if err := CheckAmount(cfg, fee); err != nil {
+		return nil, err
+	}
+	// Перебор разворачивается в расчет на каждый месяц срока.
+	if err := cfg.CheckExpansion(p.Months); err != nil {
+		return nil, err
+	}
+	current, err := AnnuitySchedule(cfg, p)
+	if err != nil {
+		return nil, err
+	}
+	best := &OptimalRefinance{
+		BestMonth:    -1,
+		BaselineCost: current.TotalPayment,
+	}
+	paidSoFar := 0.0
+	for _, entry := range current.Schedule {
+		if entry.Month == p.Months {
+			break
+		}
+		paidSoFar += entry.Payment
+		remainingMonths := p.Months - entry.Month
+		newPayment := AnnuityPayment(entry.RemainingPrincipal, newRatePercent, remainingMonths)
+		total := Round2(paidSoFar + newPayment*float64(remainingMonths) + fee)
+		best.Options = append(best.Options, RefinanceTiming{Month: entry.Month, TotalCost: total})
+		if best.BestMonth == -1 || total < best.BestTotalCost {
+			best.BestMonth = entry.Month
+			best.BestTotalCost = total
+		}
+	}
+	best.Savings = Round2(best.BaselineCost - best.BestTotalCost)
+	return best, nil
+}
@@ -0,0 +1,35 @@
+package calculations
+
+import "testing"
+
+func TestCompareRefinanceSavings(t *testing.T) {
+	cfg := DefaultConfig()
+	p := LoanParams{Principal: 1_000_000, AnnualRatePercent: 15, Months: 60}
+	res, err := CompareRefinance(cfg, p, 12, 10, 48, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Savings <= 0 {
+		t.Fatalf("рефинансирование под меньшую ставку должно давать выгоду, получено %v", res.Savings)
+	}
+}
+
+func TestOptimalRefinanceMonthPrefersEarly(t *testing.T) {
+	cfg := DefaultConfig()
+	p := LoanParams{Principal: 1_000_000, AnnualRatePercent: 18, Months: 60}
+	res, err := OptimalRefinanceMonth(cfg, p, 8, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// При существенно меньшей ставке и нулевой комиссии выгоднее всего
+	// рефинансироваться как можно раньше.
+	if res.BestMonth != 1 {
+		t.Fatalf("ожидался месяц 1, получен %d", res.BestMonth)
+	}
+	if res.Savings <= 0 {
+		t.Fatalf("ожидалась положительная выгода, получено %v", res.Savings)
+	}
+	if len(res.Options) != p.Months-1 {
+		t.Fatalf("ожидалось %d вариантов, получено %d", p.Months-1, len(res.Options))
+	}
+}
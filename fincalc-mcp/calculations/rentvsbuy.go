@@ -0,0 +1,188 @@
+package calculations
+
+import (
+	"fmt"
+	"math"
+)
+
+// RentVsBuyParams — входные данные сравнения «снимать или покупать».
+// Покупатель платит первоначальный взнос и ипотеку, арендатор инвестирует
+// взнос и ежемесячную разницу платежей под InvestmentRatePercent.
+type RentVsBuyParams struct {
+	HomePrice           float64 `json:"home_price"`
+	DownPayment         float64 `json:"down_payment"`
+	MortgageRatePercent float64 `json:"mortgage_rate_percent"`
+	MortgageMonths      int     `json:"mortgage_months"`
+	// MonthlyOwnerCosts — налоги, страховка и обслуживание жилья сверх ипотеки.
+	MonthlyOwnerCosts float64 `json:"monthly_owner_costs,omitempty"`
+	MonthlyRent       float64 `json:"monthly_rent"`
+	// RentEscalationPercent — годовой рост аренды в процентах.
+	RentEscalationPercent float64 `json:"rent_escalation_percent,omitempty"`
+	// InvestmentRatePercent — годовая доходность инвестиций в процентах.
+	InvestmentRatePercent float64 `json:"investment_rate_percent"`
+	// HomeAppreciationPercent — годовой рост цены жилья; допускается
+	// отрицательный (жилье дешевеет).
+	HomeAppreciationPercent float64 `json:"home_appreciation_percent,omitempty"`
+	HorizonMonths           int     `json:"horizon_months"`
+}
+
+func (p RentVsBuyParams) validate(cfg Config) error {
+	if err := CheckPrincipal(cfg, p.HomePrice); err != nil {
+		return err
+	}
+	if p.DownPayment < 0 || p.DownPayment >= p.HomePrice {
+		return fmt.Errorf("первоначальный взнос должен быть в диапазоне от 0 до цены жилья")
+	}
+	if err := CheckRate(cfg, p.MortgageRatePercent); err != nil {
+		return err
+	}
+	if err := CheckMonths(cfg, p.MortgageMonths); err != nil {
+		return err
+	}
+	if p.MonthlyOwnerCosts < 0 {
+		return fmt.Errorf("расходы владельца не должны быть отрицательными")
+	}
+	if p.MonthlyRent <= 0 || p.MonthlyRent > cfg.MaxPrincipal {
+		return fmt.Errorf("арендная плата должна быть положительной")
+	}
+	if err := CheckRate(cfg, p.RentEscalationPercent); err != nil {
+		return err
+	}
+	if err := CheckRate(cfg, p.InvestmentRatePercent); err != nil {
+		return err
+	}
+	if p.HomeAppreciationPercent < -cfg.MaxRate || p.HomeAppreciationPercent > cfg.MaxRate {
+		return errOutOfRange("home_appreciation_percent", -int(cfg.MaxRate), int(cfg.MaxRate))
+	}
+	return CheckMonths(cfg, p.HorizonMonths)
+}
+
+// RentVsBuyResult — чистое состояние каждого пути на горизонте: у покупателя —
+// жилье минус остаток долга плюс инвестиции, у арендатора — инвестиции.
+type RentVsBuyResult struct {
+	MonthlyPayment  float64 `json:"monthly_payment"`
+	HomeValue       float64 `json:"home_value"`
+	RemainingDebt   float64 `json:"remaining_debt"`
+	BuyInvestments  float64 `json:"buy_investments"`
+	BuyNetWorth     float64 `json:"buy_net_worth"`
+	RentInvestments float64 `json:"rent_investments"`
+	RentNetWorth    float64 `json:"rent_net_worth"`
+	// Winner — «buy» или «rent»; Advantage — отрыв победителя.
+	Winner    string  `json:"winner"`
+	Advantage float64 `json:"advantage"`
+}
+
+// rentVsBuyNetWorth моделирует оба пути помесячно и возвращает их чистое
+// состояние на горизонте. Ежемесячная разница платежей инвестируется тем,
+// кто в этом месяце платит меньше; после погашения ипотеки у покупателя
+// остаются только расходы владельца.
+func rentVsBuyNetWorth(cfg Config, p RentVsBuyParams) (*RentVsBuyResult, error) {
+	principal := p.HomePrice - p.DownPayment
+	loan, err := AnnuitySchedule(cfg, LoanParams{
+		Principal:         principal,
+		AnnualRatePercent: p.MortgageRatePercent,
+		Months:            p.MortgageMonths,
+	})
+	if err != nil {
+		return nil, err
+	}
+	investRate := p.InvestmentRatePercent / 12 / 100
+	buyInvest := 0.0
+	rentInvest := p.DownPayment
+	for m := 1; m <= p.HorizonMonths; m++ {
+		buyInvest *= 1 + investRate
+		rentInvest *= 1 + investRate
+		ownCost := p.MonthlyOwnerCosts
+		if m <= p.MortgageMonths {
+			ownCost += loan.Schedule[m-1].Payment
+		}
+		rent := p.MonthlyRent * math.Pow(1+p.RentEscalationPercent/100, float64((m-1)/12))
+		if diff := ownCost - rent; diff > 0 {
+			rentInvest += diff
+		} else {
+			buyInvest += -diff
+		}
+	}
+	homeValue := p.HomePrice * math.Pow(1+p.HomeAppreciationPercent/100, float64(p.HorizonMonths)/12)
+	remaining := 0.0
+	if p.HorizonMonths < p.MortgageMonths {
+		remaining = loan.Schedule[p.HorizonMonths-1].RemainingPrincipal
+	}
+	out := &RentVsBuyResult{
+		MonthlyPayment:  loan.MonthlyPayment,
+		HomeValue:       Round2(homeValue),
+		RemainingDebt:   remaining,
+		BuyInvestments:  Round2(buyInvest),
+		BuyNetWorth:     Round2(homeValue - remaining + buyInvest),
+		RentInvestments: Round2(rentInvest),
+		RentNetWorth:    Round2(rentInvest),
+	}
+	return out, nil
+}
+
+// RentVsBuy сравнивает покупку жилья в ипотеку с арендой и инвестированием
+// разницы: первоначального взноса и ежемесячной экономии. Возвращает чистое
+// состояние обоих путей на горизонте, победителя и его отрыв.
+func RentVsBuy(cfg Config, p RentVsBuyParams) (*RentVsBuyResult, error) {
+	if err := p.validate(cfg); err != nil {
+		return nil, err
+	}
+	out, err := rentVsBuyNetWorth(cfg, p)
+	if err != nil {
+		return nil, err
+	}
+	if out.BuyNetWorth >= out.RentNetWorth {
+		out.Winner = "buy"
+	} else {
+		out.Winner = "rent"
+	}
+	out.Advantage = Round2(math.Abs(out.BuyNetWorth - out.RentNetWorth))
+	return out, nil
+}
+
+// BreakEvenAppreciation подбирает бисекцией минимальный годовой рост цены
+// жилья, при котором покупка догоняет аренду по чистому состоянию на
+// горизонте. Путь арендатора от роста жилья не зависит, состояние покупателя
+// монотонно растет с ним. Ошибка, если покупка выгоднее даже при максимальном
+// падении цены или проигрывает даже при максимальном росте.
+func BreakEvenAppreciation(cfg Config, p RentVsBuyParams) (float64, error) {
+	if err := p.validate(cfg); err != nil {
+		return 0, err
+	}
+	netDiff := func(appreciationPercent float64) (float64, error) {
+		p.HomeAppreciationPercent = appreciationPercent
+		res, err := rentVsBuyNetWorth(cfg, p)
+		if err != nil {
+			return 0, err
+		}
+		return res.BuyNetWorth - res.RentNetWorth, nil
+	}
+	lo, hi := -cfg.MaxRate, cfg.MaxRate
+	atLo, err := netDiff(lo)
+	if err != nil {
+		return 0, err
+	}
+	if atLo >= 0 {
+		return 0, fmt.Errorf("покупка выгоднее аренды даже при падении цены на %.0f%% в год", cfg.MaxRate)
+	}
+	atHi, err := netDiff(hi)
+	if err != nil {
+		return 0, err
+	}
+	if atHi < 0 {
+		return 0, fmt.Errorf("покупка проигрывает аренде даже при росте цены на %.0f%% в год", cfg.MaxRate)
+	}
+	for i := 0; i < bisectionSteps; i++ {
+		mid := (lo + hi) / 2
+		diff, err := netDiff(mid)
+		if err != nil {
+			return 0, err
+		}
+		if diff < 0 {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return Round(hi, 4), nil
+}
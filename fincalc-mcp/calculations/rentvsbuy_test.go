@@ -0,0 +1,73 @@
+package calculations
+
+import "testing"
+
+func TestRentVsBuyBuyFavorable(t *testing.T) {
+	cfg := DefaultConfig()
+	// Дешевая ипотека, быстрорастущее жилье, слабые инвестиции — покупка.
+	res, err := RentVsBuy(cfg, RentVsBuyParams{
+		HomePrice:               10_000_000,
+		DownPayment:             3_000_000,
+		MortgageRatePercent:     5,
+		MortgageMonths:          240,
+		MonthlyRent:             60_000,
+		RentEscalationPercent:   8,
+		InvestmentRatePercent:   3,
+		HomeAppreciationPercent: 7,
+		HorizonMonths:           120,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Winner != "buy" {
+		t.Fatalf("ожидалась победа покупки, получено %q (buy=%v rent=%v)", res.Winner, res.BuyNetWorth, res.RentNetWorth)
+	}
+	inDelta(t, res.Advantage, res.BuyNetWorth-res.RentNetWorth, 0.01)
+}
+
+func TestRentVsBuyRentFavorable(t *testing.T) {
+	cfg := DefaultConfig()
+	// Дорогая ипотека, дешевеющее жилье, сильные инвестиции — аренда.
+	res, err := RentVsBuy(cfg, RentVsBuyParams{
+		HomePrice:               10_000_000,
+		DownPayment:             3_000_000,
+		MortgageRatePercent:     15,
+		MortgageMonths:          240,
+		MonthlyRent:             30_000,
+		RentEscalationPercent:   2,
+		InvestmentRatePercent:   12,
+		HomeAppreciationPercent: -2,
+		HorizonMonths:           120,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Winner != "rent" {
+		t.Fatalf("ожидалась победа аренды, получено %q (buy=%v rent=%v)", res.Winner, res.BuyNetWorth, res.RentNetWorth)
+	}
+}
+
+func TestBreakEvenAppreciation(t *testing.T) {
+	cfg := DefaultConfig()
+	p := RentVsBuyParams{
+		HomePrice:             10_000_000,
+		DownPayment:           3_000_000,
+		MortgageRatePercent:   12,
+		MortgageMonths:        240,
+		MonthlyRent:           45_000,
+		RentEscalationPercent: 4,
+		InvestmentRatePercent: 9,
+		HorizonMonths:         120,
+	}
+	rate, err := BreakEvenAppreciation(cfg, p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// При найденном росте оба пути должны сравняться.
+	p.HomeAppreciationPercent = rate
+	res, err := RentVsBuy(cfg, p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	inDelta(t, res.BuyNetWorth, res.RentNetWorth, 100)
+}
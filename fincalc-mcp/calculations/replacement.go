@@ -0,0 +1,77 @@
+package calculations
+
+import (
+	"fmt"
+	"math"
+)
+
+// IncomeReplacementResult — итог планирования «замены дохода»: целевой
+// капитал и ежемесячное накопление, достаточное для его сбора к началу
+// снятий.
+type IncomeReplacementResult struct {
+	MonthlyWithdrawal float64 `json:"monthly_withdrawal"`
+	// DrawdownMonths — горизонт снятий; 0 означает бессрочные снятия
+	// (вечная рента, капитал тратится только на проценты).
+	DrawdownMonths int `json:"drawdown_months,omitempty"`
+	// TargetBalance — капитал, который должен быть собран к началу снятий.
+	TargetBalance       float64 `json:"target_balance"`
+	AccumulationMonths  int     `json:"accumulation_months"`
+	RequiredMonthlySave float64 `json:"required_monthly_savings"`
+}
+
+// RequiredNestEgg возвращает капитал, достаточный для снятий monthlyWithdrawal
+// в месяц на горизонте drawdownMonths при заданной доходности: текущую
+// стоимость аннуитета снятий, а при drawdownMonths == 0 — вечную ренту W/r
+// (капитал тратится только на проценты; требует положительной ставки).
+func RequiredNestEgg(cfg Config, monthlyWithdrawal, annualRatePercent float64, drawdownMonths int) (float64, error) {
+	if monthlyWithdrawal <= 0 || monthlyWithdrawal > cfg.MaxPrincipal {
+		return 0, errOutOfRange("monthly_withdrawal", 1, int(cfg.MaxPrincipal))
+	}
+	if err := CheckRate(cfg, annualRatePercent); err != nil {
+		return 0, err
+	}
+	if drawdownMonths < 0 || drawdownMonths > cfg.MaxMonths {
+		return 0, errOutOfRange("drawdown_months", 0, cfg.MaxMonths)
+	}
+	r := MonthlyRate(annualRatePercent)
+	var target float64
+	switch {
+	case drawdownMonths == 0:
+		// Бессрочные снятия: капитал, проценты с которого равны снятию.
+		if r <= 0 {
+			return 0, fmt.Errorf("бессрочные снятия требуют положительной ставки")
+		}
+		target = monthlyWithdrawal / r
+	case r == 0:
+		target = monthlyWithdrawal * float64(drawdownMonths)
+	default:
+		// Текущая стоимость аннуитета снятий на горизонте drawdown.
+		target = monthlyWithdrawal * (1 - math.Pow(1+r, -float64(drawdownMonths))) / r
+	}
+	if target > cfg.MaxBalance {
+		return 0, fmt.Errorf("требуемый капитал %.2f превышает потолок %.0f", target, cfg.MaxBalance)
+	}
+	return Round(target, cfg.Precision), nil
+}
+
+// IncomeReplacement решает задачу «какое ежемесячное накопление за срок
+// накопления дает капитал, поддерживающий снятия W в месяц»: сначала
+// считается целевой капитал через RequiredNestEgg, затем требуемое
+// пополнение подбирается через RequiredContribution.
+func IncomeReplacement(cfg Config, initialAmount, annualRatePercent float64, accumulationMonths int, monthlyWithdrawal float64, drawdownMonths int) (*IncomeReplacementResult, error) {
+	target, err := RequiredNestEgg(cfg, monthlyWithdrawal, annualRatePercent, drawdownMonths)
+	if err != nil {
+		return nil, err
+	}
+	required, err := RequiredContribution(cfg, initialAmount, target, annualRatePercent, accumulationMonths, false)
+	if err != nil {
+		return nil, err
+	}
+	return &IncomeReplacementResult{
+		MonthlyWithdrawal:   monthlyWithdrawal,
+		DrawdownMonths:      drawdownMonths,
+		TargetBalance:       target,
+		AccumulationMonths:  accumulationMonths,
+		RequiredMonthlySave: required,
+	}, nil
+}
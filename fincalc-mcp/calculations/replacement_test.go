@@ -0,0 +1,60 @@
+package calculations
+
+import (
+	"math"
+	"testing"
+)
+
+func TestIncomeReplacementPerpetuity(t *testing.T) {
+	cfg := DefaultConfig()
+	// Вечная рента: при 12% годовых снятие 10 000/мес требует ровно 1 000 000.
+	res, err := IncomeReplacement(cfg, 0, 12, 120, 10_000, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	inDelta(t, res.TargetBalance, 1_000_000, 0.01)
+	// Подобранное пополнение действительно собирает целевой капитал.
+	deposit, err := DepositSchedule(cfg, DepositParams{
+		AnnualRatePercent:   12,
+		Months:              120,
+		MonthlyContribution: res.RequiredMonthlySave,
+		Capitalize:          true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if deposit.FinalBalance < res.TargetBalance-5 {
+		t.Fatalf("капитал %v не достигает цели %v", deposit.FinalBalance, res.TargetBalance)
+	}
+}
+
+func TestIncomeReplacementValidation(t *testing.T) {
+	cfg := DefaultConfig()
+	if _, err := IncomeReplacement(cfg, 0, 0, 120, 10_000, 0); err == nil {
+		t.Fatal("ожидалась ошибка: вечная рента невозможна при нулевой ставке")
+	}
+	if _, err := IncomeReplacement(cfg, 0, 12, 120, 0, 0); err == nil {
+		t.Fatal("ожидалась ошибка для нулевого снятия")
+	}
+}
+
+func TestRequiredNestEgg(t *testing.T) {
+	cfg := DefaultConfig()
+	// Конечный горизонт сходится с формулой текущей стоимости аннуитета.
+	target, err := RequiredNestEgg(cfg, 50_000, 12, 120)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := 0.01
+	expected := 50_000 * (1 - math.Pow(1+r, -120)) / r
+	inDelta(t, target, expected, 0.01)
+	// Бессрочные снятия — вечная рента W/r.
+	perpetuity, err := RequiredNestEgg(cfg, 50_000, 12, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	inDelta(t, perpetuity, 5_000_000, 0.01)
+	if _, err := RequiredNestEgg(cfg, 50_000, 0, 0); err == nil {
+		t.Fatal("ожидалась ошибка: вечная рента при нулевой ставке")
+	}
+}
@@ -0,0 +1,63 @@
+package calculations
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+)
+
+// ComparisonReportResult — пакет отчета для менеджера: числовое сравнение
+// схем, готовая сводка в Markdown и оба графика как CSV-вложения в base64.
+type ComparisonReportResult struct {
+	Comparison            map[string]any `json:"comparison"`
+	Markdown              string         `json:"markdown"`
+	AnnuityCSVBase64      string         `json:"annuity_csv_base64"`
+	DifferentialCSVBase64 string         `json:"differential_csv_base64"`
+}
+
+// scheduleCSV сериализует кредитный график в CSV: строка на месяц
+// плюс заголовок.
+func scheduleCSV(res *CalculationResult) string {
+	var b strings.Builder
+	b.WriteString("month,payment,principal_component,interest,remaining_principal\n")
+	for _, entry := range res.Schedule {
+		fmt.Fprintf(&b, "%d,%.2f,%.2f,%.2f,%.2f\n",
+			entry.Month, entry.Payment, entry.PrincipalComponent, entry.Interest, entry.RemainingPrincipal)
+	}
+	return b.String()
+}
+
+// ComparisonReport собирает полный пакет сравнения схем одним вызовом:
+// числа CompareLoans, Markdown-сводку и оба помесячных графика как
+// base64-CSV. Размер пакета ограничен лимитом развернутых расчетов —
+// графики входят в отчет целиком.
+func ComparisonReport(cfg Config, p LoanParams) (*ComparisonReportResult, error) {
+	if err := cfg.CheckExpansion(p.Months); err != nil {
+		return nil, err
+	}
+	comparison, err := CompareLoans(cfg, p)
+	if err != nil {
+		return nil, err
+	}
+	annuity, err := AnnuitySchedule(cfg, p)
+	if err != nil {
+		return nil, err
+	}
+	differential, err := DifferentialSchedule(cfg, p)
+	if err != nil {
+		return nil, err
+	}
+	var md strings.Builder
+	fmt.Fprintf(&md, "# Сравнение схем: %.2f на %d мес. под %.2f%%\n\n", p.Principal, p.Months, p.AnnualRatePercent)
+	md.WriteString("| Показатель | Аннуитет | Дифференцированная |\n|---|---|---|\n")
+	fmt.Fprintf(&md, "| Платеж | %.2f | %.2f … %.2f |\n", annuity.MonthlyPayment, differential.FirstPayment, differential.LastPayment)
+	fmt.Fprintf(&md, "| Переплата | %.2f | %.2f |\n", annuity.TotalInterest, differential.TotalInterest)
+	fmt.Fprintf(&md, "| Всего выплат | %.2f | %.2f |\n\n", annuity.TotalPayment, differential.TotalPayment)
+	fmt.Fprintf(&md, "%s\n", comparison["recommendation"])
+	return &ComparisonReportResult{
+		Comparison:            comparison,
+		Markdown:              md.String(),
+		AnnuityCSVBase64:      base64.StdEncoding.EncodeToString([]byte(scheduleCSV(annuity))),
+		DifferentialCSVBase64: base64.StdEncoding.EncodeToString([]byte(scheduleCSV(differential))),
+	}, nil
+}
@@ -0,0 +1,47 @@
+package calculations
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+)
+
+func TestComparisonReport(t *testing.T) {
+	cfg := DefaultConfig()
+	p := LoanParams{Principal: 1_000_000, AnnualRatePercent: 12, Months: 60}
+	report, err := ComparisonReport(cfg, p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Comparison["savings_with_differential"] == nil {
+		t.Fatal("в отчете нет числового сравнения")
+	}
+	if !strings.Contains(report.Markdown, "Переплата") {
+		t.Fatal("Markdown-сводка должна содержать переплату")
+	}
+	for name, encoded := range map[string]string{
+		"annuity":      report.AnnuityCSVBase64,
+		"differential": report.DifferentialCSVBase64,
+	} {
+		raw, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			t.Fatalf("CSV %s не декодируется из base64: %v", name, err)
+		}
+		lines := strings.Split(strings.TrimRight(string(raw), "\n"), "\n")
+		// Заголовок плюс строка на каждый месяц.
+		if len(lines) != 61 {
+			t.Fatalf("CSV %s: ожидалась 61 строка, получено %d", name, len(lines))
+		}
+		if !strings.HasPrefix(lines[0], "month,payment") {
+			t.Fatalf("CSV %s: неожиданный заголовок %q", name, lines[0])
+		}
+	}
+
+	// Пакет с полными графиками подчиняется лимиту развернутых расчетов.
+	long := LoanParams{Principal: 1_000_000, AnnualRatePercent: 12, Months: 600}
+	small := cfg
+	small.MaxExpandedCalculations = 100
+	if _, err := ComparisonReport(small, long); err == nil {
+		t.Fatal("ожидалась ошибка лимита развернутых расчетов")
+	}
+}
@@ -0,0 +1,61 @@
+package calculations
+
+import "fmt"
+
+// PercentOfBalancePayoff моделирует погашение револьверного долга, где
+// минимальный платеж — процент от текущего остатка, но не меньше нижнего
+// порога: платеж max(percent*balance, floor), проценты начисляются
+// помесячно. Без порога такой платеж убывает вместе с остатком и долг
+// никогда не закрывается полностью, поэтому порог обязателен; в сводке —
+// срок до выплаты, суммарные проценты и месяц, с которого порог начинает
+// действовать.
+func PercentOfBalancePayoff(cfg Config, principal, annualRatePercent, paymentPercent, paymentFloor float64) (map[string]any, error) {
+	if err := CheckPrincipal(cfg, principal); err != nil {
+		return nil, err
+	}
+	if err := CheckRate(cfg, annualRatePercent); err != nil {
+		return nil, err
+	}
+	if paymentPercent <= 0 || paymentPercent > 100 {
+		return nil, errOutOfRange("payment_percent", 1, 100)
+	}
+	if paymentFloor <= 0 || paymentFloor > cfg.MaxPrincipal {
+		return nil, fmt.Errorf("без нижнего порога платежа долг не амортизируется: порог должен быть положительным")
+	}
+	r := MonthlyRate(annualRatePercent)
+	balance := principal
+	totalInterest := 0.0
+	totalPaid := 0.0
+	months := 0
+	floorFromMonth := 0
+	for balance > 0 {
+		months++
+		if months > cfg.MaxMonths {
+			return nil, fmt.Errorf("долг не выплачивается за %d месяцев: платеж не покрывает проценты", cfg.MaxMonths)
+		}
+		interest := Round(balance*r, cfg.Precision)
+		payment := Round(balance*paymentPercent/100, cfg.Precision)
+		if payment < paymentFloor {
+			payment = paymentFloor
+			if floorFromMonth == 0 {
+				floorFromMonth = months
+			}
+		}
+		if payment <= interest {
+			return nil, fmt.Errorf("платеж %.2f месяца %d не покрывает проценты %.2f — долг растет", payment, months, interest)
+		}
+		if payment > balance+interest {
+			payment = Round(balance+interest, cfg.Precision)
+		}
+		balance = Round(balance+interest-payment, cfg.Precision)
+		totalInterest += interest
+		totalPaid += payment
+	}
+	return map[string]any{
+		"months_to_payoff": months,
+		"total_interest":   Round(totalInterest, cfg.Precision),
+		"total_paid":       Round(totalPaid, cfg.Precision),
+		"floor_from_month": floorFromMonth,
+		"note":             "без нижнего порога платеж-процент от остатка убывает вместе с долгом и никогда не закрывает его полностью",
+	}, nil
+}
@@ -0,0 +1,31 @@
+package calculations
+
+import "testing"
+
+func TestPercentOfBalancePayoff(t *testing.T) {
+	cfg := DefaultConfig()
+	// 3% от остатка с порогом 3000: хвост долга закрывается порогом.
+	out, err := PercentOfBalancePayoff(cfg, 100_000, 18, 3, 3_000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	months := out["months_to_payoff"].(int)
+	if months < 1 || months > cfg.MaxMonths {
+		t.Fatalf("срок выплаты %d вне разумных пределов", months)
+	}
+	if out["floor_from_month"].(int) == 0 {
+		t.Fatal("порог должен начать действовать на хвосте долга")
+	}
+	if out["total_interest"].(float64) <= 0 {
+		t.Fatal("суммарные проценты должны быть положительными")
+	}
+
+	// Без порога долг не амортизируется — порог обязателен.
+	if _, err := PercentOfBalancePayoff(cfg, 100_000, 18, 3, 0); err == nil {
+		t.Fatal("ожидалась ошибка для нулевого порога")
+	}
+	// Платеж ниже процентов — долг растет.
+	if _, err := PercentOfBalancePayoff(cfg, 1_000_000, 30, 1, 100); err == nil {
+		t.Fatal("ожидалась ошибка: платеж не покрывает проценты")
+	}
+}
@@ -0,0 +1,11 @@
+package calculations
+
+// Rule78Rebate возвращает возврат «незаработанных» процентов по правилу 78
+// при досрочном погашении: totalInterest * k(k+1) / (n(n+1)), где n — полный
+// срок в месяцах, k — оставшиеся месяцы. Конвенция применяется в старых
+// потребительских кредитах; границы аргументов проверяет вызывающий слой.
+func Rule78Rebate(totalInterest float64, totalMonths, monthsRemaining int) float64 {
+	n := float64(totalMonths)
+	k := float64(monthsRemaining)
+	return Round2(totalInterest * k * (k + 1) / (n * (n + 1)))
+}
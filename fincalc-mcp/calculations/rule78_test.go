@@ -0,0 +1,13 @@
+package calculations
+
+import "testing"
+
+func TestRule78Rebate(t *testing.T) {
+	// Хрестоматийный случай: 12 месяцев, переплата 78 — сумма цифр месяцев.
+	// За 3 оставшихся месяца «не заработано» 3+2+1 = 6 долей.
+	inDelta(t, Rule78Rebate(78, 12, 3), 6, 1e-9)
+	// Кредит не начался — возвращается вся переплата.
+	inDelta(t, Rule78Rebate(78, 12, 12), 78, 1e-9)
+	// Кредит выплачен полностью — возвращать нечего.
+	inDelta(t, Rule78Rebate(78, 12, 0), 0, 1e-9)
+}
@@ -0,0 +1,63 @@
+package calculations
+
+import (
+	"fmt"
+	"math"
+)
+
+// PrincipalOption — сводка кредита для одной суммы из диапазона.
+type PrincipalOption struct {
+	Principal      float64 `json:"principal"`
+	MonthlyPayment float64 `json:"monthly_payment"`
+	TotalInterest  float64 `json:"total_interest"`
+}
+
+// PrincipalSensitivity считает один и тот же кредит для сетки сумм от min до
+// max с шагом step: как масштабируются платеж и переплата. При фиксированных
+// ставке и сроке переплата линейна по телу кредита; линейность проверяется
+// по фактическим графикам (с допуском на округление) и раскрывается флагом.
+func PrincipalSensitivity(cfg Config, annualRatePercent float64, months int, minPrincipal, maxPrincipal, step float64) (map[string]any, error) {
+	if minPrincipal > maxPrincipal {
+		return nil, fmt.Errorf("минимальная сумма %.2f больше максимальной %.2f", minPrincipal, maxPrincipal)
+	}
+	if step <= 0 {
+		return nil, fmt.Errorf("шаг сетки должен быть положительным")
+	}
+	count := int((maxPrincipal-minPrincipal)/step) + 1
+	if err := cfg.CheckExpansion(count); err != nil {
+		return nil, err
+	}
+	options := make([]PrincipalOption, 0, count)
+	linear := true
+	var base *CalculationResult
+	for principal := minPrincipal; principal <= maxPrincipal+1e-9; principal += step {
+		res, err := AnnuitySchedule(cfg, LoanParams{
+			Principal:         principal,
+			AnnualRatePercent: annualRatePercent,
+			Months:            months,
+		})
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, PrincipalOption{
+			Principal:      Round(principal, cfg.Precision),
+			MonthlyPayment: res.MonthlyPayment,
+			TotalInterest:  res.TotalInterest,
+		})
+		if base == nil {
+			base = res
+			continue
+		}
+		// Переплата должна масштабироваться как отношение сумм; допуск —
+		// копейка на каждый месяц графика из-за денежного округления.
+		expected := base.TotalInterest * principal / base.Principal
+		if math.Abs(res.TotalInterest-expected) > 0.01*float64(months)+0.01 {
+			linear = false
+		}
+	}
+	return map[string]any{
+		"points":              options,
+		"linear_in_principal": linear,
+		"note":                "при фиксированных ставке и сроке переплата пропорциональна телу кредита",
+	}, nil
+}
@@ -0,0 +1,33 @@
+package calculations
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPrincipalSensitivityLinear(t *testing.T) {
+	cfg := DefaultConfig()
+	out, err := PrincipalSensitivity(cfg, 12, 60, 1_000_000, 3_000_000, 500_000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	options := out["points"].([]PrincipalOption)
+	if len(options) != 5 {
+		t.Fatalf("ожидалось 5 точек, получено %d", len(options))
+	}
+	// Переплата масштабируется пропорционально сумме (в пределах округления).
+	base := options[0]
+	for _, option := range options[1:] {
+		expected := base.TotalInterest * option.Principal / base.Principal
+		if diff := math.Abs(option.TotalInterest - expected); diff > 1 {
+			t.Fatalf("сумма %v: переплата %v далека от линейной %v", option.Principal, option.TotalInterest, expected)
+		}
+	}
+	if !out["linear_in_principal"].(bool) {
+		t.Fatal("линейность по телу кредита должна подтверждаться")
+	}
+
+	if _, err := PrincipalSensitivity(cfg, 12, 60, 1_000_000, 2_000_000, 100); err == nil {
+		t.Fatal("ожидалась ошибка лимита расчетов для слишком мелкого шага")
+	}
+}
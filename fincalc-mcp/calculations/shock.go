@@ -0,0 +1,50 @@
+package calculations
+
+import "fmt"
+
+// PaymentShockResult — «если ставка вырастет на X, платеж станет Y»:
+// пересчет платежа при повышении ставки на остаток долга и срока.
+type PaymentShockResult struct {
+	PaidMonths             int     `json:"paid_months"`
+	RemainingPrincipal     float64 `json:"remaining_principal"`
+	RemainingMonths        int     `json:"remaining_months"`
+	CurrentPayment         float64 `json:"current_payment"`
+	NewRatePercent         float64 `json:"new_rate_percent"`
+	NewPayment             float64 `json:"new_payment"`
+	PaymentIncrease        float64 `json:"payment_increase"`
+	PaymentIncreasePercent float64 `json:"payment_increase_percent"`
+}
+
+// PaymentShock считает платежный шок при пересмотре ставки (ARM-сброс):
+// остаток долга после paidMonths платежей амортизируется на оставшийся
+// срок по повышенной ставке, прирост платежа дается в абсолюте и в
+// процентах от текущего.
+func PaymentShock(cfg Config, p LoanParams, paidMonths int, rateIncreasePercent float64) (*PaymentShockResult, error) {
+	if rateIncreasePercent <= 0 {
+		return nil, fmt.Errorf("прирост ставки должен быть положительным, получено %.4f", rateIncreasePercent)
+	}
+	newRate := p.AnnualRatePercent + rateIncreasePercent
+	if err := CheckRate(cfg, newRate); err != nil {
+		return nil, err
+	}
+	if paidMonths < 0 || paidMonths >= p.Months {
+		return nil, errOutOfRange("paid_months", 0, p.Months-1)
+	}
+	balance, err := RemainingBalance(cfg, p, paidMonths)
+	if err != nil {
+		return nil, err
+	}
+	remaining := p.Months - paidMonths
+	current := Round(AnnuityPayment(p.Principal, p.AnnualRatePercent, p.Months), cfg.Precision)
+	shocked := Round(AnnuityPayment(balance, newRate, remaining), cfg.Precision)
+	return &PaymentShockResult{
+		PaidMonths:             paidMonths,
+		RemainingPrincipal:     balance,
+		RemainingMonths:        remaining,
+		CurrentPayment:         current,
+		NewRatePercent:         newRate,
+		NewPayment:             shocked,
+		PaymentIncrease:        Round(shocked-current, cfg.Precision),
+		PaymentIncreasePercent: RoundPercent(cfg, (shocked-current)/current*100),
+	}, nil
+}
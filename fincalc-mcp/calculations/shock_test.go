@@ -0,0 +1,44 @@
+package calculations
+
+import "testing"
+
+func TestPaymentShockARMReset(t *testing.T) {
+	cfg := DefaultConfig()
+	// Типичный ARM-сброс: через 5 лет из 30 ставка вырастает на 2 пункта.
+	p := LoanParams{Principal: 10_000_000, AnnualRatePercent: 7, Months: 360}
+	res, err := PaymentShock(cfg, p, 60, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	inDelta(t, res.NewRatePercent, 9, 0.0001)
+	if res.RemainingMonths != 300 {
+		t.Fatalf("ожидалось 300 оставшихся месяцев, получено %d", res.RemainingMonths)
+	}
+	if res.NewPayment <= res.CurrentPayment {
+		t.Fatalf("повышение ставки должно повышать платеж: %v <= %v", res.NewPayment, res.CurrentPayment)
+	}
+	inDelta(t, res.PaymentIncrease, res.NewPayment-res.CurrentPayment, 0.01)
+	// Сверка с прямым пересчетом аннуитета на остатке.
+	balance, err := RemainingBalance(cfg, p, 60)
+	if err != nil {
+		t.Fatal(err)
+	}
+	inDelta(t, res.NewPayment, AnnuityPayment(balance, 9, 300), 0.01)
+	if res.PaymentIncreasePercent <= 0 || res.PaymentIncreasePercent >= 100 {
+		t.Fatalf("шок в процентах выглядит неправдоподобно: %v", res.PaymentIncreasePercent)
+	}
+}
+
+func TestPaymentShockValidation(t *testing.T) {
+	cfg := DefaultConfig()
+	p := LoanParams{Principal: 1_000_000, AnnualRatePercent: 12, Months: 120}
+	if _, err := PaymentShock(cfg, p, 12, 0); err == nil {
+		t.Fatal("ожидалась ошибка для нулевого прироста ставки")
+	}
+	if _, err := PaymentShock(cfg, p, 120, 2); err == nil {
+		t.Fatal("ожидалась ошибка: кредит уже выплачен")
+	}
+	if _, err := PaymentShock(cfg, p, 12, 1000); err == nil {
+		t.Fatal("ожидалась ошибка: новая ставка за пределами лимита")
+	}
+}
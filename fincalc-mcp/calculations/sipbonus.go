@@ -0,0 +1,56 @@
+package calculations
+
+import "fmt"
+
+// SIPWithBonuses моделирует регулярные инвестиции с полугодовой премией:
+// сверх ежемесячного взноса в месяцы 6, 12, 18 и далее вносится разовый
+// бонус. Взносы и бонус ложатся в конец месяца, доход капитализируется
+// помесячно, как в CalculateInvestment. В сводке — итог с бонусами, итог
+// без них и прирост, который дали бонусы.
+func SIPWithBonuses(cfg Config, p InvestmentParams, bonusAmount float64) (map[string]any, error) {
+	if bonusAmount < 0 {
+		return nil, fmt.Errorf("бонус не может быть отрицательным")
+	}
+	if err := CheckAmount(cfg, bonusAmount); err != nil {
+		return nil, err
+	}
+	baseline, err := CalculateInvestment(cfg, p)
+	if err != nil {
+		return nil, err
+	}
+	r := MonthlyRate(p.AnnualReturnPercent)
+	res := &CalculationResult{
+		Kind:              KindInvestment,
+		AnnualRatePercent: p.AnnualReturnPercent,
+		Months:            p.Months,
+		Schedule:          make([]ScheduleEntry, 0, p.Months),
+	}
+	balance := p.InitialAmount
+	totalBonuses := 0.0
+	for m := 1; m <= p.Months; m++ {
+		interest := Round(balance*r, cfg.Precision)
+		contribution := p.MonthlyContribution
+		if m%6 == 0 {
+			contribution += bonusAmount
+			totalBonuses += bonusAmount
+		}
+		balance = Round(balance+interest+contribution, cfg.Precision)
+		res.Schedule = append(res.Schedule, ScheduleEntry{
+			Month:        m,
+			Contribution: contribution,
+			Interest:     interest,
+			Balance:      balance,
+		})
+		res.TotalContributions += contribution
+		res.TotalInterest += interest
+	}
+	res.TotalContributions = Round(res.TotalContributions, cfg.Precision)
+	res.TotalInterest = Round(res.TotalInterest, cfg.Precision)
+	res.FinalBalance = balance
+	return map[string]any{
+		"result":                 res,
+		"total_bonuses":          Round(totalBonuses, cfg.Precision),
+		"final_balance_no_bonus": baseline.FinalBalance,
+		"bonus_gain":             Round(balance-baseline.FinalBalance, cfg.Precision),
+	}, nil
+}
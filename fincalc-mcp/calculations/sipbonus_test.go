@@ -0,0 +1,41 @@
+package calculations
+
+import "testing"
+
+func TestSIPWithBonuses(t *testing.T) {
+	cfg := DefaultConfig()
+	p := InvestmentParams{
+		InitialAmount:       100_000,
+		MonthlyContribution: 10_000,
+		AnnualReturnPercent: 10,
+		Months:              24,
+	}
+	out, err := SIPWithBonuses(cfg, p, 50_000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res := out["result"].(*CalculationResult)
+	// Бонусы видны в месяцах, кратных шести, и только в них.
+	for _, entry := range res.Schedule {
+		want := 10_000.0
+		if entry.Month%6 == 0 {
+			want = 60_000
+		}
+		if entry.Contribution != want {
+			t.Fatalf("месяц %d: взнос %v, ожидался %v", entry.Month, entry.Contribution, want)
+		}
+	}
+	inDelta(t, out["total_bonuses"].(float64), 4*50_000, 0.01)
+	// Бонусы поднимают итог минимум на собственную сумму.
+	gain := out["bonus_gain"].(float64)
+	if gain < 4*50_000 {
+		t.Fatalf("прирост от бонусов %v меньше самих бонусов", gain)
+	}
+	if res.FinalBalance <= out["final_balance_no_bonus"].(float64) {
+		t.Fatal("итог с бонусами должен превышать итог без них")
+	}
+
+	if _, err := SIPWithBonuses(cfg, p, -1); err == nil {
+		t.Fatal("ожидалась ошибка для отрицательного бонуса")
+	}
+}
@@ -0,0 +1,165 @@
+package calculations
+
+import "fmt"
+
+// bisectionSteps — число итераций бисекции; достаточно для сходимости
+// заметно точнее денежного округления.
+const bisectionSteps = 100
+
+// RequiredContribution подбирает бисекцией минимальное ежемесячное
+// пополнение, при котором накопления по модели вклада с капитализацией
+// достигают целевой суммы за заданный срок. Если цель покрывается уже
+// начальной суммой, возвращает 0.
+func RequiredContribution(cfg Config, initialAmount, targetAmount, annualRatePercent float64, months int, contributionAtBeginning bool) (float64, error) {
+	if targetAmount <= 0 || targetAmount > cfg.MaxBalance {
+		return 0, fmt.Errorf("целевая сумма должна быть в диапазоне от 0 до %.0f", cfg.MaxBalance)
+	}
+	balanceAt := func(contribution float64) (float64, error) {
+		res, err := DepositSchedule(cfg, DepositParams{
+			InitialAmount:           initialAmount,
+			AnnualRatePercent:       annualRatePercent,
+			Months:                  months,
+			MonthlyContribution:     contribution,
+			Capitalize:              true,
+			ContributionAtBeginning: contributionAtBeginning,
+		})
+		if err != nil {
+			return 0, err
+		}
+		return res.FinalBalance, nil
+	}
+	if initialAmount > 0 {
+		atZero, err := balanceAt(0)
+		if err != nil {
+			return 0, err
+		}
+		if atZero >= targetAmount {
+			return 0, nil
+		}
+	}
+	atMax, err := balanceAt(cfg.MaxPrincipal)
+	if err != nil {
+		return 0, err
+	}
+	if atMax < targetAmount {
+		return 0, fmt.Errorf("цель недостижима даже при максимальном пополнении %.0f", cfg.MaxPrincipal)
+	}
+	lo, hi := 0.0, cfg.MaxPrincipal
+	for i := 0; i < bisectionSteps; i++ {
+		mid := (lo + hi) / 2
+		balance, err := balanceAt(mid)
+		if err != nil {
+			return 0, err
+		}
+		if balance < targetAmount {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return Round(hi, cfg.Precision), nil
+}
+
+// RequiredRate подбирает бисекцией минимальную годовую ставку (в процентах),
+// при которой накопления по модели вклада с капитализацией достигают целевой
+// суммы за заданный срок. Возвращает ошибку, если цель недостижима даже при
+// MaxRate или достижима уже при нулевой ставке.
+func RequiredRate(cfg Config, initialAmount, monthlyContribution, targetAmount float64, months int, contributionAtBeginning bool) (float64, error) {
+	if targetAmount <= 0 || targetAmount > cfg.MaxBalance {
+		return 0, fmt.Errorf("целевая сумма должна быть в диапазоне от 0 до %.0f", cfg.MaxBalance)
+	}
+	balanceAt := func(ratePercent float64) (float64, error) {
+		res, err := DepositSchedule(cfg, DepositParams{
+			InitialAmount:           initialAmount,
+			AnnualRatePercent:       ratePercent,
+			Months:                  months,
+			MonthlyContribution:     monthlyContribution,
+			Capitalize:              true,
+			ContributionAtBeginning: contributionAtBeginning,
+		})
+		if err != nil {
+			return 0, err
+		}
+		return res.FinalBalance, nil
+	}
+	atZero, err := balanceAt(0)
+	if err != nil {
+		return 0, err
+	}
+	if atZero >= targetAmount {
+		return 0, fmt.Errorf("цель достижима без доходности: взносы уже покрывают целевую сумму")
+	}
+	atMax, err := balanceAt(cfg.MaxRate)
+	if err != nil {
+		return 0, err
+	}
+	if atMax < targetAmount {
+		return 0, fmt.Errorf("цель недостижима даже при максимальной ставке %.0f%%", cfg.MaxRate)
+	}
+	lo, hi := 0.0, cfg.MaxRate
+	for i := 0; i < bisectionSteps; i++ {
+		mid := (lo + hi) / 2
+		balance, err := balanceAt(mid)
+		if err != nil {
+			return 0, err
+		}
+		if balance < targetAmount {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return Round(hi, 6), nil
+}
+
+// TermForInterestBudget возвращает максимальный срок в месяцах, при котором
+// переплата аннуитетного кредита не превышает бюджета процентов. Переплата
+// монотонно растет со сроком, поиск — бинарный. Ошибка, если бюджета не
+// хватает даже на один месяц.
+func TermForInterestBudget(cfg Config, principal, annualRatePercent, interestBudget float64) (int, error) {
+	if err := CheckPrincipal(cfg, principal); err != nil {
+		return 0, err
+	}
+	if err := CheckRate(cfg, annualRatePercent); err != nil {
+		return 0, err
+	}
+	if interestBudget <= 0 {
+		return 0, fmt.Errorf("бюджет процентов должен быть положительным")
+	}
+	interestAt := func(months int) float64 {
+		return AnnuityPayment(principal, annualRatePercent, months)*float64(months) - principal
+	}
+	if interestAt(1) > interestBudget {
+		return 0, fmt.Errorf("бюджета %.2f не хватает даже на один месяц", interestBudget)
+	}
+	lo, hi := 1, cfg.MaxMonths
+	for lo < hi {
+		mid := (lo + hi + 1) / 2
+		if interestAt(mid) <= interestBudget {
+			lo = mid
+		} else {
+			hi = mid - 1
+		}
+	}
+	return lo, nil
+}
+
+// RateForInterestBudget подбирает бисекцией годовую ставку, при которой
+// переплата аннуитетного кредита на заданный срок равна бюджету процентов.
+// Ошибка, если бюджет недостижим даже при максимальной ставке.
+func RateForInterestBudget(cfg Config, principal float64, months int, interestBudget float64) (float64, error) {
+	if err := CheckPrincipal(cfg, principal); err != nil {
+		return 0, err
+	}
+	if err := CheckMonths(cfg, months); err != nil {
+		return 0, err
+	}
+	if interestBudget <= 0 {
+		return 0, fmt.Errorf("бюджет процентов должен быть положительным")
+	}
+	atMax := AnnuityPayment(principal, cfg.MaxRate, months)*float64(months) - principal
+	if atMax < interestBudget {
+		return 0, fmt.Errorf("бюджет недостижим: даже при ставке %.0f%% переплата составит %.2f", cfg.MaxRate, atMax)
+	}
+	return equivalentAnnuityRate(principal, months, interestBudget, 0, cfg.MaxRate), nil
+}
@@ -0,0 +1,74 @@
+package calculations
+
+import "testing"
+
+func TestRequiredRateRecoversKnownRate(t *testing.T) {
+	cfg := DefaultConfig()
+	known, err := DepositSchedule(cfg, DepositParams{
+		InitialAmount:       100_000,
+		AnnualRatePercent:   12,
+		Months:              36,
+		MonthlyContribution: 5_000,
+		Capitalize:          true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rate, err := RequiredRate(cfg, 100_000, 5_000, known.FinalBalance, 36, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	inDelta(t, rate, 12, 0.05)
+}
+
+func TestRequiredRateUnreachable(t *testing.T) {
+	cfg := DefaultConfig()
+	if _, err := RequiredRate(cfg, 1_000, 0, 1_000_000_000, 12, false); err == nil {
+		t.Fatal("ожидалась ошибка для недостижимой цели")
+	}
+}
+
+func TestRequiredRateReachableAtZero(t *testing.T) {
+	cfg := DefaultConfig()
+	if _, err := RequiredRate(cfg, 100_000, 10_000, 150_000, 12, false); err == nil {
+		t.Fatal("ожидалась ошибка: цель достижима без доходности")
+	}
+}
+
+func TestTermForInterestBudget(t *testing.T) {
+	cfg := DefaultConfig()
+	months, err := TermForInterestBudget(cfg, 1_000_000, 12, 200_000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// На найденном сроке бюджет еще не превышен, на следующем — уже да.
+	interestAt := func(n int) float64 {
+		return AnnuityPayment(1_000_000, 12, n)*float64(n) - 1_000_000
+	}
+	if interestAt(months) > 200_000 {
+		t.Fatalf("на сроке %d переплата %v превышает бюджет", months, interestAt(months))
+	}
+	if interestAt(months+1) <= 200_000 {
+		t.Fatalf("срок %d не максимален: следующий месяц тоже укладывается", months)
+	}
+	if _, err := TermForInterestBudget(cfg, 1_000_000, 100, 100); err == nil {
+		t.Fatal("ожидалась ошибка: бюджета не хватает даже на месяц")
+	}
+}
+
+func TestRateForInterestBudget(t *testing.T) {
+	cfg := DefaultConfig()
+	// Обратная задача: какую ставку позволяет бюджет переплаты.
+	target, err := AnnuitySchedule(cfg, LoanParams{Principal: 1_000_000, AnnualRatePercent: 10, Months: 60})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rate, err := RateForInterestBudget(cfg, 1_000_000, 60, target.TotalInterest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	inDelta(t, rate, 10, 0.01)
+	if _, err := RateForInterestBudget(cfg, 1_000_000, 12, 1e9); err == nil {
+		t.Fatal("ожидалась ошибка для недостижимого бюджета")
+	}
+}
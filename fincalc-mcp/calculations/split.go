@@ -0,0 +1,72 @@
+package calculations
+
+import (
+	"fmt"
+	"math"
+)
+
+// BorrowerShareEntry — доля одного созаемщика в платеже месяца.
+type BorrowerShareEntry struct {
+	Month              int     `json:"month"`
+	Payment            float64 `json:"payment"`
+	PrincipalComponent float64 `json:"principal_component"`
+	Interest           float64 `json:"interest"`
+}
+
+// SplitScheduleResult — обычный аннуитетный график плюс параллельная
+// разбивка каждого платежа между двумя созаемщиками по согласованным долям.
+type SplitScheduleResult struct {
+	*CalculationResult
+	FirstSharePercent  float64              `json:"first_share_percent"`
+	SecondSharePercent float64              `json:"second_share_percent"`
+	FirstBorrower      []BorrowerShareEntry `json:"first_borrower"`
+	SecondBorrower     []BorrowerShareEntry `json:"second_borrower"`
+	FirstTotalPayment  float64              `json:"first_total_payment"`
+	SecondTotalPayment float64              `json:"second_total_payment"`
+}
+
+// SplitSchedule строит аннуитетный график и делит каждый платеж между двумя
+// созаемщиками по долям, в сумме дающим ровно 100%. Доля первого округляется
+// до денег, второй получает остаток платежа — так части каждой строки
+// сходятся с итогом копейка в копейку.
+func SplitSchedule(cfg Config, p LoanParams, firstSharePercent, secondSharePercent float64) (*SplitScheduleResult, error) {
+	if firstSharePercent <= 0 || firstSharePercent >= 100 {
+		return nil, errOutOfRange("first_share_percent", 0, 100)
+	}
+	if math.Abs(firstSharePercent+secondSharePercent-100) > 1e-9 {
+		return nil, fmt.Errorf("доли созаемщиков должны в сумме давать 100%%, получено %.4f", firstSharePercent+secondSharePercent)
+	}
+	res, err := AnnuitySchedule(cfg, p)
+	if err != nil {
+		return nil, err
+	}
+	out := &SplitScheduleResult{
+		CalculationResult:  res,
+		FirstSharePercent:  firstSharePercent,
+		SecondSharePercent: secondSharePercent,
+		FirstBorrower:      make([]BorrowerShareEntry, 0, len(res.Schedule)),
+		SecondBorrower:     make([]BorrowerShareEntry, 0, len(res.Schedule)),
+	}
+	share := firstSharePercent / 100
+	for _, entry := range res.Schedule {
+		first := BorrowerShareEntry{
+			Month:              entry.Month,
+			Payment:            Round(entry.Payment*share, cfg.Precision),
+			PrincipalComponent: Round(entry.PrincipalComponent*share, cfg.Precision),
+			Interest:           Round(entry.Interest*share, cfg.Precision),
+		}
+		second := BorrowerShareEntry{
+			Month:              entry.Month,
+			Payment:            Round(entry.Payment-first.Payment, cfg.Precision),
+			PrincipalComponent: Round(entry.PrincipalComponent-first.PrincipalComponent, cfg.Precision),
+			Interest:           Round(entry.Interest-first.Interest, cfg.Precision),
+		}
+		out.FirstBorrower = append(out.FirstBorrower, first)
+		out.SecondBorrower = append(out.SecondBorrower, second)
+		out.FirstTotalPayment += first.Payment
+		out.SecondTotalPayment += second.Payment
+	}
+	out.FirstTotalPayment = Round(out.FirstTotalPayment, cfg.Precision)
+	out.SecondTotalPayment = Round(out.SecondTotalPayment, cfg.Precision)
+	return out, nil
+}
@@ -0,0 +1,34 @@
+package calculations
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSplitSchedule(t *testing.T) {
+	cfg := DefaultConfig()
+	p := LoanParams{Principal: 3_000_000, AnnualRatePercent: 10, Months: 120}
+	res, err := SplitSchedule(cfg, p, 60, 40)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Доли каждого месяца сходятся с общим платежом копейка в копейку.
+	for i, entry := range res.Schedule {
+		first := res.FirstBorrower[i]
+		second := res.SecondBorrower[i]
+		if diff := math.Abs(first.Payment + second.Payment - entry.Payment); diff > 1e-9 {
+			t.Fatalf("месяц %d: %v + %v != %v", entry.Month, first.Payment, second.Payment, entry.Payment)
+		}
+		if diff := math.Abs(first.Interest + second.Interest - entry.Interest); diff > 1e-9 {
+			t.Fatalf("месяц %d: проценты долей не сходятся с общими", entry.Month)
+		}
+		if diff := math.Abs(first.PrincipalComponent + second.PrincipalComponent - entry.PrincipalComponent); diff > 1e-9 {
+			t.Fatalf("месяц %d: основной долг долей не сходится с общим", entry.Month)
+		}
+	}
+	inDelta(t, res.FirstTotalPayment+res.SecondTotalPayment, res.TotalPayment, 0.01)
+
+	if _, err := SplitSchedule(cfg, p, 60, 50); err == nil {
+		t.Fatal("ожидалась ошибка: доли не дают в сумме 100")
+	}
+}
@@ -0,0 +1,48 @@
+package calculations
+
+// SubsidizedRateResult — номинальная и эффективная ставки кредита с учетом
+// разового кэшбэка при выдаче и ежемесячной субсидии платежа.
+type SubsidizedRateResult struct {
+	NominalRatePercent   float64 `json:"nominal_rate_percent"`
+	EffectiveRatePercent float64 `json:"effective_rate_percent"`
+	Cashback             float64 `json:"cashback"`
+	MonthlySubsidy       float64 `json:"monthly_subsidy"`
+	MonthlyPayment       float64 `json:"monthly_payment"`
+	TotalNetPayment      float64 `json:"total_net_payment"`
+}
+
+// SubsidizedRate строит фактические чистые денежные потоки заемщика —
+// полученная сумма плюс кэшбэк против платежей за вычетом субсидии — и
+// решает IRR по ним: эффективную ставку, которую заемщик платит на деле.
+func SubsidizedRate(cfg Config, p LoanParams, cashback, monthlySubsidy float64) (*SubsidizedRateResult, error) {
+	if cashback < 0 || cashback > cfg.MaxPrincipal {
+		return nil, errOutOfRange("cashback", 0, int(cfg.MaxPrincipal))
+	}
+	if monthlySubsidy < 0 || monthlySubsidy > cfg.MaxPrincipal {
+		return nil, errOutOfRange("monthly_subsidy", 0, int(cfg.MaxPrincipal))
+	}
+	res, err := AnnuitySchedule(cfg, p)
+	if err != nil {
+		return nil, err
+	}
+	flows := make([]float64, 0, p.Months+1)
+	flows = append(flows, p.Principal+cashback)
+	totalNet := 0.0
+	for _, entry := range res.Schedule {
+		net := entry.Payment - monthlySubsidy
+		flows = append(flows, -net)
+		totalNet += net
+	}
+	monthlyIRR, err := IRR(flows)
+	if err != nil {
+		return nil, err
+	}
+	return &SubsidizedRateResult{
+		NominalRatePercent:   p.AnnualRatePercent,
+		EffectiveRatePercent: Round(monthlyIRR*12*100, 4),
+		Cashback:             cashback,
+		MonthlySubsidy:       monthlySubsidy,
+		MonthlyPayment:       res.MonthlyPayment,
+		TotalNetPayment:      Round(totalNet, cfg.Precision),
+	}, nil
+}
@@ -0,0 +1,70 @@
+package calculations
+
+import "testing"
+
+func TestIRRRecoversLoanRate(t *testing.T) {
+	cfg := DefaultConfig()
+	res, err := AnnuitySchedule(cfg, LoanParams{Principal: 1_000_000, AnnualRatePercent: 12, Months: 12})
+	if err != nil {
+		t.Fatal(err)
+	}
+	flows := []float64{res.Principal}
+	for _, entry := range res.Schedule {
+		flows = append(flows, -entry.Payment)
+	}
+	irr, err := IRR(flows)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// IRR платежей без льгот — исходная месячная ставка 1%.
+	inDelta(t, irr, 0.01, 1e-4)
+}
+
+func TestIRRNoSignChange(t *testing.T) {
+	if _, err := IRR([]float64{100, 100, 100}); err == nil {
+		t.Fatal("ожидалась ошибка для потока без смены знака")
+	}
+}
+
+func TestSubsidizedRateCashback(t *testing.T) {
+	cfg := DefaultConfig()
+	p := LoanParams{Principal: 1_000_000, AnnualRatePercent: 12, Months: 12}
+	res, err := SubsidizedRate(cfg, p, 100_000, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Крупный кэшбэк должен опустить эффективную ставку сильно ниже номинала.
+	if res.EffectiveRatePercent >= res.NominalRatePercent-5 {
+		t.Fatalf("эффективная ставка %v слишком близка к номинальной %v", res.EffectiveRatePercent, res.NominalRatePercent)
+	}
+	// Без льгот эффективная ставка совпадает с номинальной.
+	plain, err := SubsidizedRate(cfg, p, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	inDelta(t, plain.EffectiveRatePercent, 12, 0.01)
+}
+
+func TestSubsidizedRateMonthlySubsidy(t *testing.T) {
+	cfg := DefaultConfig()
+	p := LoanParams{Principal: 1_000_000, AnnualRatePercent: 12, Months: 12}
+	res, err := SubsidizedRate(cfg, p, 0, 10_000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.EffectiveRatePercent >= 12 {
+		t.Fatalf("субсидия должна снижать эффективную ставку, получено %v", res.EffectiveRatePercent)
+	}
+	inDelta(t, res.TotalNetPayment, res.MonthlyPayment*12-120_000, 0.05)
+}
+
+func TestSubsidizedRateValidation(t *testing.T) {
+	cfg := DefaultConfig()
+	p := LoanParams{Principal: 1_000_000, AnnualRatePercent: 12, Months: 12}
+	if _, err := SubsidizedRate(cfg, p, -1, 0); err == nil {
+		t.Fatal("ожидалась ошибка для отрицательного кэшбэка")
+	}
+	if _, err := SubsidizedRate(cfg, p, 0, -1); err == nil {
+		t.Fatal("ожидалась ошибка для отрицательной субсидии")
+	}
+}
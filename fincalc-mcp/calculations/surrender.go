@@ -0,0 +1,56 @@
+package calculations
+
+// SurrenderPoint — выкупная стоимость вклада при закрытии в конце месяца:
+// что получит вкладчик по штрафной ставке против баланса по договорной.
+type SurrenderPoint struct {
+	Month          int     `json:"month"`
+	SurrenderValue float64 `json:"surrender_value"`
+	ContractValue  float64 `json:"contract_value"`
+	Loss           float64 `json:"loss"`
+}
+
+// SurrenderCurve — кривая выкупной стоимости по месяцам: цена досрочного
+// выхода из вклада на каждом шаге срока.
+type SurrenderCurve struct {
+	PenaltyRatePercent float64          `json:"penalty_rate_percent"`
+	Points             []SurrenderPoint `json:"points"`
+}
+
+// SurrenderValueCurve строит кривую выкупной стоимости: при досрочном
+// закрытии в месяце m проценты за весь прошедший срок пересчитываются
+// по штрафной ставке (обычно ставка до востребования, 0 — полная потеря
+// процентов). Число месяцев ограничено лимитом развернутых расчетов.
+func SurrenderValueCurve(cfg Config, p DepositParams, penaltyRatePercent float64) (*SurrenderCurve, error) {
+	if err := CheckRate(cfg, penaltyRatePercent); err != nil {
+		return nil, err
+	}
+	if err := cfg.CheckExpansion(p.Months); err != nil {
+		return nil, err
+	}
+	contract, err := DepositSchedule(cfg, p)
+	if err != nil {
+		return nil, err
+	}
+	penaltyParams := p
+	penaltyParams.AnnualRatePercent = penaltyRatePercent
+	penaltyParams.RatePeriods = nil
+	penalty, err := DepositSchedule(cfg, penaltyParams)
+	if err != nil {
+		return nil, err
+	}
+	curve := &SurrenderCurve{
+		PenaltyRatePercent: penaltyRatePercent,
+		Points:             make([]SurrenderPoint, 0, p.Months),
+	}
+	for i := range contract.Schedule {
+		surrender := penalty.Schedule[i].Balance
+		held := contract.Schedule[i].Balance
+		curve.Points = append(curve.Points, SurrenderPoint{
+			Month:          contract.Schedule[i].Month,
+			SurrenderValue: surrender,
+			ContractValue:  held,
+			Loss:           Round(held-surrender, cfg.Precision),
+		})
+	}
+	return curve, nil
+}
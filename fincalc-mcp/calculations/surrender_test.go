@@ -0,0 +1,46 @@
+package calculations
+
+import "testing"
+
+func TestSurrenderValueCurveMonotonicFullLoss(t *testing.T) {
+	cfg := DefaultConfig()
+	p := DepositParams{
+		InitialAmount:       100_000,
+		AnnualRatePercent:   10,
+		Months:              24,
+		MonthlyContribution: 5_000,
+		Capitalize:          true,
+	}
+	// Штраф 0% — полная потеря процентов: остаются только свои деньги.
+	curve, err := SurrenderValueCurve(cfg, p, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(curve.Points) != 24 {
+		t.Fatalf("ожидалось 24 точки, получено %d", len(curve.Points))
+	}
+	for i, point := range curve.Points {
+		if i > 0 && point.SurrenderValue < curve.Points[i-1].SurrenderValue {
+			t.Fatalf("кривая должна быть монотонной: месяц %d (%v) ниже предыдущего (%v)",
+				point.Month, point.SurrenderValue, curve.Points[i-1].SurrenderValue)
+		}
+		if point.Loss < 0 {
+			t.Fatalf("потеря при выходе не может быть отрицательной, месяц %d: %v", point.Month, point.Loss)
+		}
+	}
+	// При полной потере процентов выкупная стоимость — только взносы.
+	inDelta(t, curve.Points[23].SurrenderValue, 100_000+24*5_000, 0.01)
+	// Цена выхода растет по мере накопления процентов.
+	if curve.Points[23].Loss <= curve.Points[0].Loss {
+		t.Fatal("потеря должна расти со временем")
+	}
+}
+
+func TestSurrenderValueCurveExpansionLimit(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.MaxExpandedCalculations = 12
+	p := DepositParams{InitialAmount: 100_000, AnnualRatePercent: 10, Months: 24, Capitalize: true}
+	if _, err := SurrenderValueCurve(cfg, p, 0); err == nil {
+		t.Fatal("ожидалась ошибка превышения лимита развернутых расчетов")
+	}
+}
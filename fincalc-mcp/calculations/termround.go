@@ -0,0 +1,45 @@
+package calculations
+
+// TermRounding — сравнение заданного срока с округленным вверх до целых
+// лет: «круглый» срок снижает платеж, но стоит дополнительных процентов.
+type TermRounding struct {
+	GivenMonths     int     `json:"given_months"`
+	RoundedMonths   int     `json:"rounded_months"`
+	GivenPayment    float64 `json:"given_payment"`
+	RoundedPayment  float64 `json:"rounded_payment"`
+	GivenInterest   float64 `json:"given_interest"`
+	RoundedInterest float64 `json:"rounded_interest"`
+	// PaymentRelief — на сколько снижается платеж при округлении срока.
+	PaymentRelief float64 `json:"payment_relief"`
+	// ExtraInterest — цена округления: прирост переплаты за весь срок.
+	ExtraInterest float64 `json:"extra_interest"`
+}
+
+// RoundTermToYears сравнивает кредит на заданный срок с тем же кредитом
+// на срок, округленный вверх до целого числа лет. Срок, уже кратный 12,
+// дает одинаковые результаты в обеих колонках.
+func RoundTermToYears(cfg Config, p LoanParams) (*TermRounding, error) {
+	given, err := AnnuitySchedule(cfg, p)
+	if err != nil {
+		return nil, err
+	}
+	roundedMonths := (p.Months + 11) / 12 * 12
+	rounded := given
+	if roundedMonths != p.Months {
+		roundedParams := p
+		roundedParams.Months = roundedMonths
+		if rounded, err = AnnuitySchedule(cfg, roundedParams); err != nil {
+			return nil, err
+		}
+	}
+	return &TermRounding{
+		GivenMonths:     p.Months,
+		RoundedMonths:   roundedMonths,
+		GivenPayment:    given.MonthlyPayment,
+		RoundedPayment:  rounded.MonthlyPayment,
+		GivenInterest:   given.TotalInterest,
+		RoundedInterest: rounded.TotalInterest,
+		PaymentRelief:   Round2(given.MonthlyPayment - rounded.MonthlyPayment),
+		ExtraInterest:   Round2(rounded.TotalInterest - given.TotalInterest),
+	}, nil
+}
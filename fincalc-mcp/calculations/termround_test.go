@@ -0,0 +1,31 @@
+package calculations
+
+import "testing"
+
+func TestRoundTermToYears(t *testing.T) {
+	cfg := DefaultConfig()
+	res, err := RoundTermToYears(cfg, LoanParams{Principal: 1_000_000, AnnualRatePercent: 12, Months: 30})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.RoundedMonths != 36 {
+		t.Fatalf("30 месяцев должны округляться до 36, получено %d", res.RoundedMonths)
+	}
+	if res.PaymentRelief <= 0 {
+		t.Fatalf("более длинный срок должен снижать платеж, получено %v", res.PaymentRelief)
+	}
+	if res.ExtraInterest <= 0 {
+		t.Fatalf("округление срока должно стоить дополнительных процентов, получено %v", res.ExtraInterest)
+	}
+}
+
+func TestRoundTermToYearsAlreadyWhole(t *testing.T) {
+	cfg := DefaultConfig()
+	res, err := RoundTermToYears(cfg, LoanParams{Principal: 1_000_000, AnnualRatePercent: 12, Months: 36})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.RoundedMonths != 36 || res.PaymentRelief != 0 || res.ExtraInterest != 0 {
+		t.Fatalf("кратный 12 срок не должен меняться: %+v", res)
+	}
+}
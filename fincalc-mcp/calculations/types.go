@@ -0,0 +1,84 @@
+// Package calculations реализует финансовые расчеты: кредиты, вклады,
+// инвестиции и сравнение схем платежей.
+//
+// Пакет — поддерживаемая поверхность для прямого встраивания: он не имеет
+// побочных эффектов (метрики, логирование, трассировка), потокобезопасен
+// (все функции чистые, конфигурация передается по значению) и возвращает
+// конкретные типы сводок — CalculationResult с дискриминатором Kind и
+// производные от него (InvestmentResult, OffsetResult). MCP-слой в
+// internal/server — лишь тонкая обертка над этим API.
+package calculations
+
+// Виды расчетов (дискриминатор Kind в CalculationResult).
+const (
+	KindAnnuity      = "annuity"
+	KindDifferential = "differential"
+	KindDeposit      = "deposit"
+	KindInvestment   = "investment"
+	KindDecumulation = "decumulation"
+)
+
+// ScheduleEntry — одна строка помесячного графика платежей или накоплений.
+// Для кредитов заполняются Payment/PrincipalComponent/RemainingPrincipal,
+// для вкладов и инвестиций — Contribution/Balance.
+type ScheduleEntry struct {
+	Month              int     `json:"month"`
+	Payment            float64 `json:"payment,omitempty"`
+	PrincipalComponent float64 `json:"principal_component,omitempty"`
+	Interest           float64 `json:"interest"`
+	// AccruedInterest и PaidInterest заполняются калькуляторами, в которых
+	// начисленные и уплаченные проценты расходятся (льготный период,
+	// пропущенный платеж, капитализация); у обычного кредита они совпадают
+	// с Interest и опускаются.
+	AccruedInterest float64 `json:"accrued_interest,omitempty"`
+	PaidInterest    float64 `json:"paid_interest,omitempty"`
+	// InterestShare и PrincipalShare — доли процентов и основного долга
+	// в платеже месяца (в процентах); при нулевом платеже опускаются.
+	InterestShare      float64 `json:"interest_share,omitempty"`
+	PrincipalShare     float64 `json:"principal_share,omitempty"`
+	RemainingPrincipal float64 `json:"remaining_principal,omitempty"`
+	// Fees — комиссия за обслуживание, действующая в месяце; заполняется
+	// при заданных периодах комиссий и не входит в Payment.
+	Fees         float64 `json:"fees,omitempty"`
+	Contribution float64 `json:"contribution,omitempty"`
+	Balance      float64 `json:"balance,omitempty"`
+	DueDate      string  `json:"due_date,omitempty"`
+	// Year и Quarter — фискальный период платежа; заполняются вместе
+	// с DueDate, когда задана дата выдачи.
+	Year    int `json:"year,omitempty"`
+	Quarter int `json:"quarter,omitempty"`
+}
+
+// fillPaymentShares проставляет в кредитном графике разбивку платежа
+// на проценты и основной долг в процентах. Нулевой платеж (каникулы,
+// вырожденные случаи) пропускается, чтобы не делить на ноль.
+func fillPaymentShares(cfg Config, schedule []ScheduleEntry) {
+	for i := range schedule {
+		if schedule[i].Payment == 0 {
+			continue
+		}
+		schedule[i].InterestShare = RoundPercent(cfg, schedule[i].Interest/schedule[i].Payment*100)
+		schedule[i].PrincipalShare = RoundPercent(cfg, schedule[i].PrincipalComponent/schedule[i].Payment*100)
+	}
+}
+
+// CalculationResult — итог расчета вместе с помесячным графиком.
+// Набор заполненных полей зависит от Kind.
+type CalculationResult struct {
+	Kind               string          `json:"kind"`
+	Principal          float64         `json:"principal,omitempty"`
+	AnnualRatePercent  float64         `json:"annual_rate_percent"`
+	Months             int             `json:"months"`
+	MonthlyPayment     float64         `json:"monthly_payment,omitempty"`
+	FirstPayment       float64         `json:"first_payment,omitempty"`
+	LastPayment        float64         `json:"last_payment,omitempty"`
+	TotalPayment       float64         `json:"total_payment,omitempty"`
+	TotalInterest      float64         `json:"total_interest"`
+	TotalFees          float64         `json:"total_fees,omitempty"`
+	TotalContributions float64         `json:"total_contributions,omitempty"`
+	CrossoverMonth     int             `json:"crossover_month,omitempty"`
+	MaturityBonus      float64         `json:"maturity_bonus,omitempty"`
+	FinalBalance       float64         `json:"final_balance,omitempty"`
+	PayoffDate         string          `json:"payoff_date,omitempty"`
+	Schedule           []ScheduleEntry `json:"schedule,omitempty"`
+}
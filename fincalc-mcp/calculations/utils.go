@@ -0,0 +1,20 @@
+package calculations
+
+import "math"
+
+// Round округляет значение до заданного числа знаков после запятой.
+func Round(v float64, precision int) float64 {
+	factor := math.Pow(10, float64(precision))
+	return math.Round(v*factor) / factor
+}
+
+// Round2 округляет денежную сумму до двух знаков (копеек).
+func Round2(v float64) float64 {
+	return Round(v, 2)
+}
+
+// RoundPercent округляет процентное значение с точностью cfg.PercentPrecision,
+// независимой от денежной.
+func RoundPercent(cfg Config, v float64) float64 {
+	return Round(v, cfg.PercentPrecision)
+}
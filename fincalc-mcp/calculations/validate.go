@@ -0,0 +1,83 @@
+package calculations
+
+import "fmt"
+
+// ValidationError описывает нарушение ограничения вместе с границами
+// допустимого диапазона: клиент может показать точную подсказку
+// («значение должно быть от X до Y»), а не только текст.
+type ValidationError struct {
+	Field   string  `json:"field"`
+	Value   float64 `json:"value"`
+	Min     float64 `json:"min"`
+	Max     float64 `json:"max"`
+	Message string  `json:"message"`
+}
+
+func (e *ValidationError) Error() string {
+	return e.Message
+}
+
+// CheckPrincipal проверяет сумму кредита.
+func CheckPrincipal(cfg Config, v float64) error {
+	if v <= 0 || v > cfg.MaxPrincipal {
+		return &ValidationError{
+			Field:   "principal",
+			Value:   v,
+			Min:     0,
+			Max:     cfg.MaxPrincipal,
+			Message: fmt.Sprintf("сумма должна быть в диапазоне от 0 до %.0f", cfg.MaxPrincipal),
+		}
+	}
+	return nil
+}
+
+// CheckAmount проверяет неотрицательную сумму (начальный взнос, пополнение).
+func CheckAmount(cfg Config, v float64) error {
+	if v < 0 || v > cfg.MaxPrincipal {
+		return &ValidationError{
+			Field:   "amount",
+			Value:   v,
+			Min:     0,
+			Max:     cfg.MaxPrincipal,
+			Message: fmt.Sprintf("сумма должна быть в диапазоне от 0 до %.0f", cfg.MaxPrincipal),
+		}
+	}
+	return nil
+}
+
+// CheckRate проверяет годовую процентную ставку.
+func CheckRate(cfg Config, v float64) error {
+	if v < 0 || v > cfg.MaxRate {
+		return &ValidationError{
+			Field:   "annual_rate_percent",
+			Value:   v,
+			Min:     0,
+			Max:     cfg.MaxRate,
+			Message: fmt.Sprintf("ставка должна быть в диапазоне от 0 до %.0f%%", cfg.MaxRate),
+		}
+	}
+	return nil
+}
+
+// CheckMonths проверяет срок в месяцах.
+func CheckMonths(cfg Config, v int) error {
+	if v < 1 || v > cfg.MaxMonths {
+		return &ValidationError{
+			Field:   "months",
+			Value:   float64(v),
+			Min:     1,
+			Max:     float64(cfg.MaxMonths),
+			Message: fmt.Sprintf("срок должен быть в диапазоне от 1 до %d месяцев", cfg.MaxMonths),
+		}
+	}
+	return nil
+}
+
+func errOutOfRange(name string, min, max int) error {
+	return &ValidationError{
+		Field:   name,
+		Min:     float64(min),
+		Max:     float64(max),
+		Message: fmt.Sprintf("параметр %s должен быть в диапазоне от %d до %d", name, min, max),
+	}
+}
@@ -0,0 +1,34 @@
+package calculations
+
+import (
+	"errors"
+	"testing"
+)
+
+func requireValidationError(t *testing.T, err error, field string, min, max float64) {
+	t.Helper()
+	if err == nil {
+		t.Fatal("ожидалась ошибка валидации")
+	}
+	var ve *ValidationError
+	if !errors.As(err, &ve) {
+		t.Fatalf("ожидалась *ValidationError, получено %T", err)
+	}
+	if ve.Field != field {
+		t.Fatalf("поле %q, ожидалось %q", ve.Field, field)
+	}
+	if ve.Min != min || ve.Max != max {
+		t.Fatalf("границы [%v, %v], ожидались [%v, %v]", ve.Min, ve.Max, min, max)
+	}
+	if ve.Message == "" {
+		t.Fatal("человекочитаемое сообщение должно сохраняться")
+	}
+}
+
+func TestValidatorsCarryBounds(t *testing.T) {
+	cfg := DefaultConfig()
+	requireValidationError(t, CheckPrincipal(cfg, -1), "principal", 0, cfg.MaxPrincipal)
+	requireValidationError(t, CheckAmount(cfg, -1), "amount", 0, cfg.MaxPrincipal)
+	requireValidationError(t, CheckRate(cfg, cfg.MaxRate+1), "annual_rate_percent", 0, cfg.MaxRate)
+	requireValidationError(t, CheckMonths(cfg, 0), "months", 1, float64(cfg.MaxMonths))
+}
@@ -0,0 +1,120 @@
+package calculations
+
+// KindVariable — кредит с переменной ставкой (график RatePeriod).
+const KindVariable = "variable"
+
+// VariableLoanParams — входные параметры кредита с переменной ставкой.
+type VariableLoanParams struct {
+	Principal   float64
+	RatePeriods []RatePeriod
+	Months      int
+	// PaymentCap — потолок ежемесячного платежа; 0 — без потолка.
+	// Если потолок ниже процентов месяца, недоплата капитализируется
+	// в остаток долга (отрицательная амортизация).
+	PaymentCap float64
+}
+
+// VariableLoanResult — график переменной ставки с раскрытием отрицательной
+// амортизации: обязательная для заемщика информация о месяцах, в которых
+// долг рос, и о том, вернулся ли кредит к нулю к концу срока.
+type VariableLoanResult struct {
+	*CalculationResult
+	// NegativeAmortizationMonths — месяцы, в которых остаток долга вырос.
+	NegativeAmortizationMonths []int `json:"negative_amortization_months,omitempty"`
+	// TotalNegativeAmortization — суммарный прирост долга за такие месяцы.
+	TotalNegativeAmortization float64 `json:"total_negative_amortization"`
+	// ReAmortizedByTerm — погашен ли долг полностью к концу срока; при
+	// false в FinalBalance остается шаровой платеж.
+	ReAmortizedByTerm bool `json:"re_amortized_by_term"`
+	// WeightedAverageRatePercent — средневзвешенная по остатку долга
+	// фактическая ставка графика (см. WeightedAverageRate).
+	WeightedAverageRatePercent float64 `json:"weighted_average_rate_percent"`
+}
+
+func (p VariableLoanParams) validate(cfg Config) error {
+	if err := CheckPrincipal(cfg, p.Principal); err != nil {
+		return err
+	}
+	if err := validateRatePeriods(cfg, p.RatePeriods, p.Months); err != nil {
+		return err
+	}
+	if err := CheckAmount(cfg, p.PaymentCap); err != nil {
+		return err
+	}
+	return CheckMonths(cfg, p.Months)
+}
+
+// VariableRateSchedule строит график кредита с переменной ставкой.
+// При каждой смене ставки платеж пересчитывается как аннуитет на остаток
+// долга и срока; потолок платежа может сделать платеж меньше процентов
+// месяца — тогда недоплата прибавляется к долгу (отрицательная амортизация),
+// что явно отражается в сводке.
+func VariableRateSchedule(cfg Config, p VariableLoanParams) (*VariableLoanResult, error) {
+	if err := p.validate(cfg); err != nil {
+		return nil, err
+	}
+	acc := newVariableAccruer(cfg, p.RatePeriods)
+	res := &VariableLoanResult{
+		CalculationResult: &CalculationResult{
+			Kind:              KindVariable,
+			Principal:         p.Principal,
+			AnnualRatePercent: blendedRatePercent(p.RatePeriods, p.Months),
+			Months:            p.Months,
+			Schedule:          make([]ScheduleEntry, 0, p.Months),
+		},
+		ReAmortizedByTerm: true,
+	}
+	balance := p.Principal
+	payment := 0.0
+	for m := 1; m <= p.Months; m++ {
+		rate := annualRateForMonth(p.RatePeriods, m)
+		if m == 1 || rate != annualRateForMonth(p.RatePeriods, m-1) {
+			payment = AnnuityPayment(balance, rate, p.Months-m+1)
+		}
+		if p.PaymentCap > 0 && payment > p.PaymentCap {
+			payment = p.PaymentCap
+		}
+		interest := acc.Interest(m, balance)
+		pay := Round(payment, cfg.Precision)
+		// Последний платеж закрывает остаток, если потолок позволяет.
+		if m == p.Months && (p.PaymentCap == 0 || balance+interest <= p.PaymentCap) {
+			pay = Round(balance+interest, cfg.Precision)
+		}
+		principalPart := Round(pay-interest, cfg.Precision)
+		if principalPart < 0 {
+			// Платеж не покрывает проценты: недоплата капитализируется.
+			balance = Round(balance-principalPart, cfg.Precision)
+			res.NegativeAmortizationMonths = append(res.NegativeAmortizationMonths, m)
+			res.TotalNegativeAmortization += -principalPart
+		} else {
+			if principalPart > balance {
+				principalPart = Round(balance, cfg.Precision)
+				pay = Round(principalPart+interest, cfg.Precision)
+			}
+			var err error
+			if balance, err = acc.ReduceDebt(m, balance, principalPart); err != nil {
+				return nil, err
+			}
+		}
+		res.Schedule = append(res.Schedule, ScheduleEntry{
+			Month:              m,
+			Payment:            pay,
+			PrincipalComponent: principalPart,
+			Interest:           interest,
+			RemainingPrincipal: balance,
+		})
+		res.TotalPayment += pay
+		res.TotalInterest += interest
+	}
+	if balance > cfg.NegativeBalanceTolerance() {
+		res.ReAmortizedByTerm = false
+		res.FinalBalance = balance
+	}
+	res.TotalPayment = Round(res.TotalPayment, cfg.Precision)
+	res.TotalInterest = Round(res.TotalInterest, cfg.Precision)
+	res.TotalNegativeAmortization = Round(res.TotalNegativeAmortization, cfg.Precision)
+	res.CrossoverMonth = CrossoverMonth(res.Schedule)
+	res.WeightedAverageRatePercent = WeightedAverageRate(res.Schedule, p.Principal)
+	fillPaymentShares(cfg, res.Schedule)
+	return res, nil
+}
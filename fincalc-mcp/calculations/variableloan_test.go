@@ -0,0 +1,64 @@
+package calculations
+
+import "testing"
+
+func TestVariableRateScheduleNegativeAmortization(t *testing.T) {
+	cfg := DefaultConfig()
+	// Потолок платежа зафиксирован на уровне стартового аннуитета; после
+	// скачка ставки проценты месяца превышают потолок и долг растет.
+	p := VariableLoanParams{
+		Principal: 1_000_000,
+		Months:    60,
+		RatePeriods: []RatePeriod{
+			{FromMonth: 1, AnnualRatePercent: 6},
+			{FromMonth: 13, AnnualRatePercent: 36},
+		},
+		PaymentCap: Round2(AnnuityPayment(1_000_000, 6, 60)),
+	}
+	res, err := VariableRateSchedule(cfg, p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.NegativeAmortizationMonths) == 0 {
+		t.Fatal("ожидались месяцы отрицательной амортизации после скачка ставки")
+	}
+	if first := res.NegativeAmortizationMonths[0]; first < 13 {
+		t.Fatalf("долг не должен расти до скачка ставки, первый месяц %d", first)
+	}
+	if res.TotalNegativeAmortization <= 0 {
+		t.Fatalf("суммарный прирост долга должен быть положительным, получено %v", res.TotalNegativeAmortization)
+	}
+	if res.Schedule[12].RemainingPrincipal <= res.Schedule[11].RemainingPrincipal {
+		t.Fatal("в месяце роста долга остаток должен увеличиваться")
+	}
+	if res.ReAmortizedByTerm {
+		t.Fatal("при постоянном превышении процентов над потолком кредит не может погаситься в срок")
+	}
+	if res.FinalBalance <= 0 {
+		t.Fatalf("ожидался шаровой остаток на конец срока, получено %v", res.FinalBalance)
+	}
+}
+
+func TestVariableRateScheduleWithoutCapAmortizes(t *testing.T) {
+	cfg := DefaultConfig()
+	res, err := VariableRateSchedule(cfg, VariableLoanParams{
+		Principal: 1_000_000,
+		Months:    60,
+		RatePeriods: []RatePeriod{
+			{FromMonth: 1, AnnualRatePercent: 6},
+			{FromMonth: 13, AnnualRatePercent: 36},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.NegativeAmortizationMonths) != 0 {
+		t.Fatalf("без потолка платежа долг не растет, получено %v", res.NegativeAmortizationMonths)
+	}
+	if !res.ReAmortizedByTerm {
+		t.Fatal("без потолка кредит должен погаситься к концу срока")
+	}
+	if last := res.Schedule[len(res.Schedule)-1].RemainingPrincipal; last != 0 {
+		t.Fatalf("остаток в последнем месяце должен быть нулевым, получено %v", last)
+	}
+}
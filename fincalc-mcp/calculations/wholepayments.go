@@ -0,0 +1,85 @@
+package calculations
+
+import (
+	"fmt"
+	"math"
+)
+
+// Режимы округления платежа до целых единиц валюты.
+const (
+	WholeNearest = "nearest" // к ближайшему целому
+	WholeUp      = "up"      // вверх, к следующему целому
+)
+
+// WholePaymentsResult — аннуитетный график с платежами, округленными до
+// целых рублей, и накопленным эффектом округления.
+type WholePaymentsResult struct {
+	*CalculationResult
+	// RoundingEffect — на сколько суммарные выплаты отличаются от графика
+	// без округления платежей; накопленная разница сверяется в последнем
+	// платеже, поэтому кредит закрывается ровно в ноль.
+	RoundingEffect float64 `json:"rounding_effect"`
+}
+
+// AnnuityScheduleWholePayments строит аннуитетный график, в котором каждый
+// плановый платеж округлен до целых единиц валюты (к ближайшему или вверх —
+// так печатают выписки некоторые банки). Накопленное округление
+// компенсируется последним платежом: он может быть нецелым, но долг
+// закрывается точно. При округлении вверх кредит может закрыться раньше
+// срока.
+func AnnuityScheduleWholePayments(cfg Config, p LoanParams, mode string) (*WholePaymentsResult, error) {
+	baseline, err := AnnuitySchedule(cfg, p)
+	if err != nil {
+		return nil, err
+	}
+	raw := AnnuityPayment(p.Principal, p.AnnualRatePercent, p.Months)
+	var payment float64
+	switch mode {
+	case WholeNearest:
+		payment = math.Round(raw)
+	case WholeUp:
+		payment = math.Ceil(raw)
+	default:
+		return nil, fmt.Errorf("параметр %q должен быть %q или %q", "round_payments_to_whole", WholeNearest, WholeUp)
+	}
+	r := MonthlyRate(p.AnnualRatePercent)
+	res := &CalculationResult{
+		Kind:              KindAnnuity,
+		Principal:         p.Principal,
+		AnnualRatePercent: p.AnnualRatePercent,
+		Months:            p.Months,
+		MonthlyPayment:    payment,
+		Schedule:          make([]ScheduleEntry, 0, p.Months),
+	}
+	balance := p.Principal
+	for m := 1; m <= p.Months && balance > 0; m++ {
+		interest := Round(balance*r, cfg.Precision)
+		principalPart := Round(payment-interest, cfg.Precision)
+		if principalPart >= balance || m == p.Months {
+			// Последний платеж сверяет накопленное округление и закрывает
+			// долг ровно в ноль; он единственный может быть нецелым.
+			principalPart = Round(balance, cfg.Precision)
+		}
+		balance = Round(balance-principalPart, cfg.Precision)
+		pay := Round(principalPart+interest, cfg.Precision)
+		res.Schedule = append(res.Schedule, ScheduleEntry{
+			Month:              m,
+			Payment:            pay,
+			PrincipalComponent: principalPart,
+			Interest:           interest,
+			RemainingPrincipal: balance,
+		})
+		res.TotalInterest += interest
+		res.TotalPayment += pay
+	}
+	res.Months = len(res.Schedule)
+	res.FirstPayment = res.Schedule[0].Payment
+	res.LastPayment = res.Schedule[len(res.Schedule)-1].Payment
+	res.TotalInterest = Round(res.TotalInterest, cfg.Precision)
+	res.TotalPayment = Round(res.TotalPayment, cfg.Precision)
+	fillPaymentShares(cfg, res.Schedule)
+	return &WholePaymentsResult{
+		CalculationResult: res,
+		RoundingEffect:    Round(res.TotalPayment-baseline.TotalPayment, cfg.Precision),
+	}, nil
+}
@@ -0,0 +1,45 @@
+package calculations
+
+import (
+	"math"
+	"testing"
+)
+
+func TestAnnuityScheduleWholePayments(t *testing.T) {
+	cfg := DefaultConfig()
+	p := LoanParams{Principal: 1_000_000, AnnualRatePercent: 12, Months: 60}
+	for _, mode := range []string{WholeNearest, WholeUp} {
+		res, err := AnnuityScheduleWholePayments(cfg, p, mode)
+		if err != nil {
+			t.Fatal(err)
+		}
+		last := res.Schedule[len(res.Schedule)-1]
+		if last.RemainingPrincipal != 0 {
+			t.Fatalf("режим %q: долг должен закрыться в ноль, остаток %v", mode, last.RemainingPrincipal)
+		}
+		// Все платежи, кроме сверочного последнего, — целые рубли.
+		for _, entry := range res.Schedule[:len(res.Schedule)-1] {
+			if math.Mod(entry.Payment, 1) != 0 {
+				t.Fatalf("режим %q: платеж месяца %d не целый: %v", mode, entry.Month, entry.Payment)
+			}
+		}
+		// Сумма компонент сходится с выплатами.
+		inDelta(t, res.TotalPayment, p.Principal+res.TotalInterest, 0.01)
+	}
+
+	// Округление вверх ускоряет выплату и не может стоить дороже.
+	up, err := AnnuityScheduleWholePayments(cfg, p, WholeUp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if up.Months > p.Months {
+		t.Fatalf("округление вверх не может удлинить срок: %d", up.Months)
+	}
+	if up.RoundingEffect > 0.01 {
+		t.Fatalf("округление вверх не должно удорожать кредит: %v", up.RoundingEffect)
+	}
+
+	if _, err := AnnuityScheduleWholePayments(cfg, p, "down"); err == nil {
+		t.Fatal("ожидалась ошибка для неизвестного режима округления")
+	}
+}
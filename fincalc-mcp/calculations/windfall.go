@@ -0,0 +1,36 @@
+package calculations
+
+import "fmt"
+
+// WindfallPayoff показывает ускорение выплаты кредита от направления
+// разовых поступлений (премий, бонусов) в тело долга: каждое поступление
+// вносится как частичное досрочное погашение с сохранением платежа и
+// сокращением срока. Поверх фактического графика RealizedRate сводка
+// отвечает на главный вопрос — новый месяц выплаты и экономия процентов.
+// Месяцы поступлений должны быть в пределах срока и строго упорядочены.
+func WindfallPayoff(cfg Config, p LoanParams, windfalls []Prepayment) (map[string]any, error) {
+	if len(windfalls) == 0 {
+		return nil, fmt.Errorf("нужно хотя бы одно поступление")
+	}
+	for i := 1; i < len(windfalls); i++ {
+		if windfalls[i].Month <= windfalls[i-1].Month {
+			return nil, fmt.Errorf("поступления должны быть строго упорядочены по месяцу")
+		}
+	}
+	res, err := RealizedRate(cfg, p, windfalls)
+	if err != nil {
+		return nil, err
+	}
+	totalWindfalls := 0.0
+	for _, w := range windfalls {
+		totalWindfalls += w.Amount
+	}
+	return map[string]any{
+		"payoff_month":      p.Months - res.MonthsShortened,
+		"months_shortened":  res.MonthsShortened,
+		"interest_saved":    res.InterestSaved,
+		"total_interest":    res.TotalInterest,
+		"baseline_interest": res.BaselineInterest,
+		"total_windfalls":   Round(totalWindfalls, cfg.Precision),
+	}, nil
+}
@@ -0,0 +1,47 @@
+package calculations
+
+import (
+	"math"
+	"testing"
+)
+
+func TestWindfallPayoff(t *testing.T) {
+	cfg := DefaultConfig()
+	p := LoanParams{Principal: 1_000_000, AnnualRatePercent: 12, Months: 120}
+	first := []Prepayment{{Month: 12, Amount: 100_000}}
+	second := []Prepayment{{Month: 24, Amount: 100_000}}
+	both := []Prepayment{first[0], second[0]}
+
+	out, err := WindfallPayoff(cfg, p, both)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out["payoff_month"].(int) >= p.Months {
+		t.Fatalf("срок должен сократиться, месяц выплаты %v", out["payoff_month"])
+	}
+	saved := out["interest_saved"].(float64)
+	one, err := WindfallPayoff(cfg, p, first)
+	if err != nil {
+		t.Fatal(err)
+	}
+	two, err := WindfallPayoff(cfg, p, second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sum := one["interest_saved"].(float64) + two["interest_saved"].(float64)
+	// Экономия двух поступлений складывается из экономий каждого
+	// (с поправкой на взаимодействие укороченных графиков).
+	if saved <= one["interest_saved"].(float64) || saved <= two["interest_saved"].(float64) {
+		t.Fatalf("экономия обоих поступлений %v должна превышать экономию каждого", saved)
+	}
+	if math.Abs(saved-sum) > 0.15*sum {
+		t.Fatalf("экономия %v слишком далека от суммы по отдельности %v", saved, sum)
+	}
+
+	if _, err := WindfallPayoff(cfg, p, []Prepayment{second[0], first[0]}); err == nil {
+		t.Fatal("ожидалась ошибка для неупорядоченных поступлений")
+	}
+	if _, err := WindfallPayoff(cfg, p, nil); err == nil {
+		t.Fatal("ожидалась ошибка для пустого списка поступлений")
+	}
+}
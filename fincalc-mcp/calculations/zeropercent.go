@@ -0,0 +1,45 @@
+package calculations
+
+import (
+	"fmt"
+	"math"
+)
+
+// ZeroPercentTrueCost раскрывает реальную стоимость «рассрочки под 0%»
+// с наценкой в цене: разница между ценой в рассрочку и наличной ценой —
+// скрытые проценты. Покупатель фактически получает товар по наличной цене
+// и гасит завышенную равными платежами; IRR этого потока дает истинную
+// ставку, effective_apr_percent — ее эффективный годовой эквивалент.
+func ZeroPercentTrueCost(cfg Config, installmentPrice, cashPrice float64, months int) (map[string]any, error) {
+	if err := CheckPrincipal(cfg, cashPrice); err != nil {
+		return nil, err
+	}
+	if err := CheckPrincipal(cfg, installmentPrice); err != nil {
+		return nil, err
+	}
+	if installmentPrice < cashPrice {
+		return nil, fmt.Errorf("цена в рассрочку %.2f ниже наличной %.2f — скрытых процентов нет", installmentPrice, cashPrice)
+	}
+	if err := CheckMonths(cfg, months); err != nil {
+		return nil, err
+	}
+	payment := installmentPrice / float64(months)
+	flows := make([]float64, 0, months+1)
+	flows = append(flows, cashPrice)
+	for m := 1; m <= months; m++ {
+		flows = append(flows, -payment)
+	}
+	irr, err := IRR(flows)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{
+		"installment_price":      installmentPrice,
+		"cash_price":             cashPrice,
+		"markup":                 Round(installmentPrice-cashPrice, cfg.Precision),
+		"monthly_payment":        Round(payment, cfg.Precision),
+		"months":                 months,
+		"nominal_annual_percent": Round(irr*12*100, 4),
+		"effective_apr_percent":  Round((math.Pow(1+irr, 12)-1)*100, 4),
+	}, nil
+}
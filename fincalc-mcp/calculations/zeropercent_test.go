@@ -0,0 +1,27 @@
+package calculations
+
+import "testing"
+
+func TestZeroPercentTrueCost(t *testing.T) {
+	cfg := DefaultConfig()
+	// Наценка 10% на годовой рассрочке — отнюдь не бесплатные деньги.
+	out, err := ZeroPercentTrueCost(cfg, 110_000, 100_000, 12)
+	if err != nil {
+		t.Fatal(err)
+	}
+	inDelta(t, out["markup"].(float64), 10_000, 0.01)
+	apr := out["effective_apr_percent"].(float64)
+	if apr < 15 || apr > 25 {
+		t.Fatalf("эффективная ставка %v%% вне правдоподобного диапазона", apr)
+	}
+	// Без наценки ставка действительно нулевая.
+	fair, err := ZeroPercentTrueCost(cfg, 100_000, 100_000, 12)
+	if err != nil {
+		t.Fatal(err)
+	}
+	inDelta(t, fair["effective_apr_percent"].(float64), 0, 0.01)
+
+	if _, err := ZeroPercentTrueCost(cfg, 90_000, 100_000, 12); err == nil {
+		t.Fatal("ожидалась ошибка для рассрочки дешевле наличной цены")
+	}
+}
@@ -0,0 +1,103 @@
+// Команда fincalc-mcp запускает MCP сервер финансовых калькуляторов
+// (кредиты, вклады, инвестиции) с HTTP транспортом.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"fincalc-mcp/calculations"
+	"fincalc-mcp/internal/server"
+)
+
+// shutdownTimeout — время на корректное завершение активных запросов.
+const shutdownTimeout = 10 * time.Second
+
+func main() {
+	printTools := flag.Bool("print-tools", false, "вывести справку по инструментам в формате mcp_tools.json и выйти")
+	flag.Parse()
+
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
+		Level: logLevel(os.Getenv("LOG_LEVEL")),
+	}))
+	srv := server.New(calculations.DefaultConfig(), logger)
+	if raw := os.Getenv("TOOL_TIMEOUT_SECONDS"); raw != "" {
+		seconds, err := strconv.Atoi(raw)
+		if err != nil || seconds < 1 {
+			logger.Error("TOOL_TIMEOUT_SECONDS должен быть положительным целым числом", "value", raw)
+			os.Exit(1)
+		}
+		srv.SetToolTimeout(time.Duration(seconds) * time.Second)
+	}
+
+	if *printTools {
+		doc, err := srv.ToolsJSON()
+		if err != nil {
+			logger.Error("не удалось сформировать справку по инструментам", "error", err)
+			os.Exit(1)
+		}
+		fmt.Println(string(doc))
+		return
+	}
+
+	host := os.Getenv("MCP_HOST")
+	port := os.Getenv("MCP_PORT")
+	if host == "" || port == "" {
+		logger.Error("переменные окружения MCP_HOST и MCP_PORT обязательны")
+		os.Exit(1)
+	}
+	addr := net.JoinHostPort(host, port)
+	logger.Info("запуск MCP сервера", "addr", addr, "endpoint", "/mcp")
+	httpSrv := &http.Server{Addr: addr, Handler: srv.Handler()}
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- httpSrv.ListenAndServe()
+	}()
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, syscall.SIGINT, syscall.SIGTERM)
+	select {
+	case err := <-errCh:
+		logger.Error("сервер остановлен с ошибкой", "error", err)
+		os.Exit(1)
+	case sig := <-stop:
+		logger.Info("получен сигнал завершения", "signal", sig.String())
+	}
+
+	// Итог финального сброса фиксируется в логе: операторам важно знать,
+	// что при остановке ни один активный запрос не был оборван.
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := httpSrv.Shutdown(ctx); err != nil {
+		logger.Error("корректное завершение не удалось: активные запросы оборваны", "error", err)
+		os.Exit(1)
+	}
+	if err := <-errCh; err != nil && !errors.Is(err, http.ErrServerClosed) {
+		logger.Error("сервер завершился с ошибкой", "error", err)
+		os.Exit(1)
+	}
+	logger.Info("корректное завершение: все активные запросы обслужены")
+}
+
+func logLevel(name string) slog.Level {
+	switch strings.ToUpper(name) {
+	case "DEBUG":
+		return slog.LevelDebug
+	case "WARNING":
+		return slog.LevelWarn
+	case "ERROR", "CRITICAL":
+		return slog.LevelError
+	}
+	return slog.LevelInfo
+}
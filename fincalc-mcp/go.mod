@@ -0,0 +1,3 @@
+module fincalc-mcp
+
+go 1.21
@@ -0,0 +1,270 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Помощники извлечения аргументов инструмента из декодированных
+// JSON-параметров tools/call.
+
+func toFloat(name string, v any) (float64, error) {
+	switch x := v.(type) {
+	case float64:
+		return x, nil
+	case json.Number:
+		f, err := x.Float64()
+		if err != nil {
+			return 0, fmt.Errorf("параметр %q должен быть числом", name)
+		}
+		return f, nil
+	case string:
+		// Некоторые MCP-клиенты кодируют все параметры строками
+		// ("1000000" вместо 1000000) — принимаем строку, если она
+		// однозначно разбирается как число.
+		f, err := strconv.ParseFloat(strings.TrimSpace(x), 64)
+		if err != nil {
+			return 0, fmt.Errorf("параметр %q содержит строку %q, которая не разбирается как число", name, x)
+		}
+		return f, nil
+	}
+	return 0, fmt.Errorf("параметр %q должен быть числом", name)
+}
+
+func floatArg(args map[string]any, name string) (float64, error) {
+	v, ok := args[name]
+	if !ok {
+		return 0, fmt.Errorf("отсутствует обязательный параметр %q", name)
+	}
+	return toFloat(name, v)
+}
+
+func floatArgDefault(args map[string]any, name string, def float64) (float64, error) {
+	if _, ok := args[name]; !ok {
+		return def, nil
+	}
+	return floatArg(args, name)
+}
+
+func intArg(args map[string]any, name string) (int, error) {
+	f, err := floatArg(args, name)
+	if err != nil {
+		return 0, err
+	}
+	if f != math.Trunc(f) {
+		return 0, fmt.Errorf("параметр %q должен быть целым числом", name)
+	}
+	return int(f), nil
+}
+
+func intArgDefault(args map[string]any, name string, def int) (int, error) {
+	if _, ok := args[name]; !ok {
+		return def, nil
+	}
+	return intArg(args, name)
+}
+
+func stringArgDefault(args map[string]any, name, def string) (string, error) {
+	v, ok := args[name]
+	if !ok {
+		return def, nil
+	}
+	s, ok := v.(string)
+	if !ok {
+		return "", fmt.Errorf("параметр %q должен быть строкой", name)
+	}
+	return s, nil
+}
+
+func stringArg(args map[string]any, name string) (string, error) {
+	if _, ok := args[name]; !ok {
+		return "", fmt.Errorf("отсутствует обязательный параметр %q", name)
+	}
+	return stringArgDefault(args, name, "")
+}
+
+func intSliceArg(args map[string]any, name string) ([]int, error) {
+	v, ok := args[name]
+	if !ok {
+		return nil, fmt.Errorf("отсутствует обязательный параметр %q", name)
+	}
+	arr, ok := v.([]any)
+	if !ok {
+		return nil, fmt.Errorf("параметр %q должен быть массивом чисел", name)
+	}
+	out := make([]int, 0, len(arr))
+	for _, item := range arr {
+		f, err := toFloat(name, item)
+		if err != nil {
+			return nil, err
+		}
+		if f != math.Trunc(f) {
+			return nil, fmt.Errorf("параметр %q должен содержать целые числа", name)
+		}
+		out = append(out, int(f))
+	}
+	return out, nil
+}
+
+func floatSliceArg(args map[string]any, name string) ([]float64, error) {
+	v, ok := args[name]
+	if !ok {
+		return nil, fmt.Errorf("отсутствует обязательный параметр %q", name)
+	}
+	arr, ok := v.([]any)
+	if !ok {
+		return nil, fmt.Errorf("параметр %q должен быть массивом чисел", name)
+	}
+	out := make([]float64, 0, len(arr))
+	for _, item := range arr {
+		f, err := toFloat(name, item)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, f)
+	}
+	return out, nil
+}
+
+func stringSliceArg(args map[string]any, name string) ([]string, error) {
+	v, ok := args[name]
+	if !ok {
+		return nil, nil
+	}
+	arr, ok := v.([]any)
+	if !ok {
+		return nil, fmt.Errorf("параметр %q должен быть массивом строк", name)
+	}
+	out := make([]string, 0, len(arr))
+	for _, item := range arr {
+		s, ok := item.(string)
+		if !ok {
+			return nil, fmt.Errorf("параметр %q должен содержать строки", name)
+		}
+		out = append(out, s)
+	}
+	return out, nil
+}
+
+// Форматы годовой ставки: проценты (12 для 12%) или доля (0.12 для 12%).
+const (
+	rateFormatPercent  = "percent"
+	rateFormatFraction = "fraction"
+)
+
+// rateArgKeys — реестр параметров-ставок, к которым применяется rate_format.
+// Инструмент, объявляющий rate_format, обязан регистрировать здесь все свои
+// параметры-ставки: конвертация только одного из них при формате fraction
+// молча смешала бы единицы измерения внутри одного расчета.
+var rateArgKeys = map[string]bool{
+	"annual_rate_percent":       true,
+	"annual_return_percent":     true,
+	"deposit_rate_percent":      true,
+	"flexible_rate_percent":     true,
+	"home_appreciation_percent": true,
+	"investment_rate_percent":   true,
+	"mortgage_rate_percent":     true,
+	"new_rate_percent":          true,
+	"nominal_rate_percent":      true,
+	"penalty_rate_percent":      true,
+	"rate_increase_percent":     true,
+	"reduced_rate_percent":      true,
+	"rent_escalation_percent":   true,
+	"revolving_rate_percent":    true,
+}
+
+// normalizeRateFormat интерпретирует зарегистрированные параметры-ставки
+// согласно rate_format и приводит значения к процентам. Возвращает
+// предупреждение, когда значение похоже на перепутанный формат: основная
+// ставка "percent" меньше 1 обычно означает долю, а "fraction" больше 1 —
+// проценты.
+func normalizeRateFormat(args map[string]any) (string, error) {
+	format, err := stringArgDefault(args, "rate_format", rateFormatPercent)
+	if err != nil {
+		return "", err
+	}
+	delete(args, "rate_format")
+	if format != rateFormatPercent && format != rateFormatFraction {
+		return "", fmt.Errorf("параметр %q должен быть %q или %q", "rate_format", rateFormatPercent, rateFormatFraction)
+	}
+	warning := ""
+	names := make([]string, 0, len(args))
+	for name := range args {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if rateArgKeys[name] {
+			w, err := normalizeRateValue(args, name, format)
+			if err != nil {
+				return "", err
+			}
+			if warning == "" {
+				warning = w
+			}
+			continue
+		}
+		// Ставки внутри массивов объектов (rate_periods, investments,
+		// offers) конвертируются по тому же реестру ключей.
+		arr, ok := args[name].([]any)
+		if !ok {
+			continue
+		}
+		for _, item := range arr {
+			obj, ok := item.(map[string]any)
+			if !ok {
+				continue
+			}
+			for key := range obj {
+				if !rateArgKeys[key] {
+					continue
+				}
+				w, err := normalizeRateValue(obj, key, format)
+				if err != nil {
+					return "", err
+				}
+				if warning == "" {
+					warning = w
+				}
+			}
+		}
+	}
+	return warning, nil
+}
+
+// normalizeRateValue приводит одну ставку объекта к процентам.
+func normalizeRateValue(obj map[string]any, name, format string) (string, error) {
+	rate, err := toFloat(name, obj[name])
+	if err != nil {
+		return "", err
+	}
+	if format == rateFormatFraction {
+		obj[name] = rate * 100
+		if rate > 1 {
+			return fmt.Sprintf("ставка %q = %v в формате fraction больше 1 — возможно, переданы проценты; для 12%% укажите 0.12 или rate_format=percent", name, rate), nil
+		}
+		return "", nil
+	}
+	// Малые значения легальны для дельт и штрафных ставок — подозрение
+	// на перепутанный формат поднимается только для основной ставки.
+	if name == "annual_rate_percent" && rate > 0 && rate < 1 {
+		return fmt.Sprintf("ставка %v в формате percent меньше 1%% — возможно, передана доля; для 12%% укажите 12 или rate_format=fraction", rate), nil
+	}
+	return "", nil
+}
+
+func boolArgDefault(args map[string]any, name string, def bool) (bool, error) {
+	v, ok := args[name]
+	if !ok {
+		return def, nil
+	}
+	b, ok := v.(bool)
+	if !ok {
+		return false, fmt.Errorf("параметр %q должен быть булевым", name)
+	}
+	return b, nil
+}
@@ -0,0 +1,40 @@
+package server
+
+import "testing"
+
+func TestFloatArgAcceptsStringEncodedNumber(t *testing.T) {
+	args := map[string]any{"principal": "1000000", "months": "12"}
+	principal, err := floatArg(args, "principal")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if principal != 1_000_000 {
+		t.Fatalf("получено %v, ожидалось 1000000", principal)
+	}
+	months, err := intArg(args, "months")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if months != 12 {
+		t.Fatalf("получено %v, ожидалось 12", months)
+	}
+}
+
+func TestFloatArgRejectsNonNumericString(t *testing.T) {
+	args := map[string]any{"principal": "миллион"}
+	if _, err := floatArg(args, "principal"); err == nil {
+		t.Fatal("ожидалась ошибка для строки, не являющейся числом")
+	}
+}
+
+func TestStringEncodedLoanCall(t *testing.T) {
+	s := newTestServer()
+	result := callToolForTest(t, s, "calculate_annuity", map[string]any{
+		"principal":           "1000000",
+		"annual_rate_percent": "12",
+		"months":              "12",
+	})
+	if result["isError"] != false {
+		t.Fatalf("строковые числа должны приниматься: %v", result)
+	}
+}
@@ -0,0 +1,261 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+
+	"fincalc-mcp/calculations"
+)
+
+// Сквозная конвертация валюты: по exchange_rate и target_currency денежные
+// поля результата пересчитываются в целевую валюту, исходные значения
+// остаются в structuredContent без изменений.
+
+// currencyConversion — разобранные параметры конвертации.
+type currencyConversion struct {
+	Rate     float64
+	Currency string
+}
+
+// currencyArgs извлекает и удаляет параметры конвертации из аргументов.
+// Оба параметра задаются вместе; курс должен быть положительным и конечным.
+func currencyArgs(args map[string]any) (*currencyConversion, error) {
+	_, hasRate := args["exchange_rate"]
+	currency, err := stringArgDefault(args, "target_currency", "")
+	if err != nil {
+		return nil, err
+	}
+	if !hasRate && currency == "" {
+		return nil, nil
+	}
+	if !hasRate || currency == "" {
+		return nil, fmt.Errorf("параметры %q и %q задаются вместе", "exchange_rate", "target_currency")
+	}
+	rate, err := floatArg(args, "exchange_rate")
+	if err != nil {
+		return nil, err
+	}
+	if rate <= 0 || math.IsInf(rate, 0) || math.IsNaN(rate) {
+		return nil, fmt.Errorf("параметр %q должен быть положительным конечным числом", "exchange_rate")
+	}
+	delete(args, "exchange_rate")
+	delete(args, "target_currency")
+	return &currencyConversion{Rate: rate, Currency: currency}, nil
+}
+
+// monetaryKeys — явный реестр денежных полей результатов: конвертируются
+// только перечисленные ключи. Ставки, проценты, доли, счетчики месяцев и
+// дни сюда не входят. Инструмент, добавляющий новое денежное поле, обязан
+// дописать его ключ в этот список — иначе поле останется в валюте кредита
+// (безопасная сторона ошибки: старый эвристический запретительный список
+// молча портил нечисловые по смыслу поля вроде долей и кварталов).
+var monetaryKeys = map[string]bool{
+	"accrued_interest":            true,
+	"added_interest":              true,
+	"advantage":                   true,
+	"amortizing_payment":          true,
+	"amount":                      true,
+	"annual_debt_service":         true,
+	"annuity_interest":            true,
+	"asset_value":                 true,
+	"average_revolving_debt":      true,
+	"average_term_debt":           true,
+	"balance":                     true,
+	"balance_delta":               true,
+	"base_monthly_payment":        true,
+	"baseline_cost":               true,
+	"baseline_interest":           true,
+	"best_total_cost":             true,
+	"bonus_gain":                  true,
+	"buy_investments":             true,
+	"buy_net_worth":               true,
+	"calendar_day_interest":       true,
+	"capital_gain":                true,
+	"cash_price":                  true,
+	"cashback":                    true,
+	"contract_value":              true,
+	"contribution":                true,
+	"contribution_delta":          true,
+	"cost_delta_to_next":          true,
+	"cumulative_contributions":    true,
+	"cumulative_interest":         true,
+	"current_payment":             true,
+	"deposit_exit_value":          true,
+	"difference":                  true,
+	"differential_interest":       true,
+	"down_payment":                true,
+	"earned_interest":             true,
+	"extra_cost":                  true,
+	"extra_cost_vs_monthly":       true,
+	"extra_interest":              true,
+	"extra_vs_immediate":          true,
+	"fair_value":                  true,
+	"fee":                         true,
+	"fees":                        true,
+	"fees_accrued":                true,
+	"final_balance":               true,
+	"final_balance_delta":         true,
+	"final_balance_difference":    true,
+	"final_balance_no_bonus":      true,
+	"final_growth":                true,
+	"final_net_worth":             true,
+	"final_offset_balance":        true,
+	"final_own_money":             true,
+	"final_payment":               true,
+	"final_value":                 true,
+	"financed_fee":                true,
+	"first_payment":               true,
+	"first_total_payment":         true,
+	"flexible_balance":            true,
+	"given_interest":              true,
+	"given_payment":               true,
+	"gross_principal":             true,
+	"headroom_payment":            true,
+	"home_price":                  true,
+	"home_value":                  true,
+	"initial_amount":              true,
+	"initial_payment":             true,
+	"installment_price":           true,
+	"interest":                    true,
+	"interest_delta":              true,
+	"interest_only_payment":       true,
+	"interest_paid":               true,
+	"interest_premium":            true,
+	"interest_saved":              true,
+	"investments":                 true,
+	"last_payment":                true,
+	"lifetime_savings":            true,
+	"loan_balance":                true,
+	"loan_total_interest":         true,
+	"loss":                        true,
+	"markup":                      true,
+	"maturity_bonus":              true,
+	"max_home_price":              true,
+	"max_principal":               true,
+	"mimic_total_interest":        true,
+	"min_principal":               true,
+	"monthly_fee":                 true,
+	"monthly_income":              true,
+	"monthly_owner_costs":         true,
+	"monthly_payment":             true,
+	"monthly_payment_foreign":     true,
+	"monthly_payment_local":       true,
+	"monthly_rent":                true,
+	"monthly_savings":             true,
+	"monthly_subsidy":             true,
+	"monthly_withdrawal":          true,
+	"net_worth":                   true,
+	"new_monthly_payment":         true,
+	"new_payment":                 true,
+	"new_total_cost":              true,
+	"nominal_paid":                true,
+	"old_remaining_cost":          true,
+	"paid_interest":               true,
+	"payment":                     true,
+	"payment_budget":              true,
+	"payment_delta":               true,
+	"payment_increase":            true,
+	"payment_jump":                true,
+	"payment_relief":              true,
+	"points_cost":                 true,
+	"principal":                   true,
+	"principal_component":         true,
+	"purchasing_power_loss":       true,
+	"real_final_balance":          true,
+	"real_paid":                   true,
+	"real_savings":                true,
+	"rebate":                      true,
+	"reduced_monthly_payment":     true,
+	"remaining_debt":              true,
+	"remaining_principal":         true,
+	"remaining_principal_delta":   true,
+	"rent_investments":            true,
+	"rent_net_worth":              true,
+	"required_contribution":       true,
+	"required_deposit":            true,
+	"required_extra_payment":      true,
+	"required_income":             true,
+	"required_monthly_savings":    true,
+	"required_nest_egg":           true,
+	"rounded_interest":            true,
+	"rounded_payment":             true,
+	"rounding_effect":             true,
+	"savings":                     true,
+	"savings_balance":             true,
+	"savings_with_differential":   true,
+	"second_total_payment":        true,
+	"shifted_interest":            true,
+	"standard_total_interest":     true,
+	"surrender_value":             true,
+	"target_amount":               true,
+	"target_balance":              true,
+	"taxes_insurance":             true,
+	"total_bonuses":               true,
+	"total_contributions":         true,
+	"total_cost":                  true,
+	"total_debt_payments":         true,
+	"total_fees":                  true,
+	"total_interest":              true,
+	"total_interest_delta":        true,
+	"total_interest_foreign":      true,
+	"total_interest_local":        true,
+	"total_invested":              true,
+	"total_negative_amortization": true,
+	"total_net_payment":           true,
+	"total_nominal_cost":          true,
+	"total_paid":                  true,
+	"total_payment":               true,
+	"total_payment_delta":         true,
+	"total_payment_foreign":       true,
+	"total_payment_local":         true,
+	"total_real_cost":             true,
+	"total_savings":               true,
+	"total_windfalls":             true,
+	"total_withdrawals":           true,
+	"upfront_fee":                 true,
+}
+
+// isMonetaryKey отвечает, входит ли числовое поле в реестр денежных.
+// Строковые значения (kind, даты, рекомендации) обход не трогает
+// независимо от ключа.
+func isMonetaryKey(key string) bool {
+	return monetaryKeys[key]
+}
+
+// convert возвращает копию результата, в которой денежные числовые поля
+// умножены на курс. Структура приводится к дереву map/slice через JSON.
+func (c *currencyConversion) convert(structured any) (any, error) {
+	b, err := json.Marshal(structured)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось сериализовать результат для конвертации")
+	}
+	var tree any
+	if err := json.Unmarshal(b, &tree); err != nil {
+		return nil, fmt.Errorf("не удалось разобрать результат для конвертации")
+	}
+	return c.walk(tree, true), nil
+}
+
+func (c *currencyConversion) walk(node any, monetary bool) any {
+	switch v := node.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for key, value := range v {
+			out[key] = c.walk(value, isMonetaryKey(key))
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, value := range v {
+			out[i] = c.walk(value, monetary)
+		}
+		return out
+	case float64:
+		if monetary {
+			return calculations.Round2(v * c.Rate)
+		}
+		return v
+	}
+	return node
+}
@@ -0,0 +1,1952 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"fincalc-mcp/calculations"
+)
+
+// Обработчики инструментов: извлекают аргументы, вызывают пакет calculations
+// и возвращают структурированный результат.
+
+// Форматы выдачи графика.
+const (
+	formatRows    = "rows"
+	formatColumns = "columns"
+)
+
+// Порядок строк графика.
+const (
+	orderAsc  = "asc"
+	orderDesc = "desc"
+)
+
+// applyOrder возвращает результат с графиком в запрошенном порядке:
+// asc (по умолчанию) — с первого месяца, desc — с последнего. Значения
+// строк не меняются, обращается только порядок перечисления.
+func applyOrder(res *calculations.CalculationResult, args map[string]any) (*calculations.CalculationResult, error) {
+	order, err := stringArgDefault(args, "order", orderAsc)
+	if err != nil {
+		return nil, err
+	}
+	switch order {
+	case orderAsc:
+		return res, nil
+	case orderDesc:
+		clone := *res
+		clone.Schedule = make([]calculations.ScheduleEntry, len(res.Schedule))
+		for i, entry := range res.Schedule {
+			clone.Schedule[len(res.Schedule)-1-i] = entry
+		}
+		return &clone, nil
+	}
+	return nil, fmt.Errorf("параметр %q должен быть %q или %q", "order", orderAsc, orderDesc)
+}
+
+// resultColumns — результат с колоночным представлением графика.
+type resultColumns struct {
+	calculations.CalculationResult
+	Columns map[string][]float64 `json:"columns"`
+}
+
+// scheduleResponse оформляет результат согласно выходному параметру format:
+// построчный график (rows) или выровненные колонки (columns).
+func scheduleResponse(res *calculations.CalculationResult, args map[string]any) (any, error) {
+	res, err := applyOrder(res, args)
+	if err != nil {
+		return nil, err
+	}
+	format, err := stringArgDefault(args, "format", formatRows)
+	if err != nil {
+		return nil, err
+	}
+	switch format {
+	case formatRows:
+		return res, nil
+	case formatColumns:
+		columns := calculations.ScheduleToColumns(res)
+		clone := *res
+		clone.Schedule = nil
+		return resultColumns{clone, columns}, nil
+	}
+	return nil, fmt.Errorf("параметр %q должен быть %q или %q", "format", formatRows, formatColumns)
+}
+
+// applyCalendar проставляет даты платежей, если передан start_date.
+// День платежа по умолчанию совпадает с днем выдачи кредита.
+func applyCalendar(res *calculations.CalculationResult, args map[string]any) error {
+	startStr, err := stringArgDefault(args, "start_date", "")
+	if err != nil {
+		return err
+	}
+	if startStr == "" {
+		return nil
+	}
+	start, err := time.Parse("2006-01-02", startStr)
+	if err != nil {
+		return fmt.Errorf("параметр %q должен быть датой в формате YYYY-MM-DD", "start_date")
+	}
+	day, err := intArgDefault(args, "payment_day_of_month", start.Day())
+	if err != nil {
+		return err
+	}
+	return calculations.DueDates(res, start, day)
+}
+
+func (s *Server) loanParams(args map[string]any) (calculations.LoanParams, error) {
+	var p calculations.LoanParams
+	var err error
+	if p.Principal, err = floatArg(args, "principal"); err != nil {
+		return p, err
+	}
+	if p.AnnualRatePercent, err = floatArg(args, "annual_rate_percent"); err != nil {
+		return p, err
+	}
+	if p.Months, err = intArg(args, "months"); err != nil {
+		return p, err
+	}
+	return p, nil
+}
+
+func (s *Server) annuityHandler(ctx context.Context, args map[string]any) (any, error) {
+	p, err := s.loanParams(args)
+	if err != nil {
+		return nil, err
+	}
+	exact, err := boolArgDefault(args, "exact", false)
+	if err != nil {
+		return nil, err
+	}
+	compounding, err := stringArgDefault(args, "compounding", calculations.CompoundingMonthly)
+	if err != nil {
+		return nil, err
+	}
+	delayDays, err := intArgDefault(args, "first_payment_delay_days", 0)
+	if err != nil {
+		return nil, err
+	}
+	financedFee, err := floatArgDefault(args, "financed_fee", 0)
+	if err != nil {
+		return nil, err
+	}
+	wholeMode, err := stringArgDefault(args, "round_payments_to_whole", "")
+	if err != nil {
+		return nil, err
+	}
+	// Альтернативные конвенции графика взаимно исключающи: комбинация —
+	// явная ошибка, а не недокументированный приоритет одной из них.
+	mode := ""
+	for _, m := range []struct {
+		name string
+		set  bool
+	}{
+		{"first_payment_delay_days", delayDays > 0},
+		{"financed_fee", financedFee != 0},
+		{"round_payments_to_whole", wholeMode != ""},
+	} {
+		if !m.set {
+			continue
+		}
+		if mode != "" {
+			return nil, fmt.Errorf("параметры %q и %q несовместимы", mode, m.name)
+		}
+		mode = m.name
+	}
+	if mode != "" {
+		if exact || compounding == calculations.CompoundingDaily {
+			return nil, fmt.Errorf("параметр %q несовместим с %q и дневной капитализацией", mode, "exact")
+		}
+		// Пост-обработка стандартного пути (комиссии, календарь, формат
+		// вывода) к альтернативным конвенциям не применяется — заявленный,
+		// но не поддержанный параметр отклоняется, а не игнорируется.
+		for _, name := range []string{"fee_periods", "start_date", "payment_day_of_month", "format", "order"} {
+			if _, ok := args[name]; ok {
+				return nil, fmt.Errorf("параметр %q не поддерживается вместе с %q", name, mode)
+			}
+		}
+		switch mode {
+		case "first_payment_delay_days":
+			return calculations.AnnuityScheduleWithFirstDelay(s.cfg, p, delayDays)
+		case "financed_fee":
+			return calculations.AnnuityScheduleFinancedFee(s.cfg, p, financedFee)
+		}
+		return calculations.AnnuityScheduleWholePayments(s.cfg, p, wholeMode)
+	}
+	switch compounding {
+	case calculations.CompoundingMonthly:
+	case calculations.CompoundingDaily:
+		if exact {
+			return nil, fmt.Errorf("параметры %q и %q несовместимы", "compounding", "exact")
+		}
+		startStr, err := stringArgDefault(args, "start_date", "")
+		if err != nil {
+			return nil, err
+		}
+		start, err := time.Parse("2006-01-02", startStr)
+		if err != nil {
+			return nil, fmt.Errorf("конвенция %q требует параметра %q в формате YYYY-MM-DD", calculations.CompoundingDaily, "start_date")
+		}
+		return calculations.AnnuityScheduleDailyCompounding(s.cfg, p, start)
+	default:
+		return nil, fmt.Errorf("параметр %q должен быть %q или %q", "compounding", calculations.CompoundingMonthly, calculations.CompoundingDaily)
+	}
+	schedule := calculations.AnnuitySchedule
+	if exact {
+		schedule = calculations.AnnuityScheduleExact
+	}
+	res, err := schedule(s.cfg, p)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.applyFees(res, args); err != nil {
+		return nil, err
+	}
+	if err := applyCalendar(res, args); err != nil {
+		return nil, err
+	}
+	return scheduleResponse(res, args)
+}
+
+func (s *Server) differentialHandler(ctx context.Context, args map[string]any) (any, error) {
+	p, err := s.loanParams(args)
+	if err != nil {
+		return nil, err
+	}
+	res, err := calculations.DifferentialSchedule(s.cfg, p)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.applyFees(res, args); err != nil {
+		return nil, err
+	}
+	if err := applyCalendar(res, args); err != nil {
+		return nil, err
+	}
+	return scheduleResponse(res, args)
+}
+
+// ratePeriodsArg разбирает необязательный массив периодов ставок
+// {from_month, annual_rate_percent}.
+func ratePeriodsArg(args map[string]any, name string) ([]calculations.RatePeriod, error) {
+	v, ok := args[name]
+	if !ok {
+		return nil, nil
+	}
+	arr, ok := v.([]any)
+	if !ok {
+		return nil, fmt.Errorf("параметр %q должен быть массивом объектов", name)
+	}
+	periods := make([]calculations.RatePeriod, 0, len(arr))
+	for i, item := range arr {
+		m, ok := item.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("элемент %d параметра %q должен быть объектом", i+1, name)
+		}
+		var period calculations.RatePeriod
+		var err error
+		if period.FromMonth, err = intArg(m, "from_month"); err != nil {
+			return nil, err
+		}
+		if period.AnnualRatePercent, err = floatArg(m, "annual_rate_percent"); err != nil {
+			return nil, err
+		}
+		periods = append(periods, period)
+	}
+	return periods, nil
+}
+
+// feePeriodsArg разбирает необязательный массив периодов комиссий
+// {from_month, monthly_fee}.
+func feePeriodsArg(args map[string]any, name string) ([]calculations.FeePeriod, error) {
+	v, ok := args[name]
+	if !ok {
+		return nil, nil
+	}
+	arr, ok := v.([]any)
+	if !ok {
+		return nil, fmt.Errorf("параметр %q должен быть массивом объектов", name)
+	}
+	periods := make([]calculations.FeePeriod, 0, len(arr))
+	for i, item := range arr {
+		m, ok := item.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("элемент %d параметра %q должен быть объектом", i+1, name)
+		}
+		var period calculations.FeePeriod
+		var err error
+		if period.FromMonth, err = intArg(m, "from_month"); err != nil {
+			return nil, err
+		}
+		if period.MonthlyFee, err = floatArg(m, "monthly_fee"); err != nil {
+			return nil, err
+		}
+		periods = append(periods, period)
+	}
+	return periods, nil
+}
+
+// applyFees накладывает на готовый график переменную комиссию
+// за обслуживание, если задан параметр fee_periods.
+func (s *Server) applyFees(res *calculations.CalculationResult, args map[string]any) error {
+	periods, err := feePeriodsArg(args, "fee_periods")
+	if err != nil {
+		return err
+	}
+	return calculations.ApplyFeePeriods(s.cfg, res, periods)
+}
+
+func (s *Server) depositParams(args map[string]any) (calculations.DepositParams, error) {
+	var p calculations.DepositParams
+	var err error
+	if p.InitialAmount, err = floatArg(args, "initial_amount"); err != nil {
+		return p, err
+	}
+	if p.RatePeriods, err = ratePeriodsArg(args, "rate_periods"); err != nil {
+		return p, err
+	}
+	if len(p.RatePeriods) > 0 {
+		// При графике ставок базовая ставка не нужна и игнорируется.
+		p.AnnualRatePercent, err = floatArgDefault(args, "annual_rate_percent", 0)
+	} else {
+		p.AnnualRatePercent, err = floatArg(args, "annual_rate_percent")
+	}
+	if err != nil {
+		return p, err
+	}
+	rateType, err := stringArgDefault(args, "rate_type", "nominal")
+	if err != nil {
+		return p, err
+	}
+	switch rateType {
+	case "nominal":
+	case "effective_annual":
+		if len(p.RatePeriods) > 0 {
+			return p, fmt.Errorf("параметр %q несовместим с графиком ставок rate_periods", "rate_type")
+		}
+		// Рекламная эффективная доходность переводится в номинальную ставку,
+		// с которой работает помесячный график.
+		if p.AnnualRatePercent, err = calculations.NominalFromEffectiveAnnual(p.AnnualRatePercent); err != nil {
+			return p, err
+		}
+	default:
+		return p, fmt.Errorf("параметр %q должен быть %q или %q", "rate_type", "nominal", "effective_annual")
+	}
+	if p.Months, err = intArg(args, "months"); err != nil {
+		return p, err
+	}
+	if p.MonthlyContribution, err = floatArgDefault(args, "monthly_contribution", 0); err != nil {
+		return p, err
+	}
+	if p.Capitalize, err = boolArgDefault(args, "capitalize", true); err != nil {
+		return p, err
+	}
+	if p.ContributionAtBeginning, err = boolArgDefault(args, "contribution_at_beginning", false); err != nil {
+		return p, err
+	}
+	if p.DayCount, err = stringArgDefault(args, "day_count", ""); err != nil {
+		return p, err
+	}
+	if p.MaturityBonus, err = floatArgDefault(args, "maturity_bonus", 0); err != nil {
+		return p, err
+	}
+	if p.ContributionFrequency, err = stringArgDefault(args, "contribution_frequency", ""); err != nil {
+		return p, err
+	}
+	if p.CapitalizationFrequency, err = stringArgDefault(args, "capitalization_frequency", ""); err != nil {
+		return p, err
+	}
+	startStr, err := stringArgDefault(args, "deposit_start_date", "")
+	if err != nil {
+		return p, err
+	}
+	if startStr != "" {
+		if p.StartDate, err = time.Parse("2006-01-02", startStr); err != nil {
+			return p, fmt.Errorf("параметр %q должен быть датой в формате YYYY-MM-DD", "deposit_start_date")
+		}
+	}
+	return p, nil
+}
+
+func (s *Server) variableLoanHandler(ctx context.Context, args map[string]any) (any, error) {
+	var p calculations.VariableLoanParams
+	var err error
+	if p.Principal, err = floatArg(args, "principal"); err != nil {
+		return nil, err
+	}
+	if p.Months, err = intArg(args, "months"); err != nil {
+		return nil, err
+	}
+	if p.RatePeriods, err = ratePeriodsArg(args, "rate_periods"); err != nil {
+		return nil, err
+	}
+	if p.PaymentCap, err = floatArgDefault(args, "payment_cap", 0); err != nil {
+		return nil, err
+	}
+	return calculations.VariableRateSchedule(s.cfg, p)
+}
+
+func (s *Server) depositHandler(ctx context.Context, args map[string]any) (any, error) {
+	p, err := s.depositParams(args)
+	if err != nil {
+		return nil, err
+	}
+	res, err := calculations.DepositSchedule(s.cfg, p)
+	if err != nil {
+		return nil, err
+	}
+	return scheduleResponse(res, args)
+}
+
+func (s *Server) decumulationHandler(ctx context.Context, args map[string]any) (any, error) {
+	initial, err := floatArg(args, "initial_amount")
+	if err != nil {
+		return nil, err
+	}
+	rate, err := floatArg(args, "annual_rate_percent")
+	if err != nil {
+		return nil, err
+	}
+	withdrawal, err := floatArg(args, "monthly_withdrawal")
+	if err != nil {
+		return nil, err
+	}
+	res, err := calculations.DecumulationSchedule(s.cfg, initial, rate, withdrawal)
+	if err != nil {
+		return nil, err
+	}
+	return scheduleResponse(res, args)
+}
+
+func (s *Server) surrenderCurveHandler(ctx context.Context, args map[string]any) (any, error) {
+	p, err := s.depositParams(args)
+	if err != nil {
+		return nil, err
+	}
+	penalty, err := floatArgDefault(args, "penalty_rate_percent", 0)
+	if err != nil {
+		return nil, err
+	}
+	return calculations.SurrenderValueCurve(s.cfg, p, penalty)
+}
+
+func (s *Server) investmentHandler(ctx context.Context, args map[string]any) (any, error) {
+	var p calculations.InvestmentParams
+	var err error
+	if p.InitialAmount, err = floatArg(args, "initial_amount"); err != nil {
+		return nil, err
+	}
+	if p.MonthlyContribution, err = floatArg(args, "monthly_contribution"); err != nil {
+		return nil, err
+	}
+	if p.AnnualReturnPercent, err = floatArg(args, "annual_return_percent"); err != nil {
+		return nil, err
+	}
+	if p.Months, err = intArg(args, "months"); err != nil {
+		return nil, err
+	}
+	if p.ContributionAtBeginning, err = boolArgDefault(args, "contribution_at_beginning", false); err != nil {
+		return nil, err
+	}
+	if p.ContributionEarnsImmediately, err = boolArgDefault(args, "contribution_earns_immediately", false); err != nil {
+		return nil, err
+	}
+	inv, err := calculations.CalculateInvestment(s.cfg, p)
+	if err != nil {
+		return nil, err
+	}
+	format, err := stringArgDefault(args, "format", formatRows)
+	if err != nil {
+		return nil, err
+	}
+	if format == formatColumns {
+		columns := calculations.ScheduleToColumns(inv.CalculationResult)
+		clone := *inv
+		res := *inv.CalculationResult
+		res.Schedule = nil
+		clone.CalculationResult = &res
+		return struct {
+			calculations.InvestmentResult
+			Columns map[string][]float64 `json:"columns"`
+		}{clone, columns}, nil
+	}
+	if format != formatRows {
+		return nil, fmt.Errorf("параметр %q должен быть %q или %q", "format", formatRows, formatColumns)
+	}
+	return inv, nil
+}
+
+func (s *Server) investmentBreakdownHandler(ctx context.Context, args map[string]any) (any, error) {
+	var p calculations.InvestmentParams
+	var err error
+	if p.InitialAmount, err = floatArg(args, "initial_amount"); err != nil {
+		return nil, err
+	}
+	if p.MonthlyContribution, err = floatArg(args, "monthly_contribution"); err != nil {
+		return nil, err
+	}
+	if p.AnnualReturnPercent, err = floatArg(args, "annual_return_percent"); err != nil {
+		return nil, err
+	}
+	if p.Months, err = intArg(args, "months"); err != nil {
+		return nil, err
+	}
+	inv, err := calculations.CalculateInvestment(s.cfg, p)
+	if err != nil {
+		return nil, err
+	}
+	return calculations.GrowthSeries(s.cfg, inv.CalculationResult, p.InitialAmount), nil
+}
+
+// productProfileArg разбирает обязательный объект профиля продукта.
+func productProfileArg(args map[string]any, name string) (calculations.ProductProfile, error) {
+	var p calculations.ProductProfile
+	v, ok := args[name]
+	if !ok {
+		return p, fmt.Errorf("отсутствует обязательный параметр %q", name)
+	}
+	m, ok := v.(map[string]any)
+	if !ok {
+		return p, fmt.Errorf("параметр %q должен быть объектом", name)
+	}
+	var err error
+	if p.MinPrincipal, err = floatArg(m, "min_principal"); err != nil {
+		return p, err
+	}
+	if p.MaxPrincipal, err = floatArg(m, "max_principal"); err != nil {
+		return p, err
+	}
+	if p.MinMonths, err = intArg(m, "min_months"); err != nil {
+		return p, err
+	}
+	if p.MaxMonths, err = intArg(m, "max_months"); err != nil {
+		return p, err
+	}
+	if p.MaxPaymentToIncomePercent, err = floatArg(m, "max_payment_to_income_percent"); err != nil {
+		return p, err
+	}
+	return p, nil
+}
+
+func (s *Server) mimicDifferentialHandler(ctx context.Context, args map[string]any) (any, error) {
+	p, err := s.loanParams(args)
+	if err != nil {
+		return nil, err
+	}
+	return calculations.MimicDifferential(s.cfg, p)
+}
+
+func (s *Server) sipWithBonusesHandler(ctx context.Context, args map[string]any) (any, error) {
+	var p calculations.InvestmentParams
+	var err error
+	if p.InitialAmount, err = floatArg(args, "initial_amount"); err != nil {
+		return nil, err
+	}
+	if p.MonthlyContribution, err = floatArg(args, "monthly_contribution"); err != nil {
+		return nil, err
+	}
+	if p.AnnualReturnPercent, err = floatArg(args, "annual_return_percent"); err != nil {
+		return nil, err
+	}
+	if p.Months, err = intArg(args, "months"); err != nil {
+		return nil, err
+	}
+	bonus, err := floatArg(args, "bonus_amount")
+	if err != nil {
+		return nil, err
+	}
+	return calculations.SIPWithBonuses(s.cfg, p, bonus)
+}
+
+func (s *Server) realReturnDepositHandler(ctx context.Context, args map[string]any) (any, error) {
+	p, err := s.depositParams(args)
+	if err != nil {
+		return nil, err
+	}
+	inflation, err := floatArg(args, "annual_inflation_percent")
+	if err != nil {
+		return nil, err
+	}
+	return calculations.RealReturnDeposit(s.cfg, p, inflation)
+}
+
+func (s *Server) comparisonReportHandler(ctx context.Context, args map[string]any) (any, error) {
+	p, err := s.loanParams(args)
+	if err != nil {
+		return nil, err
+	}
+	return calculations.ComparisonReport(s.cfg, p)
+}
+
+func (s *Server) netWorthProjectionHandler(ctx context.Context, args map[string]any) (any, error) {
+	loan, err := s.loanParams(args)
+	if err != nil {
+		return nil, err
+	}
+	var deposit calculations.DepositParams
+	if deposit.InitialAmount, err = floatArg(args, "deposit_initial_amount"); err != nil {
+		return nil, err
+	}
+	if deposit.AnnualRatePercent, err = floatArg(args, "deposit_rate_percent"); err != nil {
+		return nil, err
+	}
+	if deposit.Months, err = intArgDefault(args, "deposit_months", loan.Months); err != nil {
+		return nil, err
+	}
+	if deposit.MonthlyContribution, err = floatArgDefault(args, "deposit_monthly_contribution", 0); err != nil {
+		return nil, err
+	}
+	if deposit.Capitalize, err = boolArgDefault(args, "capitalize", true); err != nil {
+		return nil, err
+	}
+	return calculations.NetWorthProjection(s.cfg, loan, deposit)
+}
+
+func (s *Server) businessDayAccrualHandler(ctx context.Context, args map[string]any) (any, error) {
+	p, err := s.depositParams(args)
+	if err != nil {
+		return nil, err
+	}
+	dates, err := stringSliceArg(args, "holidays")
+	if err != nil {
+		return nil, err
+	}
+	holidays := make([]time.Time, 0, len(dates))
+	for _, d := range dates {
+		parsed, err := time.Parse("2006-01-02", d)
+		if err != nil {
+			return nil, fmt.Errorf("праздник %q должен быть датой в формате YYYY-MM-DD", d)
+		}
+		holidays = append(holidays, parsed)
+	}
+	return calculations.BusinessDayAccrual(s.cfg, p, holidays)
+}
+
+func (s *Server) simpleRateEquivalentHandler(ctx context.Context, args map[string]any) (any, error) {
+	p, err := s.loanParams(args)
+	if err != nil {
+		return nil, err
+	}
+	res, err := calculations.AnnuitySchedule(s.cfg, p)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{
+		"annual_rate_percent": p.AnnualRatePercent,
+		"total_interest":      res.TotalInterest,
+		"simple_rate_percent": calculations.EquivalentSimpleRate(p.Principal, res.TotalInterest, p.Months),
+	}, nil
+}
+
+func (s *Server) percentOfBalanceHandler(ctx context.Context, args map[string]any) (any, error) {
+	principal, err := floatArg(args, "principal")
+	if err != nil {
+		return nil, err
+	}
+	rate, err := floatArg(args, "annual_rate_percent")
+	if err != nil {
+		return nil, err
+	}
+	paymentPercent, err := floatArg(args, "payment_percent")
+	if err != nil {
+		return nil, err
+	}
+	floor, err := floatArg(args, "payment_floor")
+	if err != nil {
+		return nil, err
+	}
+	return calculations.PercentOfBalancePayoff(s.cfg, principal, rate, paymentPercent, floor)
+}
+
+func (s *Server) depositVsFlexibleHandler(ctx context.Context, args map[string]any) (any, error) {
+	p, err := s.depositParams(args)
+	if err != nil {
+		return nil, err
+	}
+	penaltyRate, err := floatArgDefault(args, "penalty_rate_percent", 0)
+	if err != nil {
+		return nil, err
+	}
+	penaltyMonths, err := intArgDefault(args, "penalty_months", p.Months)
+	if err != nil {
+		return nil, err
+	}
+	flexibleRate, err := floatArg(args, "flexible_rate_percent")
+	if err != nil {
+		return nil, err
+	}
+	return calculations.DepositVsFlexible(s.cfg, p, penaltyRate, penaltyMonths, flexibleRate)
+}
+
+func (s *Server) minIncomeRequiredHandler(ctx context.Context, args map[string]any) (any, error) {
+	p, err := s.loanParams(args)
+	if err != nil {
+		return nil, err
+	}
+	dtiLimit, err := floatArgDefault(args, "dti_limit_percent", calculations.DefaultDTIThresholdPercent)
+	if err != nil {
+		return nil, err
+	}
+	taxesInsurance, err := floatArgDefault(args, "taxes_insurance", 0)
+	if err != nil {
+		return nil, err
+	}
+	return calculations.MinIncomeRequired(s.cfg, p, dtiLimit, taxesInsurance)
+}
+
+func (s *Server) windfallPayoffHandler(ctx context.Context, args map[string]any) (any, error) {
+	p, err := s.loanParams(args)
+	if err != nil {
+		return nil, err
+	}
+	windfalls, err := prepaymentsArg(args, "windfalls")
+	if err != nil {
+		return nil, err
+	}
+	return calculations.WindfallPayoff(s.cfg, p, windfalls)
+}
+
+func (s *Server) fxLoanScenariosHandler(ctx context.Context, args map[string]any) (any, error) {
+	p, err := s.loanParams(args)
+	if err != nil {
+		return nil, err
+	}
+	baseRate, err := floatArg(args, "base_exchange_rate")
+	if err != nil {
+		return nil, err
+	}
+	changes, err := floatSliceArg(args, "scenario_changes_percent")
+	if err != nil {
+		return nil, err
+	}
+	return calculations.FXLoanScenarios(s.cfg, p, baseRate, changes)
+}
+
+func (s *Server) optimalAllocationHandler(ctx context.Context, args map[string]any) (any, error) {
+	p, err := s.loanParams(args)
+	if err != nil {
+		return nil, err
+	}
+	surplus, err := floatArg(args, "monthly_surplus")
+	if err != nil {
+		return nil, err
+	}
+	investRate, err := floatArg(args, "investment_rate_percent")
+	if err != nil {
+		return nil, err
+	}
+	horizon, err := intArg(args, "horizon_months")
+	if err != nil {
+		return nil, err
+	}
+	step, err := intArgDefault(args, "step_percent", 10)
+	if err != nil {
+		return nil, err
+	}
+	return calculations.OptimalAllocation(s.cfg, p, surplus, investRate, horizon, step)
+}
+
+func (s *Server) zeroPercentHandler(ctx context.Context, args map[string]any) (any, error) {
+	installment, err := floatArg(args, "installment_price")
+	if err != nil {
+		return nil, err
+	}
+	cash, err := floatArg(args, "cash_price")
+	if err != nil {
+		return nil, err
+	}
+	months, err := intArg(args, "months")
+	if err != nil {
+		return nil, err
+	}
+	return calculations.ZeroPercentTrueCost(s.cfg, installment, cash, months)
+}
+
+func (s *Server) validateApplicationHandler(ctx context.Context, args map[string]any) (any, error) {
+	p, err := s.loanParams(args)
+	if err != nil {
+		return nil, err
+	}
+	income, err := floatArg(args, "monthly_income")
+	if err != nil {
+		return nil, err
+	}
+	product, err := productProfileArg(args, "product")
+	if err != nil {
+		return nil, err
+	}
+	return calculations.ValidateApplication(s.cfg, p, income, product)
+}
+
+func (s *Server) principalSensitivityHandler(ctx context.Context, args map[string]any) (any, error) {
+	rate, err := floatArg(args, "annual_rate_percent")
+	if err != nil {
+		return nil, err
+	}
+	months, err := intArg(args, "months")
+	if err != nil {
+		return nil, err
+	}
+	minPrincipal, err := floatArg(args, "min_principal")
+	if err != nil {
+		return nil, err
+	}
+	maxPrincipal, err := floatArg(args, "max_principal")
+	if err != nil {
+		return nil, err
+	}
+	step, err := floatArg(args, "step")
+	if err != nil {
+		return nil, err
+	}
+	return calculations.PrincipalSensitivity(s.cfg, rate, months, minPrincipal, maxPrincipal, step)
+}
+
+func (s *Server) coastPointHandler(ctx context.Context, args map[string]any) (any, error) {
+	var p calculations.InvestmentParams
+	var err error
+	if p.InitialAmount, err = floatArg(args, "initial_amount"); err != nil {
+		return nil, err
+	}
+	if p.MonthlyContribution, err = floatArg(args, "monthly_contribution"); err != nil {
+		return nil, err
+	}
+	if p.AnnualReturnPercent, err = floatArg(args, "annual_return_percent"); err != nil {
+		return nil, err
+	}
+	if p.Months, err = intArg(args, "months"); err != nil {
+		return nil, err
+	}
+	target, err := floatArg(args, "target_amount")
+	if err != nil {
+		return nil, err
+	}
+	return calculations.CoastPoint(s.cfg, p, target)
+}
+
+func (s *Server) splitScheduleHandler(ctx context.Context, args map[string]any) (any, error) {
+	p, err := s.loanParams(args)
+	if err != nil {
+		return nil, err
+	}
+	first, err := floatArg(args, "first_share_percent")
+	if err != nil {
+		return nil, err
+	}
+	second, err := floatArg(args, "second_share_percent")
+	if err != nil {
+		return nil, err
+	}
+	return calculations.SplitSchedule(s.cfg, p, first, second)
+}
+
+func (s *Server) requiredNestEggHandler(ctx context.Context, args map[string]any) (any, error) {
+	withdrawal, err := floatArg(args, "monthly_withdrawal")
+	if err != nil {
+		return nil, err
+	}
+	rate, err := floatArg(args, "annual_rate_percent")
+	if err != nil {
+		return nil, err
+	}
+	drawdownMonths, err := intArgDefault(args, "drawdown_months", 0)
+	if err != nil {
+		return nil, err
+	}
+	target, err := calculations.RequiredNestEgg(s.cfg, withdrawal, rate, drawdownMonths)
+	if err != nil {
+		return nil, err
+	}
+	out := map[string]any{
+		"monthly_withdrawal": withdrawal,
+		"required_nest_egg":  target,
+	}
+	if drawdownMonths == 0 {
+		out["perpetuity"] = true
+	} else {
+		out["drawdown_months"] = drawdownMonths
+	}
+	return out, nil
+}
+
+func (s *Server) dcaSequenceHandler(ctx context.Context, args map[string]any) (any, error) {
+	initial, err := floatArg(args, "initial_amount")
+	if err != nil {
+		return nil, err
+	}
+	contribution, err := floatArgDefault(args, "monthly_contribution", 0)
+	if err != nil {
+		return nil, err
+	}
+	returns, err := floatSliceArg(args, "monthly_returns_percent")
+	if err != nil {
+		return nil, err
+	}
+	months, err := intArg(args, "months")
+	if err != nil {
+		return nil, err
+	}
+	return calculations.DCASequence(s.cfg, initial, contribution, returns, months)
+}
+
+func (s *Server) taxEquivalentYieldHandler(ctx context.Context, args map[string]any) (any, error) {
+	nominal, err := floatArg(args, "nominal_rate_percent")
+	if err != nil {
+		return nil, err
+	}
+	if err := calculations.CheckRate(s.cfg, nominal); err != nil {
+		return nil, err
+	}
+	taxRate, err := floatArg(args, "tax_rate_percent")
+	if err != nil {
+		return nil, err
+	}
+	if taxRate < 0 || taxRate >= 100 {
+		return nil, fmt.Errorf("параметр %q должен быть в диапазоне от 0 до 100 (не включая)", "tax_rate_percent")
+	}
+	return map[string]any{
+		"nominal_rate_percent":        nominal,
+		"tax_rate_percent":            taxRate,
+		"tax_equivalent_rate_percent": calculations.TaxEquivalentYield(nominal, taxRate),
+	}, nil
+}
+
+func (s *Server) portfolioSummaryHandler(ctx context.Context, args map[string]any) (any, error) {
+	v, ok := args["investments"]
+	if !ok {
+		return nil, fmt.Errorf("отсутствует обязательный параметр %q", "investments")
+	}
+	arr, ok := v.([]any)
+	if !ok {
+		return nil, fmt.Errorf("параметр %q должен быть массивом объектов", "investments")
+	}
+	items := make([]calculations.InvestmentParams, 0, len(arr))
+	for i, raw := range arr {
+		m, ok := raw.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("элемент %d параметра %q должен быть объектом", i+1, "investments")
+		}
+		var p calculations.InvestmentParams
+		var err error
+		if p.InitialAmount, err = floatArgDefault(m, "initial_amount", 0); err != nil {
+			return nil, err
+		}
+		if p.MonthlyContribution, err = floatArgDefault(m, "monthly_contribution", 0); err != nil {
+			return nil, err
+		}
+		if p.AnnualReturnPercent, err = floatArg(m, "annual_return_percent"); err != nil {
+			return nil, err
+		}
+		if p.Months, err = intArg(m, "months"); err != nil {
+			return nil, err
+		}
+		items = append(items, p)
+	}
+	return calculations.PortfolioSummary(s.cfg, items)
+}
+
+// CompareLoansHandler сравнивает аннуитетную и дифференцированную схемы.
+// Необязательный параметр preference добавляет адресную рекомендацию
+// под заявленное предпочтение заемщика.
+func (s *Server) CompareLoansHandler(ctx context.Context, args map[string]any) (any, error) {
+	p, err := s.loanParams(args)
+	if err != nil {
+		return nil, err
+	}
+	comparison, err := calculations.CompareLoans(s.cfg, p)
+	if err != nil {
+		return nil, err
+	}
+	preference, err := stringArgDefault(args, "preference", "")
+	if err != nil {
+		return nil, err
+	}
+	if preference != "" {
+		recommended, rationale, err := calculations.RecommendScheme(comparison, preference)
+		if err != nil {
+			return nil, err
+		}
+		comparison["preference"] = preference
+		comparison["recommended_type"] = recommended
+		comparison["recommendation_rationale"] = rationale
+	}
+	return comparison, nil
+}
+
+// objectArg извлекает обязательный вложенный объект параметров.
+func objectArg(args map[string]any, name string) (map[string]any, error) {
+	v, ok := args[name]
+	if !ok {
+		return nil, fmt.Errorf("отсутствует обязательный параметр %q", name)
+	}
+	m, ok := v.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("параметр %q должен быть объектом", name)
+	}
+	return m, nil
+}
+
+// scheduleDiffHandler строит два графика одного вида и возвращает
+// помесячные и сводные дельты второго относительно первого.
+func (s *Server) scheduleDiffHandler(ctx context.Context, args map[string]any) (any, error) {
+	kind, err := stringArgDefault(args, "kind", calculations.KindAnnuity)
+	if err != nil {
+		return nil, err
+	}
+	baseArgs, err := objectArg(args, "base")
+	if err != nil {
+		return nil, err
+	}
+	otherArgs, err := objectArg(args, "other")
+	if err != nil {
+		return nil, err
+	}
+	build := func(params map[string]any) (*calculations.CalculationResult, error) {
+		switch kind {
+		case calculations.KindAnnuity:
+			p, err := s.loanParams(params)
+			if err != nil {
+				return nil, err
+			}
+			return calculations.AnnuitySchedule(s.cfg, p)
+		case calculations.KindDifferential:
+			p, err := s.loanParams(params)
+			if err != nil {
+				return nil, err
+			}
+			return calculations.DifferentialSchedule(s.cfg, p)
+		case calculations.KindDeposit:
+			p, err := s.depositParams(params)
+			if err != nil {
+				return nil, err
+			}
+			return calculations.DepositSchedule(s.cfg, p)
+		}
+		return nil, fmt.Errorf("параметр %q должен быть %q, %q или %q",
+			"kind", calculations.KindAnnuity, calculations.KindDifferential, calculations.KindDeposit)
+	}
+	base, err := build(baseArgs)
+	if err != nil {
+		return nil, err
+	}
+	other, err := build(otherArgs)
+	if err != nil {
+		return nil, err
+	}
+	return calculations.DiffSchedules(s.cfg, base, other)
+}
+
+// dispatcherName — имя универсального инструмента-маршрутизатора.
+const dispatcherName = "calculate"
+
+// calculateHandler маршрутизирует вызов к инструменту по дискриминатору
+// type: остальные аргументы передаются без изменений, результат совпадает
+// с прямым вызовом и помечается типом.
+func (s *Server) calculateHandler(ctx context.Context, args map[string]any) (any, error) {
+	typ, err := stringArg(args, "type")
+	if err != nil {
+		return nil, err
+	}
+	tool, ok := s.byName[typ]
+	if !ok || typ == dispatcherName {
+		return nil, fmt.Errorf("неизвестный тип %q; доступны: %s", typ, strings.Join(s.dispatchableNames(), ", "))
+	}
+	delete(args, "type")
+	structured, err := tool.Handler(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{"type": typ, "result": structured}, nil
+}
+
+// dispatchableNames возвращает имена инструментов, доступных через calculate.
+func (s *Server) dispatchableNames() []string {
+	names := make([]string, 0, len(s.tools))
+	for _, t := range s.tools {
+		if t.Name == dispatcherName {
+			continue
+		}
+		names = append(names, t.Name)
+	}
+	return names
+}
+
+// offersArg разбирает массив предложений для compare_offers.
+func offersArg(args map[string]any, name string) ([]calculations.LoanOffer, error) {
+	v, ok := args[name]
+	if !ok {
+		return nil, fmt.Errorf("отсутствует обязательный параметр %q", name)
+	}
+	arr, ok := v.([]any)
+	if !ok {
+		return nil, fmt.Errorf("параметр %q должен быть массивом объектов", name)
+	}
+	offers := make([]calculations.LoanOffer, 0, len(arr))
+	for i, item := range arr {
+		m, ok := item.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("элемент %d параметра %q должен быть объектом", i+1, name)
+		}
+		var offer calculations.LoanOffer
+		var err error
+		if offer.Name, err = stringArgDefault(m, "name", ""); err != nil {
+			return nil, err
+		}
+		if offer.AnnualRatePercent, err = floatArg(m, "annual_rate_percent"); err != nil {
+			return nil, err
+		}
+		if offer.Months, err = intArg(m, "months"); err != nil {
+			return nil, err
+		}
+		if offer.UpfrontFee, err = floatArgDefault(m, "upfront_fee", 0); err != nil {
+			return nil, err
+		}
+		if offer.FinancedFee, err = floatArgDefault(m, "financed_fee", 0); err != nil {
+			return nil, err
+		}
+		offers = append(offers, offer)
+	}
+	return offers, nil
+}
+
+func (s *Server) compareOffersHandler(ctx context.Context, args map[string]any) (any, error) {
+	principal, err := floatArg(args, "principal")
+	if err != nil {
+		return nil, err
+	}
+	offers, err := offersArg(args, "offers")
+	if err != nil {
+		return nil, err
+	}
+	return calculations.CompareOffers(s.cfg, principal, offers)
+}
+
+func (s *Server) remainingBalanceHandler(ctx context.Context, args map[string]any) (any, error) {
+	p, err := s.loanParams(args)
+	if err != nil {
+		return nil, err
+	}
+	paidMonths, err := intArg(args, "paid_months")
+	if err != nil {
+		return nil, err
+	}
+	balance, err := calculations.RemainingBalance(s.cfg, p, paidMonths)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{
+		"paid_months":         paidMonths,
+		"remaining_principal": balance,
+	}, nil
+}
+
+func (s *Server) loanConstantHandler(ctx context.Context, args map[string]any) (any, error) {
+	p, err := s.loanParams(args)
+	if err != nil {
+		return nil, err
+	}
+	// Расчет графика здесь нужен только для валидации параметров.
+	res, err := calculations.AnnuitySchedule(s.cfg, p)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{
+		"monthly_payment":       res.MonthlyPayment,
+		"annual_debt_service":   calculations.Round2(res.MonthlyPayment * 12),
+		"loan_constant_percent": calculations.Round(calculations.LoanConstant(p.Principal, p.AnnualRatePercent, p.Months), 4),
+	}, nil
+}
+
+func (s *Server) interestPaidByHandler(ctx context.Context, args map[string]any) (any, error) {
+	p, err := s.loanParams(args)
+	if err != nil {
+		return nil, err
+	}
+	month, err := intArg(args, "month")
+	if err != nil {
+		return nil, err
+	}
+	res, err := calculations.AnnuitySchedule(s.cfg, p)
+	if err != nil {
+		return nil, err
+	}
+	total, err := calculations.CumulativeInterestBy(res, month)
+	if err != nil {
+		return nil, err
+	}
+	out := map[string]any{
+		"month":          month,
+		"interest_paid":  total,
+		"total_interest": res.TotalInterest,
+	}
+	if res.TotalInterest > 0 {
+		out["share_of_total"] = calculations.RoundPercent(s.cfg, total/res.TotalInterest*100)
+	}
+	return out, nil
+}
+
+func (s *Server) paymentShockHandler(ctx context.Context, args map[string]any) (any, error) {
+	p, err := s.loanParams(args)
+	if err != nil {
+		return nil, err
+	}
+	paidMonths, err := intArg(args, "paid_months")
+	if err != nil {
+		return nil, err
+	}
+	increase, err := floatArg(args, "rate_increase_percent")
+	if err != nil {
+		return nil, err
+	}
+	return calculations.PaymentShock(s.cfg, p, paidMonths, increase)
+}
+
+func (s *Server) loanFairValueHandler(ctx context.Context, args map[string]any) (any, error) {
+	p, err := s.loanParams(args)
+	if err != nil {
+		return nil, err
+	}
+	fromMonth, err := intArg(args, "from_month")
+	if err != nil {
+		return nil, err
+	}
+	discount, err := floatArg(args, "discount_annual_percent")
+	if err != nil {
+		return nil, err
+	}
+	res, err := calculations.AnnuitySchedule(s.cfg, p)
+	if err != nil {
+		return nil, err
+	}
+	value, err := calculations.LoanFairValue(res, fromMonth, discount)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{
+		"from_month":              fromMonth,
+		"discount_annual_percent": discount,
+		"fair_value":              value,
+	}, nil
+}
+
+func (s *Server) refinanceHandler(ctx context.Context, args map[string]any) (any, error) {
+	p, err := s.loanParams(args)
+	if err != nil {
+		return nil, err
+	}
+	paidMonths, err := intArg(args, "paid_months")
+	if err != nil {
+		return nil, err
+	}
+	newRate, err := floatArg(args, "new_rate_percent")
+	if err != nil {
+		return nil, err
+	}
+	newMonths, err := intArg(args, "new_months")
+	if err != nil {
+		return nil, err
+	}
+	fee, err := floatArgDefault(args, "fee", 0)
+	if err != nil {
+		return nil, err
+	}
+	return calculations.CompareRefinance(s.cfg, p, paidMonths, newRate, newMonths, fee)
+}
+
+func (s *Server) termComparisonHandler(ctx context.Context, args map[string]any) (any, error) {
+	principal, err := floatArg(args, "principal")
+	if err != nil {
+		return nil, err
+	}
+	rate, err := floatArg(args, "annual_rate_percent")
+	if err != nil {
+		return nil, err
+	}
+	terms, err := intSliceArg(args, "terms")
+	if err != nil {
+		return nil, err
+	}
+	options, err := calculations.TermComparison(s.cfg, principal, rate, terms)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{"options": options}, nil
+}
+
+func (s *Server) paymentDateSavingsHandler(ctx context.Context, args map[string]any) (any, error) {
+	p, err := s.loanParams(args)
+	if err != nil {
+		return nil, err
+	}
+	startStr, err := stringArg(args, "start_date")
+	if err != nil {
+		return nil, err
+	}
+	start, err := time.Parse("2006-01-02", startStr)
+	if err != nil {
+		return nil, fmt.Errorf("параметр %q должен быть датой в формате YYYY-MM-DD", "start_date")
+	}
+	baselineDay, err := intArg(args, "baseline_day")
+	if err != nil {
+		return nil, err
+	}
+	shiftedDay, err := intArg(args, "shifted_day")
+	if err != nil {
+		return nil, err
+	}
+	return calculations.PaymentDateSavings(s.cfg, p, start, baselineDay, shiftedDay)
+}
+
+func (s *Server) realPaymentBurdenHandler(ctx context.Context, args map[string]any) (any, error) {
+	p, err := s.loanParams(args)
+	if err != nil {
+		return nil, err
+	}
+	inflation, err := floatArg(args, "annual_inflation_percent")
+	if err != nil {
+		return nil, err
+	}
+	return calculations.RealPaymentBurden(s.cfg, p, inflation)
+}
+
+func (s *Server) roundTermHandler(ctx context.Context, args map[string]any) (any, error) {
+	p, err := s.loanParams(args)
+	if err != nil {
+		return nil, err
+	}
+	return calculations.RoundTermToYears(s.cfg, p)
+}
+
+func (s *Server) deferralCostHandler(ctx context.Context, args map[string]any) (any, error) {
+	p, err := s.loanParams(args)
+	if err != nil {
+		return nil, err
+	}
+	deferralMonths, err := intArg(args, "deferral_months")
+	if err != nil {
+		return nil, err
+	}
+	fee, err := floatArg(args, "commitment_fee_percent")
+	if err != nil {
+		return nil, err
+	}
+	return calculations.DeferralCost(s.cfg, p, deferralMonths, fee)
+}
+
+func (s *Server) graduatedPaymentHandler(ctx context.Context, args map[string]any) (any, error) {
+	p, err := s.loanParams(args)
+	if err != nil {
+		return nil, err
+	}
+	growth, err := floatArg(args, "payment_growth_percent")
+	if err != nil {
+		return nil, err
+	}
+	return calculations.GraduatedPaymentSchedule(s.cfg, p, growth)
+}
+
+func (s *Server) capitalizationComparisonHandler(ctx context.Context, args map[string]any) (any, error) {
+	p, err := s.depositParams(args)
+	if err != nil {
+		return nil, err
+	}
+	return calculations.CapitalizationComparison(s.cfg, p)
+}
+
+func (s *Server) rule78Handler(ctx context.Context, args map[string]any) (any, error) {
+	totalInterest, err := floatArg(args, "total_interest")
+	if err != nil {
+		return nil, err
+	}
+	if totalInterest < 0 {
+		return nil, fmt.Errorf("параметр %q не должен быть отрицательным", "total_interest")
+	}
+	totalMonths, err := intArg(args, "total_months")
+	if err != nil {
+		return nil, err
+	}
+	if err := calculations.CheckMonths(s.cfg, totalMonths); err != nil {
+		return nil, err
+	}
+	monthsRemaining, err := intArg(args, "months_remaining")
+	if err != nil {
+		return nil, err
+	}
+	if monthsRemaining < 0 || monthsRemaining > totalMonths {
+		return nil, fmt.Errorf("параметр %q должен быть в диапазоне от 0 до %d", "months_remaining", totalMonths)
+	}
+	rebate := calculations.Rule78Rebate(totalInterest, totalMonths, monthsRemaining)
+	return map[string]any{
+		"rebate":          rebate,
+		"earned_interest": calculations.Round2(totalInterest - rebate),
+	}, nil
+}
+
+func (s *Server) rentVsBuyParams(args map[string]any) (calculations.RentVsBuyParams, error) {
+	var p calculations.RentVsBuyParams
+	var err error
+	if p.HomePrice, err = floatArg(args, "home_price"); err != nil {
+		return p, err
+	}
+	if p.DownPayment, err = floatArgDefault(args, "down_payment", 0); err != nil {
+		return p, err
+	}
+	if p.MortgageRatePercent, err = floatArg(args, "mortgage_rate_percent"); err != nil {
+		return p, err
+	}
+	if p.MortgageMonths, err = intArg(args, "mortgage_months"); err != nil {
+		return p, err
+	}
+	if p.MonthlyOwnerCosts, err = floatArgDefault(args, "monthly_owner_costs", 0); err != nil {
+		return p, err
+	}
+	if p.MonthlyRent, err = floatArg(args, "monthly_rent"); err != nil {
+		return p, err
+	}
+	if p.RentEscalationPercent, err = floatArgDefault(args, "rent_escalation_percent", 0); err != nil {
+		return p, err
+	}
+	if p.InvestmentRatePercent, err = floatArg(args, "investment_rate_percent"); err != nil {
+		return p, err
+	}
+	if p.HomeAppreciationPercent, err = floatArgDefault(args, "home_appreciation_percent", 0); err != nil {
+		return p, err
+	}
+	if p.HorizonMonths, err = intArg(args, "horizon_months"); err != nil {
+		return p, err
+	}
+	return p, nil
+}
+
+func (s *Server) rentVsBuyHandler(ctx context.Context, args map[string]any) (any, error) {
+	p, err := s.rentVsBuyParams(args)
+	if err != nil {
+		return nil, err
+	}
+	return calculations.RentVsBuy(s.cfg, p)
+}
+
+func (s *Server) breakEvenAppreciationHandler(ctx context.Context, args map[string]any) (any, error) {
+	p, err := s.rentVsBuyParams(args)
+	if err != nil {
+		return nil, err
+	}
+	rate, err := calculations.BreakEvenAppreciation(s.cfg, p)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{
+		"break_even_appreciation_percent": rate,
+		"horizon_months":                  p.HorizonMonths,
+	}, nil
+}
+
+func (s *Server) lumpSumForGoalHandler(ctx context.Context, args map[string]any) (any, error) {
+	target, err := floatArg(args, "target_amount")
+	if err != nil {
+		return nil, err
+	}
+	rate, err := floatArg(args, "annual_rate_percent")
+	if err != nil {
+		return nil, err
+	}
+	months, err := intArg(args, "months")
+	if err != nil {
+		return nil, err
+	}
+	contribution, err := floatArgDefault(args, "monthly_contribution", 0)
+	if err != nil {
+		return nil, err
+	}
+	lump, err := calculations.LumpSumForGoal(s.cfg, target, rate, months, contribution)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{
+		"target_amount":    target,
+		"months":           months,
+		"required_deposit": lump,
+	}, nil
+}
+
+func (s *Server) interestBudgetHandler(ctx context.Context, args map[string]any) (any, error) {
+	principal, err := floatArg(args, "principal")
+	if err != nil {
+		return nil, err
+	}
+	budget, err := floatArg(args, "interest_budget")
+	if err != nil {
+		return nil, err
+	}
+	_, hasRate := args["annual_rate_percent"]
+	_, hasMonths := args["months"]
+	if hasRate == hasMonths {
+		return nil, fmt.Errorf("нужен ровно один из параметров %q и %q: второй подбирается под бюджет", "annual_rate_percent", "months")
+	}
+	if hasRate {
+		rate, err := floatArg(args, "annual_rate_percent")
+		if err != nil {
+			return nil, err
+		}
+		months, err := calculations.TermForInterestBudget(s.cfg, principal, rate, budget)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{
+			"solved_for":      "months",
+			"months":          months,
+			"monthly_payment": calculations.Round2(calculations.AnnuityPayment(principal, rate, months)),
+		}, nil
+	}
+	months, err := intArg(args, "months")
+	if err != nil {
+		return nil, err
+	}
+	rate, err := calculations.RateForInterestBudget(s.cfg, principal, months, budget)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{
+		"solved_for":          "annual_rate_percent",
+		"annual_rate_percent": rate,
+		"monthly_payment":     calculations.Round2(calculations.AnnuityPayment(principal, rate, months)),
+	}, nil
+}
+
+func (s *Server) optimalRefinanceHandler(ctx context.Context, args map[string]any) (any, error) {
+	p, err := s.loanParams(args)
+	if err != nil {
+		return nil, err
+	}
+	newRate, err := floatArg(args, "new_rate_percent")
+	if err != nil {
+		return nil, err
+	}
+	fee, err := floatArgDefault(args, "fee", 0)
+	if err != nil {
+		return nil, err
+	}
+	return calculations.OptimalRefinanceMonth(s.cfg, p, newRate, fee)
+}
+
+func (s *Server) offsetMortgageHandler(ctx context.Context, args map[string]any) (any, error) {
+	p, err := s.loanParams(args)
+	if err != nil {
+		return nil, err
+	}
+	offsetBalance, err := floatArg(args, "offset_balance")
+	if err != nil {
+		return nil, err
+	}
+	monthlySavings, err := floatArgDefault(args, "monthly_savings", 0)
+	if err != nil {
+		return nil, err
+	}
+	return calculations.OffsetMortgage(s.cfg, p, offsetBalance, monthlySavings)
+}
+
+func (s *Server) loanDurationHandler(ctx context.Context, args map[string]any) (any, error) {
+	p, err := s.loanParams(args)
+	if err != nil {
+		return nil, err
+	}
+	res, err := calculations.AnnuitySchedule(s.cfg, p)
+	if err != nil {
+		return nil, err
+	}
+	months := calculations.LoanDuration(res, p.AnnualRatePercent)
+	return map[string]any{
+		"duration_months": calculations.Round2(months),
+		"duration_years":  calculations.Round2(months / 12),
+	}, nil
+}
+
+func (s *Server) requiredRateHandler(ctx context.Context, args map[string]any) (any, error) {
+	initial, err := floatArg(args, "initial_amount")
+	if err != nil {
+		return nil, err
+	}
+	contribution, err := floatArg(args, "monthly_contribution")
+	if err != nil {
+		return nil, err
+	}
+	target, err := floatArg(args, "target_amount")
+	if err != nil {
+		return nil, err
+	}
+	months, err := intArg(args, "months")
+	if err != nil {
+		return nil, err
+	}
+	atBeginning, err := boolArgDefault(args, "contribution_at_beginning", false)
+	if err != nil {
+		return nil, err
+	}
+	rate, err := calculations.RequiredRate(s.cfg, initial, contribution, target, months, atBeginning)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{
+		"required_rate_percent": rate,
+		"target_amount":         target,
+		"months":                months,
+	}, nil
+}
+
+// prepaymentsArg разбирает массив досрочных погашений {month, amount}.
+func prepaymentsArg(args map[string]any, name string) ([]calculations.Prepayment, error) {
+	v, ok := args[name]
+	if !ok {
+		return nil, nil
+	}
+	arr, ok := v.([]any)
+	if !ok {
+		return nil, fmt.Errorf("параметр %q должен быть массивом объектов", name)
+	}
+	prepayments := make([]calculations.Prepayment, 0, len(arr))
+	for i, item := range arr {
+		m, ok := item.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("элемент %d параметра %q должен быть объектом", i+1, name)
+		}
+		var pp calculations.Prepayment
+		var err error
+		if pp.Month, err = intArg(m, "month"); err != nil {
+			return nil, err
+		}
+		if pp.Amount, err = floatArg(m, "amount"); err != nil {
+			return nil, err
+		}
+		prepayments = append(prepayments, pp)
+	}
+	return prepayments, nil
+}
+
+func (s *Server) realizedRateHandler(ctx context.Context, args map[string]any) (any, error) {
+	p, err := s.loanParams(args)
+	if err != nil {
+		return nil, err
+	}
+	prepayments, err := prepaymentsArg(args, "prepayments")
+	if err != nil {
+		return nil, err
+	}
+	return calculations.RealizedRate(s.cfg, p, prepayments)
+}
+
+func (s *Server) incomeReplacementHandler(ctx context.Context, args map[string]any) (any, error) {
+	initial, err := floatArgDefault(args, "initial_amount", 0)
+	if err != nil {
+		return nil, err
+	}
+	rate, err := floatArg(args, "annual_rate_percent")
+	if err != nil {
+		return nil, err
+	}
+	accumulationMonths, err := intArg(args, "accumulation_months")
+	if err != nil {
+		return nil, err
+	}
+	withdrawal, err := floatArg(args, "monthly_withdrawal")
+	if err != nil {
+		return nil, err
+	}
+	drawdownMonths, err := intArgDefault(args, "drawdown_months", 0)
+	if err != nil {
+		return nil, err
+	}
+	return calculations.IncomeReplacement(s.cfg, initial, rate, accumulationMonths, withdrawal, drawdownMonths)
+}
+
+func (s *Server) ioThenAmortizingHandler(ctx context.Context, args map[string]any) (any, error) {
+	principal, err := floatArg(args, "principal")
+	if err != nil {
+		return nil, err
+	}
+	rate, err := floatArg(args, "annual_rate_percent")
+	if err != nil {
+		return nil, err
+	}
+	ioMonths, err := intArg(args, "interest_only_months")
+	if err != nil {
+		return nil, err
+	}
+	amortizingMonths, err := intArg(args, "amortizing_months")
+	if err != nil {
+		return nil, err
+	}
+	return calculations.IOThenAmortizing(s.cfg, principal, rate, ioMonths, amortizingMonths)
+}
+
+func (s *Server) prepaymentTimingHandler(ctx context.Context, args map[string]any) (any, error) {
+	p, err := s.loanParams(args)
+	if err != nil {
+		return nil, err
+	}
+	extra, err := floatArg(args, "extra_amount")
+	if err != nil {
+		return nil, err
+	}
+	months, err := intSliceArg(args, "candidate_months")
+	if err != nil {
+		return nil, err
+	}
+	options, err := calculations.PrepaymentTiming(s.cfg, p, extra, months)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{"options": options}, nil
+}
+
+func (s *Server) graceLoanHandler(ctx context.Context, args map[string]any) (any, error) {
+	p, err := s.loanParams(args)
+	if err != nil {
+		return nil, err
+	}
+	graceMonths, err := intArg(args, "grace_months")
+	if err != nil {
+		return nil, err
+	}
+	mode, err := stringArgDefault(args, "grace_mode", calculations.GraceInterestOnly)
+	if err != nil {
+		return nil, err
+	}
+	return calculations.GraceSchedule(s.cfg, p, graceMonths, mode)
+}
+
+func (s *Server) paymentHolidayHandler(ctx context.Context, args map[string]any) (any, error) {
+	p, err := s.loanParams(args)
+	if err != nil {
+		return nil, err
+	}
+	holidayMonth, err := intArg(args, "holiday_month")
+	if err != nil {
+		return nil, err
+	}
+	mode, err := stringArgDefault(args, "mode", calculations.HolidayKeepTerm)
+	if err != nil {
+		return nil, err
+	}
+	return calculations.PaymentHoliday(s.cfg, p, holidayMonth, mode)
+}
+
+func (s *Server) subsidizedRateHandler(ctx context.Context, args map[string]any) (any, error) {
+	p, err := s.loanParams(args)
+	if err != nil {
+		return nil, err
+	}
+	cashback, err := floatArgDefault(args, "cashback", 0)
+	if err != nil {
+		return nil, err
+	}
+	subsidy, err := floatArgDefault(args, "monthly_subsidy", 0)
+	if err != nil {
+		return nil, err
+	}
+	return calculations.SubsidizedRate(s.cfg, p, cashback, subsidy)
+}
+
+func (s *Server) downPaymentPlanHandler(ctx context.Context, args map[string]any) (any, error) {
+	target, err := floatArg(args, "target_amount")
+	if err != nil {
+		return nil, err
+	}
+	savings, err := floatArgDefault(args, "current_savings", 0)
+	if err != nil {
+		return nil, err
+	}
+	rate, err := floatArg(args, "annual_rate_percent")
+	if err != nil {
+		return nil, err
+	}
+	months, err := intArg(args, "months")
+	if err != nil {
+		return nil, err
+	}
+	ltv, err := floatArgDefault(args, "target_ltv_percent", 80)
+	if err != nil {
+		return nil, err
+	}
+	return calculations.PlanDownPayment(s.cfg, target, savings, rate, months, ltv)
+}
+
+func (s *Server) maxHomePriceHandler(ctx context.Context, args map[string]any) (any, error) {
+	income, err := floatArg(args, "monthly_income")
+	if err != nil {
+		return nil, err
+	}
+	dtiLimit, err := floatArgDefault(args, "dti_limit_percent", calculations.DefaultDTIThresholdPercent)
+	if err != nil {
+		return nil, err
+	}
+	rate, err := floatArg(args, "annual_rate_percent")
+	if err != nil {
+		return nil, err
+	}
+	months, err := intArg(args, "months")
+	if err != nil {
+		return nil, err
+	}
+	downPayment, err := floatArgDefault(args, "down_payment", 0)
+	if err != nil {
+		return nil, err
+	}
+	taxesInsurance, err := floatArgDefault(args, "taxes_insurance", 0)
+	if err != nil {
+		return nil, err
+	}
+	return calculations.MaxHomePrice(s.cfg, income, dtiLimit, rate, months, downPayment, taxesInsurance)
+}
+
+func (s *Server) blendedAPRHandler(ctx context.Context, args map[string]any) (any, error) {
+	p, err := s.loanParams(args)
+	if err != nil {
+		return nil, err
+	}
+	limit, err := floatArg(args, "revolving_limit")
+	if err != nil {
+		return nil, err
+	}
+	revolvingRate, err := floatArg(args, "revolving_rate_percent")
+	if err != nil {
+		return nil, err
+	}
+	utilization, err := floatArgDefault(args, "utilization_percent", 100)
+	if err != nil {
+		return nil, err
+	}
+	return calculations.BlendedAPR(s.cfg, p, limit, revolvingRate, utilization)
+}
+
+func (s *Server) rateBuydownHandler(ctx context.Context, args map[string]any) (any, error) {
+	p, err := s.loanParams(args)
+	if err != nil {
+		return nil, err
+	}
+	pointsCost, err := floatArg(args, "points_cost")
+	if err != nil {
+		return nil, err
+	}
+	reducedRate, err := floatArg(args, "reduced_rate_percent")
+	if err != nil {
+		return nil, err
+	}
+	return calculations.RateBuydown(s.cfg, p, pointsCost, reducedRate)
+}
+
+// datedFlowsArg разбирает необязательный массив движений {month, amount}.
+func datedFlowsArg(args map[string]any, name string) ([]calculations.DatedFlow, error) {
+	v, ok := args[name]
+	if !ok {
+		return nil, nil
+	}
+	arr, ok := v.([]any)
+	if !ok {
+		return nil, fmt.Errorf("параметр %q должен быть массивом объектов", name)
+	}
+	flows := make([]calculations.DatedFlow, 0, len(arr))
+	for i, item := range arr {
+		m, ok := item.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("элемент %d параметра %q должен быть объектом", i+1, name)
+		}
+		var flow calculations.DatedFlow
+		var err error
+		if flow.Month, err = intArg(m, "month"); err != nil {
+			return nil, err
+		}
+		if flow.Amount, err = floatArg(m, "amount"); err != nil {
+			return nil, err
+		}
+		flows = append(flows, flow)
+	}
+	return flows, nil
+}
+
+func (s *Server) investmentIRRHandler(ctx context.Context, args map[string]any) (any, error) {
+	initial, err := floatArg(args, "initial_amount")
+	if err != nil {
+		return nil, err
+	}
+	flows, err := datedFlowsArg(args, "flows")
+	if err != nil {
+		return nil, err
+	}
+	finalBalance, err := floatArg(args, "final_balance")
+	if err != nil {
+		return nil, err
+	}
+	months, err := intArg(args, "months")
+	if err != nil {
+		return nil, err
+	}
+	return calculations.MoneyWeightedReturn(s.cfg, initial, flows, finalBalance, months)
+}
+
+func (s *Server) ltvMilestoneHandler(ctx context.Context, args map[string]any) (any, error) {
+	p, err := s.loanParams(args)
+	if err != nil {
+		return nil, err
+	}
+	assetPrice, err := floatArg(args, "asset_price")
+	if err != nil {
+		return nil, err
+	}
+	appreciation, err := floatArgDefault(args, "annual_appreciation_percent", 0)
+	if err != nil {
+		return nil, err
+	}
+	target, err := floatArg(args, "target_ltv_percent")
+	if err != nil {
+		return nil, err
+	}
+	return calculations.FindLTVMilestone(s.cfg, p, assetPrice, appreciation, target)
+}
+
+func (s *Server) dtiHandler(ctx context.Context, args map[string]any) (any, error) {
+	income, err := floatArg(args, "monthly_income")
+	if err != nil {
+		return nil, err
+	}
+	payments, err := floatSliceArg(args, "debt_payments")
+	if err != nil {
+		return nil, err
+	}
+	threshold, err := floatArgDefault(args, "threshold_percent", calculations.DefaultDTIThresholdPercent)
+	if err != nil {
+		return nil, err
+	}
+	return calculations.DebtToIncome(s.cfg, income, payments, threshold)
+}
+
+func (s *Server) rateConvertHandler(ctx context.Context, args map[string]any) (any, error) {
+	rate, err := floatArg(args, "rate")
+	if err != nil {
+		return nil, err
+	}
+	from, err := stringArg(args, "from_frequency")
+	if err != nil {
+		return nil, err
+	}
+	to, err := stringArg(args, "to_frequency")
+	if err != nil {
+		return nil, err
+	}
+	converted, err := calculations.ConvertRate(rate, from, to)
+	if err != nil {
+		return nil, err
+	}
+	eay, err := calculations.EffectiveAnnualYield(rate, from)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{
+		"rate":                     rate,
+		"from_frequency":           from,
+		"to_frequency":             to,
+		"converted_rate":           converted,
+		"effective_annual_percent": eay * 100,
+	}, nil
+}
@@ -0,0 +1,64 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Metrics — счетчики вызовов инструментов для эндпоинта /metrics
+// (текстовый формат Prometheus, без внешних зависимостей).
+type Metrics struct {
+	mu         sync.Mutex
+	started    time.Time
+	toolCalls  map[string]int64
+	toolErrors map[string]int64
+}
+
+func newMetrics() *Metrics {
+	return &Metrics{
+		started:    time.Now(),
+		toolCalls:  make(map[string]int64),
+		toolErrors: make(map[string]int64),
+	}
+}
+
+// RecordToolCall учитывает один вызов инструмента и его исход.
+func (m *Metrics) RecordToolCall(tool string, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.toolCalls[tool]++
+	if err != nil {
+		m.toolErrors[tool]++
+	}
+}
+
+func (m *Metrics) handle(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	fmt.Fprintln(w, "# HELP fincalc_tool_calls_total Число вызовов инструмента.")
+	fmt.Fprintln(w, "# TYPE fincalc_tool_calls_total counter")
+	for _, tool := range sortedKeys(m.toolCalls) {
+		fmt.Fprintf(w, "fincalc_tool_calls_total{tool=%q} %d\n", tool, m.toolCalls[tool])
+	}
+	fmt.Fprintln(w, "# HELP fincalc_tool_errors_total Число вызовов инструмента, завершившихся ошибкой.")
+	fmt.Fprintln(w, "# TYPE fincalc_tool_errors_total counter")
+	for _, tool := range sortedKeys(m.toolErrors) {
+		fmt.Fprintf(w, "fincalc_tool_errors_total{tool=%q} %d\n", tool, m.toolErrors[tool])
+	}
+	fmt.Fprintln(w, "# HELP fincalc_uptime_seconds Время работы сервера в секундах.")
+	fmt.Fprintln(w, "# TYPE fincalc_uptime_seconds gauge")
+	fmt.Fprintf(w, "fincalc_uptime_seconds %.0f\n", time.Since(m.started).Seconds())
+}
+
+func sortedKeys(m map[string]int64) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,249 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"fincalc-mcp/calculations"
+)
+
+// JSON-RPC 2.0 поверх HTTP POST — транспорт streamable-http без SSE.
+
+type rpcRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      any             `json:"id"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type rpcResponse struct {
+	JSONRPC string    `json:"jsonrpc"`
+	ID      any       `json:"id"`
+	Result  any       `json:"result,omitempty"`
+	Error   *rpcError `json:"error,omitempty"`
+}
+
+const (
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+	codeParseError     = -32700
+)
+
+func (s *Server) handleMCP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "метод не поддерживается", http.StatusMethodNotAllowed)
+		return
+	}
+	var req rpcRequest
+	dec := json.NewDecoder(r.Body)
+	if err := dec.Decode(&req); err != nil {
+		writeRPC(w, rpcResponse{JSONRPC: "2.0", Error: &rpcError{Code: codeParseError, Message: "некорректный JSON"}})
+		return
+	}
+	// Уведомления (без id) не требуют ответа.
+	if req.ID == nil {
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+	resp := rpcResponse{JSONRPC: "2.0", ID: req.ID}
+	switch req.Method {
+	case "initialize":
+		resp.Result = map[string]any{
+			"protocolVersion": "2024-11-05",
+			"capabilities":    map[string]any{"tools": map[string]any{}},
+			"serverInfo":      map[string]any{"name": "fincalc-mcp", "version": Version},
+		}
+	case "ping":
+		resp.Result = map[string]any{}
+	case "tools/list":
+		resp.Result = s.listTools()
+	case "tools/call":
+		resp.Result = s.callTool(r, req.Params)
+	default:
+		resp.Error = &rpcError{Code: codeMethodNotFound, Message: "неизвестный метод " + req.Method}
+	}
+	writeRPC(w, resp)
+}
+
+func (s *Server) listTools() map[string]any {
+	tools := make([]map[string]any, 0, len(s.tools))
+	for _, t := range s.tools {
+		tools = append(tools, map[string]any{
+			"name":        t.Name,
+			"description": t.Description,
+			"inputSchema": t.InputSchema(),
+		})
+	}
+	return map[string]any{"tools": tools}
+}
+
+// maxMetadataBytes ограничивает размер сквозных клиентских метаданных
+// в сериализованном виде.
+const maxMetadataBytes = 4096
+
+func (s *Server) callTool(r *http.Request, raw json.RawMessage) map[string]any {
+	var params struct {
+		Name      string         `json:"name"`
+		Arguments map[string]any `json:"arguments"`
+	}
+	if err := json.Unmarshal(raw, &params); err != nil {
+		return errorResult("некорректные параметры tools/call")
+	}
+	tool, ok := s.byName[params.Name]
+	if !ok {
+		return errorResult("неизвестный инструмент " + params.Name)
+	}
+	if params.Arguments == nil {
+		params.Arguments = map[string]any{}
+	}
+	// Зарезервированное поле metadata не участвует в расчете: оно
+	// проверяется только на размер и возвращается клиенту без изменений
+	// (корреляция запросов и ответов в асинхронных конвейерах).
+	metadata, ok := params.Arguments["metadata"]
+	if ok {
+		delete(params.Arguments, "metadata")
+		b, err := json.Marshal(metadata)
+		if err != nil || len(b) > maxMetadataBytes {
+			return errorResult(fmt.Sprintf("поле metadata не должно превышать %d байт", maxMetadataBytes))
+		}
+	}
+	// Ставка нормализуется к процентам до вызова обработчика; подозрение
+	// на перепутанный формат не блокирует расчет, а попадает в warnings.
+	rateWarning, err := normalizeRateFormat(params.Arguments)
+	if err != nil {
+		return errorResult(err.Error())
+	}
+	// Параметры конвертации валюты тоже извлекаются до вызова обработчика:
+	// сам расчет от них не зависит.
+	conversion, err := currencyArgs(params.Arguments)
+	if err != nil {
+		return errorResult(err.Error())
+	}
+	structured, err := s.invokeTool(r.Context(), tool, params.Arguments)
+	s.metrics.RecordToolCall(params.Name, err)
+	if err != nil {
+		s.log.Warn("ошибка инструмента", "tool", params.Name, "error", err)
+		result := errorResult(err.Error())
+		// Ошибки валидации дополняются структурированными границами
+		// допустимого диапазона для точных подсказок в UI.
+		var ve *calculations.ValidationError
+		if errors.As(err, &ve) {
+			result["structuredContent"] = map[string]any{"validation": ve}
+		}
+		if rateWarning != "" {
+			result["warnings"] = []string{rateWarning}
+		}
+		if metadata != nil {
+			result["metadata"] = metadata
+		}
+		return result
+	}
+	result := map[string]any{
+		"content":           []map[string]any{{"type": "text", "text": renderText(structured)}},
+		"structuredContent": structured,
+		"isError":           false,
+	}
+	if conversion != nil {
+		converted, err := conversion.convert(structured)
+		if err != nil {
+			return errorResult(err.Error())
+		}
+		result["convertedContent"] = converted
+		result["targetCurrency"] = conversion.Currency
+		result["exchangeRate"] = conversion.Rate
+	}
+	var warnings []string
+	if rateWarning != "" {
+		warnings = append(warnings, rateWarning)
+	}
+	if w := overpaymentWarning(s.cfg, structured); w != "" {
+		warnings = append(warnings, w)
+	}
+	if len(warnings) > 0 {
+		result["warnings"] = warnings
+	}
+	if metadata != nil {
+		result["metadata"] = metadata
+	}
+	return result
+}
+
+// overpaymentWarning возвращает мягкое предупреждение, когда переплата
+// превышает тело кредита, умноженное на cfg.OverpaymentWarningRatio.
+// Сводка читается через JSON-представление (как при конвертации валюты),
+// чтобы покрыть и конкретные типы результатов, и map-ответы; расчеты без
+// полей principal/total_interest в корне пропускаются.
+func overpaymentWarning(cfg calculations.Config, structured any) string {
+	if cfg.OverpaymentWarningRatio <= 0 {
+		return ""
+	}
+	b, err := json.Marshal(structured)
+	if err != nil {
+		return ""
+	}
+	var tree map[string]any
+	if err := json.Unmarshal(b, &tree); err != nil {
+		return ""
+	}
+	principal, ok := tree["principal"].(float64)
+	if !ok || principal <= 0 {
+		return ""
+	}
+	interest, ok := tree["total_interest"].(float64)
+	if !ok || interest <= principal*cfg.OverpaymentWarningRatio {
+		return ""
+	}
+	return fmt.Sprintf("переплата %.2f превышает тело кредита %.2f: проценты за весь срок — %.0f%% от суммы",
+		interest, principal, interest/principal*100)
+}
+
+// invokeTool выполняет обработчик с лимитом времени s.toolTimeout:
+// патологический вход (несходящийся солвер, гигантский срок в точном
+// режиме) не должен занимать соединение навсегда.
+func (s *Server) invokeTool(ctx context.Context, tool *Tool, args map[string]any) (any, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.toolTimeout)
+	defer cancel()
+	type outcome struct {
+		structured any
+		err        error
+	}
+	done := make(chan outcome, 1)
+	go func() {
+		structured, err := tool.Handler(ctx, args)
+		done <- outcome{structured, err}
+	}()
+	select {
+	case out := <-done:
+		return out.structured, out.err
+	case <-ctx.Done():
+		return nil, fmt.Errorf("инструмент %s не уложился в лимит %s", tool.Name, s.toolTimeout)
+	}
+}
+
+func errorResult(message string) map[string]any {
+	return map[string]any{
+		"content": []map[string]any{{"type": "text", "text": message}},
+		"isError": true,
+	}
+}
+
+func renderText(v any) string {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return "не удалось сериализовать результат"
+	}
+	return string(b)
+}
+
+func writeRPC(w http.ResponseWriter, resp rpcResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
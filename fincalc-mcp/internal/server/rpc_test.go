@@ -0,0 +1,599 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"fincalc-mcp/calculations"
+)
+
+func newTestServer() *Server {
+	return New(calculations.DefaultConfig(), slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+// callToolForTest вызывает tools/call напрямую, минуя HTTP-декодирование.
+func callToolForTest(t *testing.T, s *Server, name string, args map[string]any) map[string]any {
+	t.Helper()
+	params, err := json.Marshal(map[string]any{"name": name, "arguments": args})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := httptest.NewRequest("POST", "/mcp", nil)
+	return s.callTool(r, params)
+}
+
+func TestMetadataRoundTrip(t *testing.T) {
+	s := newTestServer()
+	meta := map[string]any{"request_id": "abc-123", "user_id": float64(42)}
+	result := callToolForTest(t, s, "rate_convert", map[string]any{
+		"rate":           float64(12),
+		"from_frequency": "monthly",
+		"to_frequency":   "annual",
+		"metadata":       meta,
+	})
+	if result["isError"] != false {
+		t.Fatalf("неожиданная ошибка: %v", result)
+	}
+	got, ok := result["metadata"].(map[string]any)
+	if !ok {
+		t.Fatal("в ответе нет поля metadata")
+	}
+	if got["request_id"] != "abc-123" || got["user_id"] != float64(42) {
+		t.Fatalf("metadata изменилось: %v", got)
+	}
+}
+
+func TestMetadataTooLarge(t *testing.T) {
+	s := newTestServer()
+	result := callToolForTest(t, s, "rate_convert", map[string]any{
+		"rate":           float64(12),
+		"from_frequency": "monthly",
+		"to_frequency":   "annual",
+		"metadata":       map[string]any{"blob": strings.Repeat("x", maxMetadataBytes+1)},
+	})
+	if result["isError"] != true {
+		t.Fatal("ожидалась ошибка для слишком большого metadata")
+	}
+}
+
+func TestRateFormatFraction(t *testing.T) {
+	s := newTestServer()
+	percent := callToolForTest(t, s, "calculate_annuity", map[string]any{
+		"principal": float64(1_000_000), "annual_rate_percent": float64(12), "months": float64(12),
+	})
+	fraction := callToolForTest(t, s, "calculate_annuity", map[string]any{
+		"principal": float64(1_000_000), "annual_rate_percent": float64(0.12), "months": float64(12),
+		"rate_format": "fraction",
+	})
+	if percent["isError"] != false || fraction["isError"] != false {
+		t.Fatalf("неожиданная ошибка: %v / %v", percent, fraction)
+	}
+	p := percent["structuredContent"].(*calculations.CalculationResult)
+	f := fraction["structuredContent"].(*calculations.CalculationResult)
+	if p.MonthlyPayment != f.MonthlyPayment {
+		t.Fatalf("fraction 0.12 должен совпадать с percent 12: %v != %v", f.MonthlyPayment, p.MonthlyPayment)
+	}
+	if _, ok := fraction["warnings"]; ok {
+		t.Fatalf("для корректной доли не ожидалось предупреждений: %v", fraction["warnings"])
+	}
+}
+
+func TestRateFormatWarnings(t *testing.T) {
+	s := newTestServer()
+	// Доля в формате percent — вероятная ошибка клиента.
+	result := callToolForTest(t, s, "calculate_annuity", map[string]any{
+		"principal": float64(1_000_000), "annual_rate_percent": float64(0.12), "months": float64(12),
+	})
+	if result["isError"] != false {
+		t.Fatalf("неожиданная ошибка: %v", result)
+	}
+	if _, ok := result["warnings"]; !ok {
+		t.Fatal("ожидалось предупреждение для percent < 1")
+	}
+	// Проценты в формате fraction.
+	result = callToolForTest(t, s, "calculate_annuity", map[string]any{
+		"principal": float64(1_000_000), "annual_rate_percent": float64(12), "months": float64(12),
+		"rate_format": "fraction",
+	})
+	// Ставка 12 в формате fraction превращается в 1200% и отклоняется
+	// валидацией, но предупреждение о перепутанном формате сохраняется.
+	if result["isError"] != true {
+		t.Fatalf("ожидалась ошибка валидации для ставки 1200%%: %v", result)
+	}
+	if _, ok := result["warnings"]; !ok {
+		t.Fatal("ожидалось предупреждение для fraction > 1")
+	}
+	result = callToolForTest(t, s, "calculate_annuity", map[string]any{
+		"principal": float64(1_000_000), "annual_rate_percent": float64(12), "months": float64(12),
+		"rate_format": "неизвестный",
+	})
+	if result["isError"] != true {
+		t.Fatal("ожидалась ошибка для неизвестного rate_format")
+	}
+}
+
+// TestRateFormatConvertsAllRateParams сверяет вызов в процентах с тем же
+// вызовом в долях: rate_format=fraction обязан конвертировать каждую
+// зарегистрированную ставку инструмента, иначе расчет смешает единицы.
+func TestRateFormatConvertsAllRateParams(t *testing.T) {
+	s := newTestServer()
+	cases := []struct {
+		tool     string
+		percent  map[string]any
+		fraction map[string]any
+	}{
+		{
+			tool: "refinance_compare",
+			percent: map[string]any{
+				"principal": 3_000_000.0, "annual_rate_percent": 14.0, "months": 120.0,
+				"paid_months": 24.0, "new_rate_percent": 10.0, "new_months": 96.0,
+			},
+			fraction: map[string]any{
+				"principal": 3_000_000.0, "annual_rate_percent": 0.14, "months": 120.0,
+				"paid_months": 24.0, "new_rate_percent": 0.10, "new_months": 96.0,
+			},
+		},
+		{
+			tool: "deposit_vs_flexible",
+			percent: map[string]any{
+				"initial_amount": 1_000_000.0, "annual_rate_percent": 10.0, "months": 12.0,
+				"penalty_rate_percent": 3.0, "flexible_rate_percent": 6.0,
+			},
+			fraction: map[string]any{
+				"initial_amount": 1_000_000.0, "annual_rate_percent": 0.10, "months": 12.0,
+				"penalty_rate_percent": 0.03, "flexible_rate_percent": 0.06,
+			},
+		},
+		{
+			tool: "rent_vs_buy",
+			percent: map[string]any{
+				"home_price": 10_000_000.0, "down_payment": 2_000_000.0,
+				"mortgage_rate_percent": 8.0, "mortgage_months": 240.0,
+				"monthly_rent": 45_000.0, "rent_escalation_percent": 5.0,
+				"investment_rate_percent": 10.0, "home_appreciation_percent": 4.0,
+				"horizon_months": 120.0,
+			},
+			fraction: map[string]any{
+				"home_price": 10_000_000.0, "down_payment": 2_000_000.0,
+				"mortgage_rate_percent": 0.08, "mortgage_months": 240.0,
+				"monthly_rent": 45_000.0, "rent_escalation_percent": 0.05,
+				"investment_rate_percent": 0.10, "home_appreciation_percent": 0.04,
+				"horizon_months": 120.0,
+			},
+		},
+		{
+			tool: "break_even_appreciation",
+			percent: map[string]any{
+				"home_price": 10_000_000.0, "down_payment": 2_000_000.0,
+				"mortgage_rate_percent": 8.0, "mortgage_months": 240.0,
+				"monthly_rent": 45_000.0, "rent_escalation_percent": 5.0,
+				"investment_rate_percent": 10.0, "horizon_months": 120.0,
+			},
+			fraction: map[string]any{
+				"home_price": 10_000_000.0, "down_payment": 2_000_000.0,
+				"mortgage_rate_percent": 0.08, "mortgage_months": 240.0,
+				"monthly_rent": 45_000.0, "rent_escalation_percent": 0.05,
+				"investment_rate_percent": 0.10, "horizon_months": 120.0,
+			},
+		},
+		{
+			tool: "calculate_variable_loan",
+			percent: map[string]any{
+				"principal": 1_000_000.0, "months": 24.0,
+				"rate_periods": []any{
+					map[string]any{"from_month": 1.0, "annual_rate_percent": 12.0},
+					map[string]any{"from_month": 13.0, "annual_rate_percent": 15.0},
+				},
+			},
+			fraction: map[string]any{
+				"principal": 1_000_000.0, "months": 24.0,
+				"rate_periods": []any{
+					map[string]any{"from_month": 1.0, "annual_rate_percent": 0.12},
+					map[string]any{"from_month": 13.0, "annual_rate_percent": 0.15},
+				},
+			},
+		},
+		{
+			tool: "portfolio_summary",
+			percent: map[string]any{
+				"investments": []any{
+					map[string]any{"initial_amount": 100_000.0, "monthly_contribution": 5_000.0, "annual_return_percent": 9.0, "months": 36.0},
+					map[string]any{"initial_amount": 300_000.0, "monthly_contribution": 0.0, "annual_return_percent": 6.0, "months": 36.0},
+				},
+			},
+			fraction: map[string]any{
+				"investments": []any{
+					map[string]any{"initial_amount": 100_000.0, "monthly_contribution": 5_000.0, "annual_return_percent": 0.09, "months": 36.0},
+					map[string]any{"initial_amount": 300_000.0, "monthly_contribution": 0.0, "annual_return_percent": 0.06, "months": 36.0},
+				},
+			},
+		},
+		{
+			// Налоговая ставка — доля налога, а не годовая ставка:
+			// rate_format ее не трогает, в обоих вызовах она в процентах.
+			tool: "tax_equivalent_yield",
+			percent: map[string]any{
+				"nominal_rate_percent": 9.0, "tax_rate_percent": 13.0,
+			},
+			fraction: map[string]any{
+				"nominal_rate_percent": 0.09, "tax_rate_percent": 13.0,
+			},
+		},
+		{
+			tool: "coast_point",
+			percent: map[string]any{
+				"initial_amount": 500_000.0, "monthly_contribution": 20_000.0,
+				"annual_return_percent": 9.0, "months": 120.0, "target_amount": 4_000_000.0,
+			},
+			fraction: map[string]any{
+				"initial_amount": 500_000.0, "monthly_contribution": 20_000.0,
+				"annual_return_percent": 0.09, "months": 120.0, "target_amount": 4_000_000.0,
+			},
+		},
+		{
+			tool: "sip_with_bonuses",
+			percent: map[string]any{
+				"initial_amount": 100_000.0, "monthly_contribution": 10_000.0,
+				"annual_return_percent": 8.0, "months": 36.0, "bonus_amount": 30_000.0,
+			},
+			fraction: map[string]any{
+				"initial_amount": 100_000.0, "monthly_contribution": 10_000.0,
+				"annual_return_percent": 0.08, "months": 36.0, "bonus_amount": 30_000.0,
+			},
+		},
+		{
+			tool: "payment_shock",
+			percent: map[string]any{
+				"principal": 1_000_000.0, "annual_rate_percent": 12.0, "months": 60.0,
+				"paid_months": 12.0, "rate_increase_percent": 3.0,
+			},
+			fraction: map[string]any{
+				"principal": 1_000_000.0, "annual_rate_percent": 0.12, "months": 60.0,
+				"paid_months": 12.0, "rate_increase_percent": 0.03,
+			},
+		},
+	}
+	for _, c := range cases {
+		p := callToolForTest(t, s, c.tool, cloneArgs(c.percent))
+		f := cloneArgs(c.fraction)
+		f["rate_format"] = "fraction"
+		got := callToolForTest(t, s, c.tool, f)
+		if p["isError"] != false || got["isError"] != false {
+			t.Fatalf("%s: неожиданная ошибка: %v / %v", c.tool, p["content"], got["content"])
+		}
+		if renderText(got["structuredContent"]) != renderText(p["structuredContent"]) {
+			t.Fatalf("%s: результат в долях отличается от результата в процентах", c.tool)
+		}
+	}
+}
+
+func TestCalculateDispatcherMatchesDirectCall(t *testing.T) {
+	s := newTestServer()
+	targets := map[string]map[string]any{
+		"calculate_annuity": {
+			"principal": float64(1_000_000), "annual_rate_percent": float64(12), "months": float64(12),
+		},
+		"calculate_deposit": {
+			"initial_amount": float64(100_000), "annual_rate_percent": float64(8), "months": float64(12),
+		},
+		"compare_loans": {
+			"principal": float64(1_000_000), "annual_rate_percent": float64(12), "months": float64(24),
+		},
+		"rate_convert": {
+			"rate": float64(12), "from_frequency": "monthly", "to_frequency": "annual",
+		},
+	}
+	for name, args := range targets {
+		direct := callToolForTest(t, s, name, cloneArgs(args))
+		routedArgs := cloneArgs(args)
+		routedArgs["type"] = name
+		routed := callToolForTest(t, s, "calculate", routedArgs)
+		if direct["isError"] != false || routed["isError"] != false {
+			t.Fatalf("%s: неожиданная ошибка: %v / %v", name, direct, routed)
+		}
+		wrapped, ok := routed["structuredContent"].(map[string]any)
+		if !ok {
+			t.Fatalf("%s: ответ диспетчера не содержит обертку", name)
+		}
+		if wrapped["type"] != name {
+			t.Fatalf("%s: ответ помечен типом %v", name, wrapped["type"])
+		}
+		// Результаты совпадают с прямым вызовом байт в байт.
+		if renderText(wrapped["result"]) != renderText(direct["structuredContent"]) {
+			t.Fatalf("%s: результат диспетчера отличается от прямого вызова", name)
+		}
+	}
+}
+
+func TestCalculateDispatcherUnknownType(t *testing.T) {
+	s := newTestServer()
+	result := callToolForTest(t, s, "calculate", map[string]any{"type": "несуществующий"})
+	if result["isError"] != true {
+		t.Fatal("ожидалась ошибка для неизвестного типа")
+	}
+	text := result["content"].([]map[string]any)[0]["text"].(string)
+	if !strings.Contains(text, "calculate_annuity") {
+		t.Fatalf("ошибка должна перечислять доступные типы, получено %q", text)
+	}
+}
+
+func cloneArgs(args map[string]any) map[string]any {
+	clone := make(map[string]any, len(args))
+	for k, v := range args {
+		clone[k] = v
+	}
+	return clone
+}
+
+func TestToolTimeout(t *testing.T) {
+	s := newTestServer()
+	s.SetToolTimeout(20 * time.Millisecond)
+	// Искусственно медленный инструмент: зависает дольше лимита.
+	s.register(&Tool{
+		Name:        "slow_for_test",
+		Description: "тестовый медленный инструмент",
+		Handler: func(ctx context.Context, args map[string]any) (any, error) {
+			select {
+			case <-time.After(time.Second):
+				return map[string]any{}, nil
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		},
+	})
+	result := callToolForTest(t, s, "slow_for_test", map[string]any{})
+	if result["isError"] != true {
+		t.Fatal("ожидалась ошибка тайм-аута")
+	}
+	text := result["content"].([]map[string]any)[0]["text"].(string)
+	if !strings.Contains(text, "лимит") {
+		t.Fatalf("ошибка должна упоминать лимит времени, получено %q", text)
+	}
+	// Обычный вызов в лимит укладывается.
+	ok := callToolForTest(t, s, "rate_convert", map[string]any{
+		"rate": float64(12), "from_frequency": "monthly", "to_frequency": "annual",
+	})
+	if ok["isError"] != false {
+		t.Fatalf("быстрый вызов не должен упираться в тайм-аут: %v", ok)
+	}
+}
+
+func TestScheduleOrderDesc(t *testing.T) {
+	s := newTestServer()
+	args := map[string]any{"principal": 1_000_000.0, "annual_rate_percent": 12.0, "months": 12.0}
+	asc := callToolForTest(t, s, "calculate_annuity", cloneArgs(args))
+	args["order"] = "desc"
+	desc := callToolForTest(t, s, "calculate_annuity", args)
+	a := asc["structuredContent"].(*calculations.CalculationResult)
+	d := desc["structuredContent"].(*calculations.CalculationResult)
+	if d.Schedule[0].Month != 12 || d.Schedule[len(d.Schedule)-1].Month != 1 {
+		t.Fatalf("при desc график должен начинаться с последнего месяца, получено %d", d.Schedule[0].Month)
+	}
+	// Значения строк не меняются — только порядок.
+	if d.Schedule[0] != a.Schedule[len(a.Schedule)-1] {
+		t.Fatal("строка последнего месяца должна совпадать с прямым порядком")
+	}
+	if d.TotalInterest != a.TotalInterest {
+		t.Fatal("итоги не должны зависеть от порядка вывода")
+	}
+
+	bad := callToolForTest(t, s, "calculate_annuity", map[string]any{
+		"principal": 1_000_000.0, "annual_rate_percent": 12.0, "months": 12.0, "order": "random",
+	})
+	if bad["isError"] != true {
+		t.Fatal("ожидалась ошибка для неизвестного порядка")
+	}
+}
+
+func TestCurrencyConversion(t *testing.T) {
+	s := newTestServer()
+	result := callToolForTest(t, s, "compare_loans", map[string]any{
+		"principal": 1_000_000.0, "annual_rate_percent": 12.0, "months": 12.0,
+		"exchange_rate": 90.0, "target_currency": "RUB",
+	})
+	if result["isError"] != false {
+		t.Fatalf("неожиданная ошибка: %v", result["content"])
+	}
+	original := result["structuredContent"].(map[string]any)
+	converted := result["convertedContent"].(map[string]any)
+	if result["targetCurrency"] != "RUB" {
+		t.Fatalf("ожидалась валюта RUB, получено %v", result["targetCurrency"])
+	}
+	// Денежные поля умножены на курс, проценты — без изменений.
+	origAnnuity := original["annuity"].(map[string]any)
+	convAnnuity := converted["annuity"].(map[string]any)
+	wantPayment := calculations.Round2(origAnnuity["monthly_payment"].(float64) * 90)
+	if convAnnuity["monthly_payment"].(float64) != wantPayment {
+		t.Fatalf("платеж должен быть конвертирован: %v != %v", convAnnuity["monthly_payment"], wantPayment)
+	}
+	if convAnnuity["overpayment_percent"] != origAnnuity["overpayment_percent"] {
+		t.Fatal("процент переплаты не должен конвертироваться")
+	}
+
+	bad := callToolForTest(t, s, "compare_loans", map[string]any{
+		"principal": 1_000_000.0, "annual_rate_percent": 12.0, "months": 12.0,
+		"exchange_rate": -1.0, "target_currency": "RUB",
+	})
+	if bad["isError"] != true {
+		t.Fatal("ожидалась ошибка для отрицательного курса")
+	}
+	incomplete := callToolForTest(t, s, "compare_loans", map[string]any{
+		"principal": 1_000_000.0, "annual_rate_percent": 12.0, "months": 12.0,
+		"exchange_rate": 90.0,
+	})
+	if incomplete["isError"] != true {
+		t.Fatal("ожидалась ошибка: курс без целевой валюты")
+	}
+}
+
+func TestCurrencyConversionAllowlist(t *testing.T) {
+	s := newTestServer()
+	// График с долями платежа и фискальными периодами: конвертируются
+	// только деньги, доли/кварталы/номера месяцев остаются как есть.
+	result := callToolForTest(t, s, "calculate_annuity", map[string]any{
+		"principal": 1_000_000.0, "annual_rate_percent": 12.0, "months": 12.0,
+		"start_date":    "2026-01-15",
+		"exchange_rate": 90.0, "target_currency": "RUB",
+	})
+	if result["isError"] != false {
+		t.Fatalf("неожиданная ошибка: %v", result["content"])
+	}
+	orig := result["structuredContent"].(*calculations.CalculationResult)
+	conv := result["convertedContent"].(map[string]any)
+	entry := conv["schedule"].([]any)[0].(map[string]any)
+	first := orig.Schedule[0]
+	if entry["payment"].(float64) != calculations.Round2(first.Payment*90) {
+		t.Fatalf("платеж должен быть конвертирован: %v", entry["payment"])
+	}
+	if entry["interest_share"].(float64) != first.InterestShare ||
+		entry["principal_share"].(float64) != first.PrincipalShare {
+		t.Fatalf("доли платежа не должны конвертироваться: %v / %v",
+			entry["interest_share"], entry["principal_share"])
+	}
+	if entry["quarter"].(float64) != float64(first.Quarter) || entry["month"].(float64) != 1 {
+		t.Fatalf("квартал и номер месяца не должны конвертироваться: %v / %v",
+			entry["quarter"], entry["month"])
+	}
+
+	// Сводка со счетчиком месяцев: months_shortened — не деньги.
+	windfall := callToolForTest(t, s, "windfall_payoff", map[string]any{
+		"principal": 1_000_000.0, "annual_rate_percent": 12.0, "months": 24.0,
+		"windfalls":     []any{map[string]any{"month": 6.0, "amount": 200_000.0}},
+		"exchange_rate": 90.0, "target_currency": "RUB",
+	})
+	if windfall["isError"] != false {
+		t.Fatalf("неожиданная ошибка: %v", windfall["content"])
+	}
+	wOrig := windfall["structuredContent"].(map[string]any)
+	wConv := windfall["convertedContent"].(map[string]any)
+	if wConv["months_shortened"] != float64(wOrig["months_shortened"].(int)) {
+		t.Fatalf("months_shortened не должен конвертироваться: %v", wConv["months_shortened"])
+	}
+	if wConv["interest_saved"].(float64) != calculations.Round2(wOrig["interest_saved"].(float64)*90) {
+		t.Fatalf("interest_saved должен быть конвертирован: %v", wConv["interest_saved"])
+	}
+}
+
+func TestFXLoanScenariosCall(t *testing.T) {
+	s := newTestServer()
+	// Имя base_exchange_rate выбрано намеренно: exchange_rate зарезервирован
+	// глобальной конвертацией валюты и выедается из аргументов до хендлера.
+	result := callToolForTest(t, s, "fx_loan_scenarios", map[string]any{
+		"principal": 10_000.0, "annual_rate_percent": 8.0, "months": 12.0,
+		"base_exchange_rate":       90.0,
+		"scenario_changes_percent": []any{-10.0, 10.0},
+	})
+	if result["isError"] != false {
+		t.Fatalf("неожиданная ошибка: %v", result["content"])
+	}
+	out := result["structuredContent"].(map[string]any)
+	scenarios := out["scenarios"].([]calculations.FXScenario)
+	if len(scenarios) != 3 {
+		t.Fatalf("ожидался базовый сценарий плюс два заданных, получено %d", len(scenarios))
+	}
+	// Глобальная конвертация продолжает работать вместе с базовым курсом.
+	combined := callToolForTest(t, s, "fx_loan_scenarios", map[string]any{
+		"principal": 10_000.0, "annual_rate_percent": 8.0, "months": 12.0,
+		"base_exchange_rate":       90.0,
+		"scenario_changes_percent": []any{-10.0, 10.0},
+		"exchange_rate":            2.0, "target_currency": "EUR",
+	})
+	if combined["isError"] != false {
+		t.Fatalf("неожиданная ошибка: %v", combined["content"])
+	}
+	if _, ok := combined["convertedContent"]; !ok {
+		t.Fatal("ожидался convertedContent при заданной целевой валюте")
+	}
+}
+
+func TestAnnuityExclusiveModes(t *testing.T) {
+	s := newTestServer()
+	base := map[string]any{
+		"principal": 1_000_000.0, "annual_rate_percent": 12.0, "months": 12.0,
+	}
+	// financed_fee раньше молча проигрывал отсрочке первого платежа —
+	// теперь комбинация отклоняется явно.
+	combo := cloneArgs(base)
+	combo["financed_fee"] = 30_000.0
+	combo["first_payment_delay_days"] = 45.0
+	result := callToolForTest(t, s, "calculate_annuity", combo)
+	if result["isError"] != true {
+		t.Fatal("ожидалась ошибка для financed_fee вместе с first_payment_delay_days")
+	}
+	// round_payments_to_whole так же несовместим с остальными режимами.
+	combo = cloneArgs(base)
+	combo["round_payments_to_whole"] = "nearest"
+	combo["first_payment_delay_days"] = 45.0
+	result = callToolForTest(t, s, "calculate_annuity", combo)
+	if result["isError"] != true {
+		t.Fatal("ожидалась ошибка для round_payments_to_whole вместе с first_payment_delay_days")
+	}
+	combo = cloneArgs(base)
+	combo["round_payments_to_whole"] = "up"
+	combo["financed_fee"] = 30_000.0
+	result = callToolForTest(t, s, "calculate_annuity", combo)
+	if result["isError"] != true {
+		t.Fatal("ожидалась ошибка для round_payments_to_whole вместе с financed_fee")
+	}
+	// Пост-обработка стандартного пути в альтернативных режимах
+	// отклоняется явно, а не игнорируется.
+	for _, extra := range []map[string]any{
+		{"first_payment_delay_days": 45.0, "start_date": "2026-03-01"},
+		{"financed_fee": 30_000.0, "fee_periods": []any{map[string]any{"from_month": 1.0, "monthly_fee": 500.0}}},
+		{"round_payments_to_whole": "nearest", "order": "desc"},
+	} {
+		call := cloneArgs(base)
+		for k, v := range extra {
+			call[k] = v
+		}
+		result = callToolForTest(t, s, "calculate_annuity", call)
+		if result["isError"] != true {
+			t.Fatalf("ожидалась ошибка для неподдерживаемой пост-обработки: %v", extra)
+		}
+	}
+	// Поодиночке режимы работают.
+	for _, alone := range []map[string]any{
+		{"financed_fee": 30_000.0},
+		{"round_payments_to_whole": "nearest"},
+	} {
+		call := cloneArgs(base)
+		for k, v := range alone {
+			call[k] = v
+		}
+		result = callToolForTest(t, s, "calculate_annuity", call)
+		if result["isError"] != false {
+			t.Fatalf("неожиданная ошибка: %v", result["content"])
+		}
+	}
+}
+
+func TestOverpaymentWarning(t *testing.T) {
+	s := newTestServer()
+	// Длинный дорогой кредит: переплата превышает тело — ждем предупреждение.
+	long := callToolForTest(t, s, "calculate_annuity", map[string]any{
+		"principal": 1_000_000.0, "annual_rate_percent": 15.0, "months": 240.0,
+	})
+	if long["isError"] != false {
+		t.Fatalf("неожиданная ошибка: %v", long["content"])
+	}
+	warnings, ok := long["warnings"].([]string)
+	if !ok || len(warnings) == 0 {
+		t.Fatalf("ожидалось предупреждение о переплате, получено %v", long["warnings"])
+	}
+	// Короткий дешевый кредит предупреждения не вызывает.
+	short := callToolForTest(t, s, "calculate_annuity", map[string]any{
+		"principal": 1_000_000.0, "annual_rate_percent": 8.0, "months": 12.0,
+	})
+	if short["isError"] != false {
+		t.Fatalf("неожиданная ошибка: %v", short["content"])
+	}
+	if _, ok := short["warnings"]; ok {
+		t.Fatalf("предупреждение не должно появляться: %v", short["warnings"])
+	}
+}
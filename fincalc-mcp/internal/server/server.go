@@ -0,0 +1,123 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"fincalc-mcp/calculations"
+)
+
+// Version сервера, отдается в serverInfo при initialize.
+const Version = "1.0.0"
+
+// DefaultToolTimeout — лимит времени на один вызов инструмента.
+// Выбран с большим запасом: обычные расчеты занимают миллисекунды.
+const DefaultToolTimeout = 30 * time.Second
+
+// Server обслуживает MCP поверх HTTP и хранит реестр инструментов.
+type Server struct {
+	cfg         calculations.Config
+	log         *slog.Logger
+	metrics     *Metrics
+	tools       []*Tool
+	byName      map[string]*Tool
+	toolTimeout time.Duration
+}
+
+// New создает сервер с заданными ограничениями расчетов и регистрирует
+// все инструменты.
+func New(cfg calculations.Config, log *slog.Logger) *Server {
+	s := &Server{
+		cfg:         cfg,
+		log:         log,
+		metrics:     newMetrics(),
+		byName:      make(map[string]*Tool),
+		toolTimeout: DefaultToolTimeout,
+	}
+	s.registerTools()
+	return s
+}
+
+// SetToolTimeout переопределяет лимит времени на вызов инструмента.
+func (s *Server) SetToolTimeout(d time.Duration) {
+	if d > 0 {
+		s.toolTimeout = d
+	}
+}
+
+func (s *Server) register(t *Tool) {
+	if _, ok := s.byName[t.Name]; ok {
+		panic(fmt.Sprintf("инструмент %q зарегистрирован дважды", t.Name))
+	}
+	s.tools = append(s.tools, t)
+	s.byName[t.Name] = t
+}
+
+// Handler возвращает корневой HTTP-обработчик с эндпоинтами /mcp, /health
+// и /metrics и CORS-заголовками для MCP Inspector.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/mcp", s.handleMCP)
+	mux.HandleFunc("/health", s.handleHealth)
+	mux.HandleFunc("/metrics", s.metrics.handle)
+	return withCORS(mux)
+}
+
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]any{
+		"status":  "ok",
+		"service": "fincalc-mcp",
+		"version": Version,
+		"tools":   len(s.tools),
+	})
+}
+
+// ToolsJSON возвращает справку по инструментам в формате mcp_tools.json
+// (см. одноименные файлы соседних MCP-сервисов).
+func (s *Server) ToolsJSON() ([]byte, error) {
+	type argDoc struct {
+		Name        string `json:"name"`
+		Type        string `json:"type"`
+		Description string `json:"description"`
+		Required    bool   `json:"required"`
+	}
+	type toolDoc struct {
+		Name        string   `json:"name"`
+		Description string   `json:"description"`
+		Args        []argDoc `json:"args"`
+	}
+	docs := make([]toolDoc, 0, len(s.tools))
+	for _, t := range s.tools {
+		doc := toolDoc{Name: t.Name, Description: t.Description, Args: []argDoc{}}
+		for _, p := range t.Params {
+			doc.Args = append(doc.Args, argDoc{
+				Name:        p.Name,
+				Type:        p.Type,
+				Description: p.Description,
+				Required:    p.Required,
+			})
+		}
+		docs = append(docs, doc)
+	}
+	return json.MarshalIndent(docs, "", "  ")
+}
+
+// withCORS добавляет CORS-заголовки, необходимые MCP Inspector
+// (Connection Type: Direct).
+func withCORS(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, DELETE, OPTIONS")
+		w.Header().Set("Access-Control-Allow-Headers", "mcp-protocol-version, mcp-session-id, Authorization, Content-Type")
+		w.Header().Set("Access-Control-Expose-Headers", "mcp-session-id")
+		if r.Method == http.MethodOptions {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
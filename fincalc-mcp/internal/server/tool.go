@@ -0,0 +1,51 @@
+// Package server реализует HTTP-транспорт MCP (streamable-http, JSON-RPC 2.0)
+// поверх пакета calculations: реестр инструментов, эндпоинты /mcp, /health
+// и /metrics.
+package server
+
+import "context"
+
+// Param описывает один аргумент инструмента. Из списка Param строится
+// JSON Schema для tools/list и справка mcp_tools.json.
+type Param struct {
+	Name        string
+	Type        string // number | integer | string | boolean | array | object
+	Description string
+	Required    bool
+	Enum        []string
+}
+
+// Tool — зарегистрированный MCP инструмент.
+type Tool struct {
+	Name        string
+	Description string
+	Params      []Param
+	Handler     func(ctx context.Context, args map[string]any) (any, error)
+}
+
+// InputSchema собирает JSON Schema аргументов инструмента.
+func (t *Tool) InputSchema() map[string]any {
+	props := map[string]any{}
+	var required []string
+	for _, p := range t.Params {
+		prop := map[string]any{
+			"type":        p.Type,
+			"description": p.Description,
+		}
+		if len(p.Enum) > 0 {
+			prop["enum"] = p.Enum
+		}
+		props[p.Name] = prop
+		if p.Required {
+			required = append(required, p.Name)
+		}
+	}
+	schema := map[string]any{
+		"type":       "object",
+		"properties": props,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
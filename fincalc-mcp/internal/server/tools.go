@@ -0,0 +1,873 @@
+package server
+
+import "fincalc-mcp/calculations"
+
+var frequencies = []string{
+	calculations.FreqAnnual,
+	calculations.FreqSemiannual,
+	calculations.FreqQuarterly,
+	calculations.FreqMonthly,
+	calculations.FreqWeekly,
+	calculations.FreqDaily,
+	calculations.FreqContinuous,
+}
+
+// Выходной параметр format для инструментов, возвращающих график.
+func formatParam() Param {
+	return Param{
+		Name:        "format",
+		Type:        "string",
+		Description: "Формат графика: построчный (rows, по умолчанию) или колоночный (columns)",
+		Enum:        []string{formatRows, formatColumns},
+	}
+}
+
+// Выходной параметр order для инструментов, возвращающих график.
+func orderParam() Param {
+	return Param{
+		Name:        "order",
+		Type:        "string",
+		Description: "Порядок строк графика: с первого месяца (asc, по умолчанию) или с последнего (desc)",
+		Enum:        []string{orderAsc, orderDesc},
+	}
+}
+
+// Формат годовой ставки для инструментов с параметром annual_rate_percent.
+func rateFormatParam() Param {
+	return Param{
+		Name:        "rate_format",
+		Type:        "string",
+		Description: "Формат ставки: percent (12 для 12%, по умолчанию) или fraction (0.12 для 12%)",
+		Enum:        []string{rateFormatPercent, rateFormatFraction},
+	}
+}
+
+// Базовые параметры кредитных инструментов.
+func loanToolParams() []Param {
+	return []Param{
+		{Name: "principal", Type: "number", Description: "Сумма кредита", Required: true},
+		{Name: "annual_rate_percent", Type: "number", Description: "Годовая ставка в процентах", Required: true},
+		{Name: "months", Type: "integer", Description: "Срок кредита в месяцах", Required: true},
+		rateFormatParam(),
+	}
+}
+
+// Параметры конвертации валюты для сравнительных и инвестиционных
+// инструментов: результат дублируется в целевой валюте (convertedContent),
+// исходные значения остаются без изменений.
+func currencyParams() []Param {
+	return []Param{
+		{Name: "exchange_rate", Type: "number", Description: "Курс пересчета денежных полей результата в целевую валюту (задается вместе с target_currency)"},
+		{Name: "target_currency", Type: "string", Description: "Код целевой валюты для конвертированной копии результата (например, RUB)"},
+	}
+}
+
+// Параметры платежного календаря для инструментов с графиком кредита.
+func feePeriodsParam() Param {
+	return Param{
+		Name:        "fee_periods",
+		Type:        "array",
+		Description: "График комиссий за обслуживание: [{from_month, monthly_fee}, ...], первый участок с месяца 1; комиссия показывается отдельно от платежа",
+	}
+}
+
+func calendarParams() []Param {
+	return []Param{
+		{Name: "start_date", Type: "string", Description: "Дата выдачи кредита (YYYY-MM-DD); при наличии в графике проставляются даты платежей"},
+		{Name: "payment_day_of_month", Type: "integer", Description: "Желаемый день платежа (по умолчанию день выдачи); в коротких месяцах прижимается к последнему дню"},
+	}
+}
+
+// registerTools регистрирует все MCP инструменты сервиса.
+func (s *Server) registerTools() {
+	s.register(&Tool{
+		Name:        dispatcherName,
+		Description: "Универсальный вход: маршрутизирует вызов к инструменту по дискриминатору type, остальные параметры передаются как есть.",
+		Params: []Param{
+			{Name: "type", Type: "string", Description: "Имя целевого инструмента (например, calculate_annuity)", Required: true},
+		},
+		Handler: s.calculateHandler,
+	})
+	s.register(&Tool{
+		Name:        "calculate_annuity",
+		Description: "Строит помесячный график аннуитетного кредита с равными платежами.",
+		Params: append(append(loanToolParams(), calendarParams()...),
+			Param{Name: "exact", Type: "boolean", Description: "Точная рациональная арифметика без накопленного дрейфа (медленнее; для аудиторской сверки)"},
+			Param{Name: "compounding", Type: "string", Description: "Капитализация процентов: monthly (по умолчанию) или daily — дневное начисление за фактические дни периода, требует start_date", Enum: []string{"monthly", "daily"}},
+			Param{Name: "first_payment_delay_days", Type: "integer", Description: "Отсрочка первого платежа в днях сверх стандартного месяца: первый период начисления длиннее, кредит немного дороже"},
+			Param{Name: "financed_fee", Type: "number", Description: "Разовая комиссия за выдачу, включаемая в тело кредита: амортизируется валовая сумма, в сводке — переплата против оплаты комиссии сразу"},
+			Param{Name: "round_payments_to_whole", Type: "string", Description: "Округление каждого платежа до целых единиц валюты: nearest или up; накопленная разница сверяется последним платежом", Enum: []string{calculations.WholeNearest, calculations.WholeUp}},
+			feePeriodsParam(),
+			formatParam(),
+			orderParam(),
+		),
+		Handler: s.annuityHandler,
+	})
+	s.register(&Tool{
+		Name:        "calculate_differential",
+		Description: "Строит помесячный график дифференцированного кредита с убывающими платежами.",
+		Params:      append(append(loanToolParams(), calendarParams()...), feePeriodsParam(), formatParam(), orderParam()),
+		Handler:     s.differentialHandler,
+	})
+	s.register(&Tool{
+		Name:        "calculate_variable_loan",
+		Description: "Строит график кредита с переменной ставкой и опциональным потолком платежа; раскрывает месяцы отрицательной амортизации и шаровой остаток.",
+		Params: []Param{
+			{Name: "principal", Type: "number", Description: "Сумма кредита", Required: true},
+			{Name: "months", Type: "integer", Description: "Срок в месяцах", Required: true},
+			{Name: "rate_periods", Type: "array", Description: "График ставок: [{from_month, annual_rate_percent}, ...], первый участок с месяца 1", Required: true},
+			{Name: "payment_cap", Type: "number", Description: "Потолок ежемесячного платежа (по умолчанию без потолка)"},
+			rateFormatParam(),
+			formatParam(),
+		},
+		Handler: s.variableLoanHandler,
+	})
+	s.register(&Tool{
+		Name:        "calculate_deposit",
+		Description: "Строит помесячный график вклада с пополнениями и опциональной капитализацией.",
+		Params: []Param{
+			{Name: "initial_amount", Type: "number", Description: "Начальная сумма вклада", Required: true},
+			{Name: "annual_rate_percent", Type: "number", Description: "Годовая ставка в процентах (не нужна при rate_periods)"},
+			{Name: "rate_type", Type: "string", Description: "Как трактовать annual_rate_percent: nominal (по умолчанию) или effective_annual — рекламная эффективная доходность, переводится в номинальную", Enum: []string{"nominal", "effective_annual"}},
+			{Name: "months", Type: "integer", Description: "Срок вклада в месяцах", Required: true},
+			{Name: "rate_periods", Type: "array", Description: "График будущих ставок: объекты {from_month, annual_rate_percent}, первый с from_month=1; заменяет annual_rate_percent"},
+			{Name: "monthly_contribution", Type: "number", Description: "Ежемесячное пополнение (по умолчанию 0)"},
+			rateFormatParam(),
+			{Name: "capitalize", Type: "boolean", Description: "Капитализировать проценты (по умолчанию true)"},
+			{Name: "contribution_at_beginning", Type: "boolean", Description: "Пополнение в начале месяца, до начисления процентов"},
+			{Name: "contribution_frequency", Type: "string", Description: "Частота пополнений: monthly (по умолчанию) или biweekly — 26 взносов в год, monthly_contribution трактуется как взнос за две недели", Enum: []string{calculations.ContributionMonthly, calculations.ContributionBiweekly}},
+			{Name: "capitalization_frequency", Type: "string", Description: "Частота зачисления процентов: monthly (по умолчанию) или annual — проценты копятся и капитализируются раз в год", Enum: []string{calculations.CapitalizationMonthly, calculations.CapitalizationAnnual}},
+			{Name: "maturity_bonus", Type: "number", Description: "Промо-бонус при дожитии вклада до конца срока (по умолчанию 0)"},
+			{Name: "day_count", Type: "string", Description: "Конвенция начисления: flat (годовая/12, по умолчанию) или actual_actual (фактические дни месяца к 365/366)", Enum: []string{calculations.DayCountFlat, calculations.DayCountActual}},
+			{Name: "deposit_start_date", Type: "string", Description: "Дата открытия вклада (YYYY-MM-DD); обязательна при day_count=actual_actual"},
+			formatParam(),
+			orderParam(),
+		},
+		Handler: s.depositHandler,
+	})
+	s.register(&Tool{
+		Name:        "surrender_value_curve",
+		Description: "Строит кривую выкупной стоимости вклада: что получит вкладчик при досрочном закрытии в каждом месяце по штрафной ставке.",
+		Params: []Param{
+			{Name: "initial_amount", Type: "number", Description: "Начальная сумма вклада", Required: true},
+			{Name: "annual_rate_percent", Type: "number", Description: "Договорная годовая ставка в процентах", Required: true},
+			{Name: "months", Type: "integer", Description: "Срок вклада в месяцах", Required: true},
+			{Name: "monthly_contribution", Type: "number", Description: "Ежемесячное пополнение (по умолчанию 0)"},
+			{Name: "capitalize", Type: "boolean", Description: "Капитализировать проценты (по умолчанию true)"},
+			{Name: "penalty_rate_percent", Type: "number", Description: "Штрафная ставка при досрочном закрытии в процентах (по умолчанию 0 — полная потеря процентов)"},
+			rateFormatParam(),
+		},
+		Handler: s.surrenderCurveHandler,
+	})
+	s.register(&Tool{
+		Name:        "decumulation_schedule",
+		Description: "Строит график траты накоплений: капитал зарабатывает проценты, из него идут ежемесячные снятия до исчерпания; сводка показывает, на сколько месяцев хватит.",
+		Params: []Param{
+			{Name: "initial_amount", Type: "number", Description: "Стартовый капитал", Required: true},
+			{Name: "annual_rate_percent", Type: "number", Description: "Годовая доходность в процентах", Required: true},
+			{Name: "monthly_withdrawal", Type: "number", Description: "Ежемесячное снятие", Required: true},
+			rateFormatParam(),
+			formatParam(),
+			orderParam(),
+		},
+		Handler: s.decumulationHandler,
+	})
+	s.register(&Tool{
+		Name:        "calculate_investment",
+		Description: "Моделирует регулярные инвестиции и возвращает метрики доходности.",
+		Params: append([]Param{
+			{Name: "initial_amount", Type: "number", Description: "Начальная сумма инвестиций", Required: true},
+			{Name: "monthly_contribution", Type: "number", Description: "Ежемесячное пополнение", Required: true},
+			{Name: "annual_return_percent", Type: "number", Description: "Ожидаемая годовая доходность в процентах", Required: true},
+			{Name: "months", Type: "integer", Description: "Горизонт инвестирования в месяцах", Required: true},
+			{Name: "contribution_at_beginning", Type: "boolean", Description: "Пополнение в начале месяца, до начисления дохода"},
+			{Name: "contribution_earns_immediately", Type: "boolean", Description: "Взнос участвует в доходе своего месяца независимо от момента внесения"},
+			formatParam(),
+		}, currencyParams()...),
+		Handler: s.investmentHandler,
+	})
+	s.register(&Tool{
+		Name:        "investment_breakdown",
+		Description: "Разделяет рост инвестиций по месяцам: собственные взносы против начисленного дохода, с итоговыми долями в процентах.",
+		Params: []Param{
+			{Name: "initial_amount", Type: "number", Description: "Начальная сумма инвестиций", Required: true},
+			{Name: "monthly_contribution", Type: "number", Description: "Ежемесячное пополнение", Required: true},
+			{Name: "annual_return_percent", Type: "number", Description: "Ожидаемая годовая доходность в процентах", Required: true},
+			{Name: "months", Type: "integer", Description: "Горизонт инвестирования в месяцах", Required: true},
+		},
+		Handler: s.investmentBreakdownHandler,
+	})
+	s.register(&Tool{
+		Name:        "compare_loans",
+		Description: "Сравнивает аннуитетную и дифференцированную схемы для одного кредита.",
+		Params: append(append(loanToolParams(),
+			Param{Name: "preference", Type: "string", Description: "Предпочтение заемщика для адресной рекомендации: минимальная переплата, щадящий старт или ровный бюджет", Enum: []string{calculations.PreferMinTotalCost, calculations.PreferMinEarlyPayments, calculations.PreferStableBudget}},
+		), currencyParams()...),
+		Handler: s.CompareLoansHandler,
+	})
+	s.register(&Tool{
+		Name:        "compare_offers",
+		Description: "Ранжирует кредитные предложения с разными комиссиями по истинной APR и полной стоимости.",
+		Params: append([]Param{
+			{Name: "principal", Type: "number", Description: "Сумма кредита, общая для всех предложений", Required: true},
+			{Name: "offers", Type: "array", Description: "Предложения: объекты с annual_rate_percent, months, upfront_fee, financed_fee и опциональным name", Required: true},
+		}, currencyParams()...),
+		Handler: s.compareOffersHandler,
+	})
+	s.register(&Tool{
+		Name:        "schedule_diff",
+		Description: "Сравнивает два сценария одного вида и возвращает помесячные дельты платежей, процентов и остатка («до/после»).",
+		Params: []Param{
+			{Name: "kind", Type: "string", Description: "Вид расчета обоих сценариев (по умолчанию annuity)", Enum: []string{calculations.KindAnnuity, calculations.KindDifferential, calculations.KindDeposit}},
+			{Name: "base", Type: "object", Description: "Параметры исходного сценария (как у соответствующего инструмента)", Required: true},
+			{Name: "other", Type: "object", Description: "Параметры измененного сценария", Required: true},
+		},
+		Handler: s.scheduleDiffHandler,
+	})
+	s.register(&Tool{
+		Name:        "remaining_balance",
+		Description: "Возвращает остаток основного долга после заданного числа платежей.",
+		Params: append(loanToolParams(),
+			Param{Name: "paid_months", Type: "integer", Description: "Число внесенных платежей", Required: true},
+		),
+		Handler: s.remainingBalanceHandler,
+	})
+	s.register(&Tool{
+		Name:        "loan_constant",
+		Description: "Возвращает кредитную константу — годовое обслуживание долга к телу кредита, в процентах (метрика коммерческой недвижимости).",
+		Params:      loanToolParams(),
+		Handler:     s.loanConstantHandler,
+	})
+	s.register(&Tool{
+		Name:        "interest_paid_by",
+		Description: "Возвращает сумму процентов, уплаченных к заданному месяцу, без выгрузки всего графика.",
+		Params: append(loanToolParams(),
+			Param{Name: "month", Type: "integer", Description: "Месяц, по который включительно суммируются проценты", Required: true},
+		),
+		Handler: s.interestPaidByHandler,
+	})
+	s.register(&Tool{
+		Name:        "payment_shock",
+		Description: "Оценивает платежный шок при повышении ставки: новый платеж на остаток долга и срока, прирост в абсолюте и в процентах.",
+		Params: append(loanToolParams(),
+			Param{Name: "paid_months", Type: "integer", Description: "Число внесенных платежей", Required: true},
+			Param{Name: "rate_increase_percent", Type: "number", Description: "Прирост годовой ставки в процентных пунктах", Required: true},
+		),
+		Handler: s.paymentShockHandler,
+	})
+	s.register(&Tool{
+		Name:        "loan_fair_value",
+		Description: "Считает справедливую цену кредита на вторичном рынке: текущую стоимость оставшихся платежей по рыночной ставке дисконтирования.",
+		Params: append(loanToolParams(),
+			Param{Name: "from_month", Type: "integer", Description: "Месяц, с которого оцениваются оставшиеся платежи", Required: true},
+			Param{Name: "discount_annual_percent", Type: "number", Description: "Рыночная годовая ставка дисконтирования в процентах", Required: true},
+		),
+		Handler: s.loanFairValueHandler,
+	})
+	s.register(&Tool{
+		Name:        "refinance_compare",
+		Description: "Сравнивает продолжение текущего кредита с рефинансированием остатка.",
+		Params: append(loanToolParams(),
+			Param{Name: "paid_months", Type: "integer", Description: "Число внесенных платежей", Required: true},
+			Param{Name: "new_rate_percent", Type: "number", Description: "Новая годовая ставка в процентах", Required: true},
+			Param{Name: "new_months", Type: "integer", Description: "Новый срок в месяцах", Required: true},
+			Param{Name: "fee", Type: "number", Description: "Разовая комиссия за рефинансирование (по умолчанию 0)"},
+		),
+		Handler: s.refinanceHandler,
+	})
+	s.register(&Tool{
+		Name:        "term_comparison",
+		Description: "Сравнивает один и тот же аннуитетный кредит для нескольких сроков за один вызов.",
+		Params: []Param{
+			{Name: "principal", Type: "number", Description: "Сумма кредита", Required: true},
+			{Name: "annual_rate_percent", Type: "number", Description: "Годовая ставка в процентах", Required: true},
+			{Name: "terms", Type: "array", Description: "Список сроков в месяцах", Required: true},
+			rateFormatParam(),
+		},
+		Handler: s.termComparisonHandler,
+	})
+	s.register(&Tool{
+		Name:        "payment_date_savings",
+		Description: "Оценивает экономию процентов от переноса дня платежа внутри цикла по модели дневного начисления.",
+		Params: append(loanToolParams(),
+			Param{Name: "start_date", Type: "string", Description: "Дата выдачи кредита (YYYY-MM-DD)", Required: true},
+			Param{Name: "baseline_day", Type: "integer", Description: "Текущий день платежа в месяце", Required: true},
+			Param{Name: "shifted_day", Type: "integer", Description: "Новый день платежа в месяце", Required: true},
+		),
+		Handler: s.paymentDateSavingsHandler,
+	})
+	s.register(&Tool{
+		Name:        "real_payment_burden",
+		Description: "Дефлирует платежи кредита накопленной инфляцией и показывает по годам, как фиксированный платеж дешевеет в реальном выражении.",
+		Params: append(loanToolParams(),
+			Param{Name: "annual_inflation_percent", Type: "number", Description: "Ожидаемая годовая инфляция в процентах", Required: true},
+		),
+		Handler: s.realPaymentBurdenHandler,
+	})
+	s.register(&Tool{
+		Name:        "round_term_to_years",
+		Description: "Сравнивает кредит на заданный срок с тем же кредитом на срок, округленный вверх до целых лет: снижение платежа против прироста переплаты.",
+		Params:      loanToolParams(),
+		Handler:     s.roundTermHandler,
+	})
+	s.register(&Tool{
+		Name:        "real_return_deposit",
+		Description: "Считает реальный (с поправкой на инфляцию) итог вклада и реальную годовую доходность по формуле Фишера; флаг показывает потерю покупательной способности.",
+		Params: []Param{
+			{Name: "initial_amount", Type: "number", Description: "Начальная сумма", Required: true},
+			{Name: "annual_rate_percent", Type: "number", Description: "Годовая ставка вклада в процентах", Required: true},
+			{Name: "months", Type: "integer", Description: "Срок в месяцах", Required: true},
+			{Name: "monthly_contribution", Type: "number", Description: "Ежемесячное пополнение (по умолчанию 0)"},
+			{Name: "capitalize", Type: "boolean", Description: "Капитализировать проценты (по умолчанию true)"},
+			{Name: "annual_inflation_percent", Type: "number", Description: "Ожидаемая годовая инфляция в процентах", Required: true},
+			rateFormatParam(),
+		},
+		Handler: s.realReturnDepositHandler,
+	})
+	s.register(&Tool{
+		Name:        "sip_with_bonuses",
+		Description: "Моделирует регулярные инвестиции с полугодовой премией: бонус вносится в месяцы 6, 12, 18 и далее; в сводке — прирост итога от бонусов.",
+		Params: []Param{
+			{Name: "initial_amount", Type: "number", Description: "Начальная сумма", Required: true},
+			{Name: "monthly_contribution", Type: "number", Description: "Ежемесячный взнос", Required: true},
+			{Name: "annual_return_percent", Type: "number", Description: "Ожидаемая годовая доходность в процентах", Required: true},
+			{Name: "months", Type: "integer", Description: "Горизонт в месяцах", Required: true},
+			{Name: "bonus_amount", Type: "number", Description: "Разовый бонус, вносимый каждые 6 месяцев", Required: true},
+			rateFormatParam(),
+		},
+		Handler: s.sipWithBonusesHandler,
+	})
+	s.register(&Tool{
+		Name:        "comparison_report",
+		Description: "Собирает полный пакет сравнения схем одним вызовом: числовое сравнение, Markdown-сводку и оба графика как CSV-вложения в base64.",
+		Params: []Param{
+			{Name: "principal", Type: "number", Description: "Сумма кредита", Required: true},
+			{Name: "annual_rate_percent", Type: "number", Description: "Годовая ставка в процентах", Required: true},
+			{Name: "months", Type: "integer", Description: "Срок в месяцах", Required: true},
+			rateFormatParam(),
+		},
+		Handler: s.comparisonReportHandler,
+	})
+	s.register(&Tool{
+		Name:        "mimic_differential",
+		Description: "Подбирает фиксированную ежемесячную доплату к аннуитетному платежу, при которой переплата аннуитета сравнивается с дифференцированной схемой.",
+		Params: []Param{
+			{Name: "principal", Type: "number", Description: "Сумма кредита", Required: true},
+			{Name: "annual_rate_percent", Type: "number", Description: "Годовая ставка в процентах", Required: true},
+			{Name: "months", Type: "integer", Description: "Срок в месяцах", Required: true},
+			rateFormatParam(),
+		},
+		Handler: s.mimicDifferentialHandler,
+	})
+	s.register(&Tool{
+		Name:        "net_worth_projection",
+		Description: "Строит совместную траекторию кредита и накоплений: помесячное чистое состояние (накопления минус долг) и месяц выхода в плюс.",
+		Params: []Param{
+			{Name: "principal", Type: "number", Description: "Сумма кредита", Required: true},
+			{Name: "annual_rate_percent", Type: "number", Description: "Годовая ставка кредита в процентах", Required: true},
+			{Name: "months", Type: "integer", Description: "Срок кредита в месяцах", Required: true},
+			{Name: "deposit_initial_amount", Type: "number", Description: "Начальная сумма накоплений", Required: true},
+			{Name: "deposit_rate_percent", Type: "number", Description: "Годовая ставка накоплений в процентах", Required: true},
+			{Name: "deposit_months", Type: "integer", Description: "Срок накоплений в месяцах (по умолчанию равен сроку кредита)"},
+			{Name: "deposit_monthly_contribution", Type: "number", Description: "Ежемесячное пополнение накоплений (по умолчанию 0)"},
+			{Name: "capitalize", Type: "boolean", Description: "Капитализировать проценты накоплений (по умолчанию true)"},
+			rateFormatParam(),
+		},
+		Handler: s.netWorthProjectionHandler,
+	})
+	s.register(&Tool{
+		Name:        "business_day_accrual",
+		Description: "Строит график вклада с начислением процентов только по рабочим дням (выходные и праздники пропускаются) и сравнивает с календарным начислением.",
+		Params: []Param{
+			{Name: "initial_amount", Type: "number", Description: "Начальная сумма", Required: true},
+			{Name: "annual_rate_percent", Type: "number", Description: "Годовая ставка в процентах", Required: true},
+			{Name: "months", Type: "integer", Description: "Срок в месяцах", Required: true},
+			{Name: "monthly_contribution", Type: "number", Description: "Ежемесячное пополнение (по умолчанию 0)"},
+			{Name: "deposit_start_date", Type: "string", Description: "Дата открытия вклада (YYYY-MM-DD)", Required: true},
+			{Name: "holidays", Type: "array", Description: "Праздничные даты (YYYY-MM-DD), в которые начисление не идет, сверх обычных выходных"},
+			rateFormatParam(),
+		},
+		Handler: s.businessDayAccrualHandler,
+	})
+	s.register(&Tool{
+		Name:        "simple_rate_equivalent",
+		Description: "Пересчитывает стоимость аннуитетного кредита в простую годовую ставку на исходное тело — регуляторную цифру раскрытия, отличную от APR.",
+		Params: []Param{
+			{Name: "principal", Type: "number", Description: "Сумма кредита", Required: true},
+			{Name: "annual_rate_percent", Type: "number", Description: "Годовая ставка в процентах", Required: true},
+			{Name: "months", Type: "integer", Description: "Срок в месяцах", Required: true},
+			rateFormatParam(),
+		},
+		Handler: s.simpleRateEquivalentHandler,
+	})
+	s.register(&Tool{
+		Name:        "percent_of_balance_payoff",
+		Description: "Моделирует погашение револьверного долга минимальным платежом «процент от остатка, но не меньше порога»: срок до выплаты и суммарные проценты.",
+		Params: []Param{
+			{Name: "principal", Type: "number", Description: "Текущий долг", Required: true},
+			{Name: "annual_rate_percent", Type: "number", Description: "Годовая ставка в процентах", Required: true},
+			{Name: "payment_percent", Type: "number", Description: "Минимальный платеж как процент от остатка", Required: true},
+			{Name: "payment_floor", Type: "number", Description: "Нижний порог платежа; обязателен — без него долг не амортизируется", Required: true},
+			rateFormatParam(),
+		},
+		Handler: s.percentOfBalanceHandler,
+	})
+	s.register(&Tool{
+		Name:        "deposit_vs_flexible",
+		Description: "Сравнивает срочный вклад со штрафом за досрочный выход и гибкий счет по меньшей ставке: месяц, с которого выход из вклада обгоняет гибкий счет.",
+		Params: []Param{
+			{Name: "initial_amount", Type: "number", Description: "Начальная сумма", Required: true},
+			{Name: "annual_rate_percent", Type: "number", Description: "Договорная годовая ставка вклада в процентах", Required: true},
+			{Name: "months", Type: "integer", Description: "Срок вклада в месяцах", Required: true},
+			{Name: "monthly_contribution", Type: "number", Description: "Ежемесячное пополнение (по умолчанию 0)"},
+			{Name: "capitalize", Type: "boolean", Description: "Капитализировать проценты (по умолчанию true)"},
+			{Name: "penalty_rate_percent", Type: "number", Description: "Штрафная ставка при выходе внутри штрафного окна (по умолчанию 0 — полная потеря процентов)"},
+			{Name: "penalty_months", Type: "integer", Description: "Длина штрафного окна в месяцах (по умолчанию весь срок)"},
+			{Name: "flexible_rate_percent", Type: "number", Description: "Ставка гибкого счета в процентах", Required: true},
+			rateFormatParam(),
+		},
+		Handler: s.depositVsFlexibleHandler,
+	})
+	s.register(&Tool{
+		Name:        "min_income_required",
+		Description: "Выводит минимальный валовый месячный доход для предквалификации: платеж по желаемому кредиту плюс налоги и страховка, деленные на лимит DTI.",
+		Params: []Param{
+			{Name: "principal", Type: "number", Description: "Желаемая сумма кредита", Required: true},
+			{Name: "annual_rate_percent", Type: "number", Description: "Годовая ставка в процентах", Required: true},
+			{Name: "months", Type: "integer", Description: "Срок в месяцах", Required: true},
+			{Name: "dti_limit_percent", Type: "number", Description: "Лимит DTI в процентах (по умолчанию 43)"},
+			{Name: "taxes_insurance", Type: "number", Description: "Ежемесячные налоги и страховка сверх платежа (по умолчанию 0)"},
+			rateFormatParam(),
+		},
+		Handler: s.minIncomeRequiredHandler,
+	})
+	s.register(&Tool{
+		Name:        "windfall_payoff",
+		Description: "Показывает ускорение выплаты кредита от направления разовых поступлений в тело долга: новый месяц выплаты и экономия процентов.",
+		Params: []Param{
+			{Name: "principal", Type: "number", Description: "Сумма кредита", Required: true},
+			{Name: "annual_rate_percent", Type: "number", Description: "Годовая ставка в процентах", Required: true},
+			{Name: "months", Type: "integer", Description: "Срок в месяцах", Required: true},
+			{Name: "windfalls", Type: "array", Description: "Поступления: [{month, amount}, ...], строго упорядочены по месяцу", Required: true},
+			rateFormatParam(),
+		},
+		Handler: s.windfallPayoffHandler,
+	})
+	s.register(&Tool{
+		Name:        "fx_loan_scenarios",
+		Description: "Иллюстрирует валютный риск кредита в иностранной валюте: платеж и полная стоимость в местной валюте под сценариями изменения курса.",
+		Params: []Param{
+			{Name: "principal", Type: "number", Description: "Сумма кредита в иностранной валюте", Required: true},
+			{Name: "annual_rate_percent", Type: "number", Description: "Годовая ставка в процентах", Required: true},
+			{Name: "months", Type: "integer", Description: "Срок в месяцах", Required: true},
+			{Name: "base_exchange_rate", Type: "number", Description: "Базовый курс: единиц местной валюты за единицу валюты кредита", Required: true},
+			{Name: "scenario_changes_percent", Type: "array", Description: "Сценарии изменения курса в процентах, например [-20, -10, 10, 20]; базовый сценарий добавляется автоматически", Required: true},
+			rateFormatParam(),
+		},
+		Handler: s.fxLoanScenariosHandler,
+	})
+	s.register(&Tool{
+		Name:        "optimal_allocation",
+		Description: "Перебирает распределения месячного профицита между досрочным погашением кредита и инвестициями и возвращает вариант с максимальным чистым состоянием на горизонте.",
+		Params: []Param{
+			{Name: "principal", Type: "number", Description: "Сумма кредита", Required: true},
+			{Name: "annual_rate_percent", Type: "number", Description: "Годовая ставка кредита в процентах", Required: true},
+			{Name: "months", Type: "integer", Description: "Срок кредита в месяцах", Required: true},
+			{Name: "monthly_surplus", Type: "number", Description: "Свободные деньги в месяц сверх обязательного платежа", Required: true},
+			{Name: "investment_rate_percent", Type: "number", Description: "Ожидаемая годовая доходность инвестиций в процентах", Required: true},
+			{Name: "horizon_months", Type: "integer", Description: "Горизонт сравнения в месяцах", Required: true},
+			{Name: "step_percent", Type: "integer", Description: "Шаг перебора доли на погашение в процентах (по умолчанию 10)"},
+			rateFormatParam(),
+		},
+		Handler: s.optimalAllocationHandler,
+	})
+	s.register(&Tool{
+		Name:        "zero_percent_true_cost",
+		Description: "Раскрывает реальную стоимость «рассрочки под 0%» с наценкой в цене: наценка трактуется как скрытые проценты, истинная ставка считается через IRR потока платежей.",
+		Params: []Param{
+			{Name: "installment_price", Type: "number", Description: "Цена товара при покупке в рассрочку", Required: true},
+			{Name: "cash_price", Type: "number", Description: "Цена товара при оплате сразу", Required: true},
+			{Name: "months", Type: "integer", Description: "Срок рассрочки в месяцах", Required: true},
+		},
+		Handler: s.zeroPercentHandler,
+	})
+	s.register(&Tool{
+		Name:        "validate_application",
+		Description: "Проверяет кредитную заявку против профиля продукта за один вызов: полевые валидаторы, диапазоны суммы и срока, доля платежа в доходе; возвращает вердикт по каждому правилу.",
+		Params: []Param{
+			{Name: "principal", Type: "number", Description: "Запрошенная сумма кредита", Required: true},
+			{Name: "annual_rate_percent", Type: "number", Description: "Годовая ставка в процентах", Required: true},
+			{Name: "months", Type: "integer", Description: "Запрошенный срок в месяцах", Required: true},
+			{Name: "monthly_income", Type: "number", Description: "Месячный доход заемщика", Required: true},
+			{Name: "product", Type: "object", Description: "Профиль продукта: {min_principal, max_principal, min_months, max_months, max_payment_to_income_percent}", Required: true},
+			rateFormatParam(),
+		},
+		Handler: s.validateApplicationHandler,
+	})
+	s.register(&Tool{
+		Name:        "principal_sensitivity",
+		Description: "Считает платеж и переплату для сетки сумм кредита при фиксированных ставке и сроке; подтверждает линейность переплаты по телу.",
+		Params: []Param{
+			{Name: "annual_rate_percent", Type: "number", Description: "Годовая ставка в процентах", Required: true},
+			{Name: "months", Type: "integer", Description: "Срок в месяцах", Required: true},
+			{Name: "min_principal", Type: "number", Description: "Минимальная сумма сетки", Required: true},
+			{Name: "max_principal", Type: "number", Description: "Максимальная сумма сетки", Required: true},
+			{Name: "step", Type: "number", Description: "Шаг сетки по сумме", Required: true},
+			rateFormatParam(),
+		},
+		Handler: s.principalSensitivityHandler,
+	})
+	s.register(&Tool{
+		Name:        "coast_point",
+		Description: "Находит самый ранний месяц, с которого можно прекратить пополнения и все равно достичь цели к горизонту на одном росте капитала; -1 — цель недостижима.",
+		Params: []Param{
+			{Name: "initial_amount", Type: "number", Description: "Начальная сумма", Required: true},
+			{Name: "monthly_contribution", Type: "number", Description: "Ежемесячное пополнение", Required: true},
+			{Name: "annual_return_percent", Type: "number", Description: "Ожидаемая годовая доходность в процентах", Required: true},
+			{Name: "months", Type: "integer", Description: "Горизонт в месяцах", Required: true},
+			{Name: "target_amount", Type: "number", Description: "Целевая сумма к горизонту", Required: true},
+			rateFormatParam(),
+		},
+		Handler: s.coastPointHandler,
+	})
+	s.register(&Tool{
+		Name:        "split_schedule",
+		Description: "Делит каждый платеж аннуитетного кредита между двумя созаемщиками по согласованным долям, в сумме дающим 100%.",
+		Params: append(loanToolParams(),
+			Param{Name: "first_share_percent", Type: "number", Description: "Доля первого созаемщика в процентах", Required: true},
+			Param{Name: "second_share_percent", Type: "number", Description: "Доля второго созаемщика в процентах; в сумме с первой — ровно 100", Required: true},
+		),
+		Handler: s.splitScheduleHandler,
+	})
+	s.register(&Tool{
+		Name:        "required_nest_egg",
+		Description: "Считает капитал, достаточный для снятий заданной суммы в месяц: текущая стоимость аннуитета снятий, при нулевом горизонте — вечная рента.",
+		Params: []Param{
+			{Name: "monthly_withdrawal", Type: "number", Description: "Желаемое снятие в месяц", Required: true},
+			{Name: "annual_rate_percent", Type: "number", Description: "Годовая доходность капитала в процентах", Required: true},
+			{Name: "drawdown_months", Type: "integer", Description: "Горизонт снятий в месяцах; 0 (по умолчанию) — бессрочные снятия"},
+			rateFormatParam(),
+		},
+		Handler: s.requiredNestEggHandler,
+	})
+	s.register(&Tool{
+		Name:        "dca_sequence",
+		Description: "Моделирует регулярные инвестиции при явной последовательности месячных доходностей — показывает риск последовательности при пополнениях.",
+		Params: []Param{
+			{Name: "initial_amount", Type: "number", Description: "Начальная сумма", Required: true},
+			{Name: "monthly_contribution", Type: "number", Description: "Ежемесячное пополнение (по умолчанию 0)"},
+			{Name: "monthly_returns_percent", Type: "array", Description: "Последовательность месячных доходностей в процентах; срок должен быть кратен ее длине", Required: true},
+			{Name: "months", Type: "integer", Description: "Горизонт в месяцах", Required: true},
+		},
+		Handler: s.dcaSequenceHandler,
+	})
+	s.register(&Tool{
+		Name:        "tax_equivalent_yield",
+		Description: "Переводит необлагаемую доходность в эквивалентную доналоговую: nominal / (1 - tax) — для честного сравнения облагаемого вклада с безналоговым инструментом.",
+		Params: []Param{
+			{Name: "nominal_rate_percent", Type: "number", Description: "Необлагаемая годовая доходность в процентах", Required: true},
+			{Name: "tax_rate_percent", Type: "number", Description: "Налоговая ставка в процентах, от 0 до 100 (не включая)", Required: true},
+			rateFormatParam(),
+		},
+		Handler: s.taxEquivalentYieldHandler,
+	})
+	s.register(&Tool{
+		Name:        "portfolio_summary",
+		Description: "Агрегирует несколько инвестиций: итоговая стоимость, вложено, прирост капитала и денежно-взвешенная годовая доходность портфеля.",
+		Params: []Param{
+			{Name: "investments", Type: "array", Description: "Список инвестиций: объекты {initial_amount, monthly_contribution, annual_return_percent, months}", Required: true},
+			rateFormatParam(),
+		},
+		Handler: s.portfolioSummaryHandler,
+	})
+	s.register(&Tool{
+		Name:        "deferral_cost",
+		Description: "Считает цену отсрочки выборки кредита: комиссию за обязательство на невыбранное тело и удорожание против немедленного старта.",
+		Params: append(loanToolParams(),
+			Param{Name: "deferral_months", Type: "integer", Description: "Месяцы отсрочки выборки", Required: true},
+			Param{Name: "commitment_fee_percent", Type: "number", Description: "Годовая комиссия за обязательство на невыбранную сумму, в процентах", Required: true},
+		),
+		Handler: s.deferralCostHandler,
+	})
+	s.register(&Tool{
+		Name:        "graduated_payment",
+		Description: "Строит график ипотеки с растущим платежом: платеж индексируется раз в год, стартовый подбирается до полного погашения долга к концу срока.",
+		Params: append(loanToolParams(),
+			Param{Name: "payment_growth_percent", Type: "number", Description: "Годовой рост платежа в процентах", Required: true},
+		),
+		Handler: s.graduatedPaymentHandler,
+	})
+	s.register(&Tool{
+		Name:        "capitalization_comparison",
+		Description: "Сравнивает один и тот же вклад с помесячной и годовой капитализацией: разница итогового баланса и эффективной годовой доходности.",
+		Params: []Param{
+			{Name: "initial_amount", Type: "number", Description: "Начальная сумма вклада", Required: true},
+			{Name: "annual_rate_percent", Type: "number", Description: "Номинальная годовая ставка в процентах", Required: true},
+			{Name: "months", Type: "integer", Description: "Срок вклада в месяцах", Required: true},
+			{Name: "monthly_contribution", Type: "number", Description: "Ежемесячное пополнение (по умолчанию 0)"},
+			rateFormatParam(),
+		},
+		Handler: s.capitalizationComparisonHandler,
+	})
+	s.register(&Tool{
+		Name:        "rule_of_78",
+		Description: "Считает возврат «незаработанных» процентов по правилу 78 при досрочном погашении старых потребительских кредитов.",
+		Params: []Param{
+			{Name: "total_interest", Type: "number", Description: "Полная переплата по процентам за весь срок", Required: true},
+			{Name: "total_months", Type: "integer", Description: "Полный срок кредита в месяцах", Required: true},
+			{Name: "months_remaining", Type: "integer", Description: "Оставшиеся месяцы на момент погашения", Required: true},
+		},
+		Handler: s.rule78Handler,
+	})
+	s.register(&Tool{
+		Name:        "rent_vs_buy",
+		Description: "Сравнивает покупку жилья в ипотеку с арендой и инвестированием первоначального взноса и разницы платежей: чистое состояние обоих путей на горизонте.",
+		Params: []Param{
+			{Name: "home_price", Type: "number", Description: "Цена жилья", Required: true},
+			{Name: "down_payment", Type: "number", Description: "Первоначальный взнос (по умолчанию 0)"},
+			{Name: "mortgage_rate_percent", Type: "number", Description: "Годовая ставка ипотеки в процентах", Required: true},
+			{Name: "mortgage_months", Type: "integer", Description: "Срок ипотеки в месяцах", Required: true},
+			{Name: "monthly_owner_costs", Type: "number", Description: "Ежемесячные расходы владельца сверх ипотеки (по умолчанию 0)"},
+			{Name: "monthly_rent", Type: "number", Description: "Арендная плата в месяц", Required: true},
+			{Name: "rent_escalation_percent", Type: "number", Description: "Годовой рост аренды в процентах (по умолчанию 0)"},
+			{Name: "investment_rate_percent", Type: "number", Description: "Годовая доходность инвестиций в процентах", Required: true},
+			{Name: "home_appreciation_percent", Type: "number", Description: "Годовой рост цены жилья в процентах, допускается отрицательный (по умолчанию 0)"},
+			{Name: "horizon_months", Type: "integer", Description: "Горизонт сравнения в месяцах", Required: true},
+			rateFormatParam(),
+		},
+		Handler: s.rentVsBuyHandler,
+	})
+	s.register(&Tool{
+		Name:        "break_even_appreciation",
+		Description: "Подбирает минимальный годовой рост цены жилья, при котором покупка сравнивается с арендой по чистому состоянию на горизонте.",
+		Params: []Param{
+			{Name: "home_price", Type: "number", Description: "Цена жилья", Required: true},
+			{Name: "down_payment", Type: "number", Description: "Первоначальный взнос (по умолчанию 0)"},
+			{Name: "mortgage_rate_percent", Type: "number", Description: "Годовая ставка ипотеки в процентах", Required: true},
+			{Name: "mortgage_months", Type: "integer", Description: "Срок ипотеки в месяцах", Required: true},
+			{Name: "monthly_owner_costs", Type: "number", Description: "Ежемесячные расходы владельца сверх ипотеки (по умолчанию 0)"},
+			{Name: "monthly_rent", Type: "number", Description: "Арендная плата в месяц", Required: true},
+			{Name: "rent_escalation_percent", Type: "number", Description: "Годовой рост аренды в процентах (по умолчанию 0)"},
+			{Name: "investment_rate_percent", Type: "number", Description: "Годовая доходность инвестиций в процентах", Required: true},
+			{Name: "horizon_months", Type: "integer", Description: "Горизонт сравнения в месяцах", Required: true},
+			rateFormatParam(),
+		},
+		Handler: s.breakEvenAppreciationHandler,
+	})
+	s.register(&Tool{
+		Name:        "lump_sum_for_goal",
+		Description: "Считает разовый вклад сегодня, достаточный для достижения целевой суммы за срок; будущая стоимость пополнений вычитается из цели.",
+		Params: []Param{
+			{Name: "target_amount", Type: "number", Description: "Целевая сумма накоплений", Required: true},
+			{Name: "annual_rate_percent", Type: "number", Description: "Годовая доходность в процентах", Required: true},
+			{Name: "months", Type: "integer", Description: "Горизонт в месяцах", Required: true},
+			{Name: "monthly_contribution", Type: "number", Description: "Планируемое ежемесячное пополнение (по умолчанию 0)"},
+			rateFormatParam(),
+		},
+		Handler: s.lumpSumForGoalHandler,
+	})
+	s.register(&Tool{
+		Name:        "interest_budget_solver",
+		Description: "Подбирает срок или ставку аннуитетного кредита под бюджет переплаты: фиксируется один параметр, второй решается бисекцией.",
+		Params: []Param{
+			{Name: "principal", Type: "number", Description: "Сумма кредита", Required: true},
+			{Name: "interest_budget", Type: "number", Description: "Допустимая суммарная переплата по процентам", Required: true},
+			{Name: "annual_rate_percent", Type: "number", Description: "Фиксированная годовая ставка в процентах (подбирается срок)"},
+			{Name: "months", Type: "integer", Description: "Фиксированный срок в месяцах (подбирается ставка)"},
+			rateFormatParam(),
+		},
+		Handler: s.interestBudgetHandler,
+	})
+	s.register(&Tool{
+		Name:        "optimal_refinance_month",
+		Description: "Находит месяц рефинансирования с минимальной полной стоимостью кредита при ожидаемой будущей ставке.",
+		Params: append(loanToolParams(),
+			Param{Name: "new_rate_percent", Type: "number", Description: "Ожидаемая новая годовая ставка в процентах", Required: true},
+			Param{Name: "fee", Type: "number", Description: "Разовая комиссия за рефинансирование (по умолчанию 0)"},
+		),
+		Handler: s.optimalRefinanceHandler,
+	})
+	s.register(&Tool{
+		Name:        "offset_mortgage",
+		Description: "Моделирует ипотеку с зачетным счетом: проценты начисляются на долг за вычетом накоплений.",
+		Params: append(loanToolParams(),
+			Param{Name: "offset_balance", Type: "number", Description: "Баланс зачетного счета на старте", Required: true},
+			Param{Name: "monthly_savings", Type: "number", Description: "Ежемесячное пополнение зачетного счета (по умолчанию 0)"},
+		),
+		Handler: s.offsetMortgageHandler,
+	})
+	s.register(&Tool{
+		Name:        "loan_duration",
+		Description: "Возвращает дюрацию Маколея кредита — средневзвешенное время платежей по приведенной стоимости.",
+		Params:      loanToolParams(),
+		Handler:     s.loanDurationHandler,
+	})
+	s.register(&Tool{
+		Name:        "required_rate",
+		Description: "Подбирает минимальную годовую доходность, при которой накопления достигают целевой суммы.",
+		Params: []Param{
+			{Name: "initial_amount", Type: "number", Description: "Начальная сумма", Required: true},
+			{Name: "monthly_contribution", Type: "number", Description: "Ежемесячное пополнение", Required: true},
+			{Name: "target_amount", Type: "number", Description: "Целевая сумма накоплений", Required: true},
+			{Name: "months", Type: "integer", Description: "Горизонт в месяцах", Required: true},
+			{Name: "contribution_at_beginning", Type: "boolean", Description: "Пополнение в начале месяца, до начисления дохода"},
+		},
+		Handler: s.requiredRateHandler,
+	})
+	s.register(&Tool{
+		Name:        "income_replacement",
+		Description: "Подбирает ежемесячное накопление, дающее капитал для снятий заданной суммы: бессрочно (вечная рента) или на горизонте drawdown_months.",
+		Params: []Param{
+			{Name: "initial_amount", Type: "number", Description: "Уже накопленная сумма (по умолчанию 0)"},
+			{Name: "annual_rate_percent", Type: "number", Description: "Годовая доходность в процентах", Required: true},
+			{Name: "accumulation_months", Type: "integer", Description: "Срок накопления в месяцах", Required: true},
+			{Name: "monthly_withdrawal", Type: "number", Description: "Желаемое ежемесячное снятие", Required: true},
+			{Name: "drawdown_months", Type: "integer", Description: "Горизонт снятий в месяцах; 0 (по умолчанию) — бессрочно"},
+			rateFormatParam(),
+		},
+		Handler: s.incomeReplacementHandler,
+	})
+	s.register(&Tool{
+		Name:        "grace_loan",
+		Description: "Строит график кредита с льготным периодом и считает реальную стоимость: номинальную и сводную ставки.",
+		Params: append(loanToolParams(),
+			Param{Name: "grace_months", Type: "integer", Description: "Длительность льготного периода в месяцах", Required: true},
+			Param{Name: "grace_mode", Type: "string", Description: "Режим льготы: interest_only (по умолчанию) или capitalize", Enum: []string{calculations.GraceInterestOnly, calculations.GraceCapitalize}},
+		),
+		Handler: s.graceLoanHandler,
+	})
+	s.register(&Tool{
+		Name:        "io_then_amortizing",
+		Description: "Строит единый график кредита «только проценты, затем полная амортизация» со скачком платежа на переходе и переплатой против обычного аннуитета.",
+		Params: []Param{
+			{Name: "principal", Type: "number", Description: "Сумма кредита", Required: true},
+			{Name: "annual_rate_percent", Type: "number", Description: "Годовая ставка в процентах", Required: true},
+			{Name: "interest_only_months", Type: "integer", Description: "Длительность фазы «только проценты» в месяцах", Required: true},
+			{Name: "amortizing_months", Type: "integer", Description: "Длительность фазы амортизации в месяцах", Required: true},
+			rateFormatParam(),
+		},
+		Handler: s.ioThenAmortizingHandler,
+	})
+	s.register(&Tool{
+		Name:        "realized_rate",
+		Description: "Считает фактическую ставку кредита после частичных досрочных погашений: IRR реальных потоков и сводную ставку против номинала.",
+		Params: append(loanToolParams(),
+			Param{Name: "prepayments", Type: "array", Description: "Досрочные погашения: объекты {month, amount}"},
+		),
+		Handler: s.realizedRateHandler,
+	})
+	s.register(&Tool{
+		Name:        "prepayment_timing",
+		Description: "Сравнивает экономию от одного и того же досрочного платежа в разные месяцы; варианты отсортированы по экономии процентов.",
+		Params: append(loanToolParams(),
+			Param{Name: "extra_amount", Type: "number", Description: "Сумма разового досрочного платежа", Required: true},
+			Param{Name: "candidate_months", Type: "array", Description: "Месяцы-кандидаты для внесения платежа", Required: true},
+		),
+		Handler: s.prepaymentTimingHandler,
+	})
+	s.register(&Tool{
+		Name:        "payment_holiday",
+		Description: "Считает цену одного пропущенного платежа: проценты капитализируются, график пересчитывается или продлевается.",
+		Params: append(loanToolParams(),
+			Param{Name: "holiday_month", Type: "integer", Description: "Месяц без платежа (от 1 до срока кредита)", Required: true},
+			Param{Name: "mode", Type: "string", Description: "Восстановление графика: keep_term (выше платеж, по умолчанию) или extend_term (дольше срок)", Enum: []string{calculations.HolidayKeepTerm, calculations.HolidayExtendTerm}},
+		),
+		Handler: s.paymentHolidayHandler,
+	})
+	s.register(&Tool{
+		Name:        "subsidized_rate",
+		Description: "Считает эффективную ставку кредита с учетом кэшбэка при выдаче и ежемесячной субсидии (IRR по чистым потокам).",
+		Params: append(loanToolParams(),
+			Param{Name: "cashback", Type: "number", Description: "Разовый кэшбэк при выдаче (по умолчанию 0)"},
+			Param{Name: "monthly_subsidy", Type: "number", Description: "Ежемесячная субсидия платежа (по умолчанию 0)"},
+		),
+		Handler: s.subsidizedRateHandler,
+	})
+	s.register(&Tool{
+		Name:        "down_payment_plan",
+		Description: "Подбирает ежемесячное накопление на первоначальный взнос и выводит максимальную цену жилья при целевом LTV.",
+		Params: []Param{
+			{Name: "target_amount", Type: "number", Description: "Целевой первоначальный взнос", Required: true},
+			{Name: "current_savings", Type: "number", Description: "Текущие накопления (по умолчанию 0)"},
+			{Name: "annual_rate_percent", Type: "number", Description: "Ожидаемая ставка вклада в процентах", Required: true},
+			{Name: "months", Type: "integer", Description: "Горизонт накопления в месяцах", Required: true},
+			{Name: "target_ltv_percent", Type: "number", Description: "Целевая доля кредита в стоимости жилья (по умолчанию 80)"},
+			rateFormatParam(),
+		},
+		Handler: s.downPaymentPlanHandler,
+	})
+	s.register(&Tool{
+		Name:        "blended_apr",
+		Description: "Считает сводную ставку пакета «срочный кредит + возобновляемая линия», взвешенную по среднему задолженному капиталу.",
+		Params: append(loanToolParams(),
+			Param{Name: "revolving_limit", Type: "number", Description: "Лимит возобновляемой линии", Required: true},
+			Param{Name: "revolving_rate_percent", Type: "number", Description: "Годовая ставка линии в процентах", Required: true},
+			Param{Name: "utilization_percent", Type: "number", Description: "Средняя утилизация линии в процентах (по умолчанию 100)"},
+		),
+		Handler: s.blendedAPRHandler,
+	})
+	s.register(&Tool{
+		Name:        "rate_buydown",
+		Description: "Оценивает выкуп ставки за разовую комиссию: ежемесячная экономия, месяц окупаемости и чистая выгода за срок.",
+		Params: append(loanToolParams(),
+			Param{Name: "points_cost", Type: "number", Description: "Разовая стоимость выкупа ставки", Required: true},
+			Param{Name: "reduced_rate_percent", Type: "number", Description: "Сниженная годовая ставка в процентах", Required: true},
+		),
+		Handler: s.rateBuydownHandler,
+	})
+	s.register(&Tool{
+		Name:        "investment_irr",
+		Description: "Считает денежно-взвешенную доходность (IRR) портфеля с датированными пополнениями и снятиями.",
+		Params: []Param{
+			{Name: "initial_amount", Type: "number", Description: "Начальный взнос в месяце 0", Required: true},
+			{Name: "flows", Type: "array", Description: "Движения средств: объекты {month, amount}; amount > 0 — пополнение, < 0 — снятие"},
+			{Name: "final_balance", Type: "number", Description: "Итоговый баланс портфеля в последнем месяце", Required: true},
+			{Name: "months", Type: "integer", Description: "Горизонт в месяцах", Required: true},
+		},
+		Handler: s.investmentIRRHandler,
+	})
+	s.register(&Tool{
+		Name:        "ltv_milestone",
+		Description: "Находит месяц, в котором отношение долга к стоимости актива впервые опускается до целевого LTV (снятие страховки).",
+		Params: append(loanToolParams(),
+			Param{Name: "asset_price", Type: "number", Description: "Текущая стоимость актива (жилья)", Required: true},
+			Param{Name: "annual_appreciation_percent", Type: "number", Description: "Годовой рост стоимости актива в процентах (по умолчанию 0)"},
+			Param{Name: "target_ltv_percent", Type: "number", Description: "Целевой LTV в процентах (например, 70)", Required: true},
+		),
+		Handler: s.ltvMilestoneHandler,
+	})
+	s.register(&Tool{
+		Name:        "max_home_price",
+		Description: "Выводит максимальную цену жилья из дохода: лимит DTI дает бюджет платежа, он обращается в тело кредита, сверху добавляется первоначальный взнос.",
+		Params: []Param{
+			{Name: "monthly_income", Type: "number", Description: "Ежемесячный валовый доход", Required: true},
+			{Name: "dti_limit_percent", Type: "number", Description: "Лимит DTI в процентах (по умолчанию 43)"},
+			{Name: "annual_rate_percent", Type: "number", Description: "Годовая ставка ипотеки в процентах", Required: true},
+			{Name: "months", Type: "integer", Description: "Срок ипотеки в месяцах", Required: true},
+			{Name: "down_payment", Type: "number", Description: "Первоначальный взнос (по умолчанию 0)"},
+			{Name: "taxes_insurance", Type: "number", Description: "Ежемесячные налоги и страховка, вычитаемые из бюджета платежа (по умолчанию 0)"},
+			rateFormatParam(),
+		},
+		Handler: s.maxHomePriceHandler,
+	})
+	s.register(&Tool{
+		Name:        "dti_calculator",
+		Description: "Считает долговую нагрузку (DTI): долю дохода на обслуживание долгов, сравнение с порогом и запас по платежам.",
+		Params: []Param{
+			{Name: "monthly_income", Type: "number", Description: "Валовой ежемесячный доход домохозяйства", Required: true},
+			{Name: "debt_payments", Type: "array", Description: "Ежемесячные платежи по долгам (например, monthly_payment из кредитных инструментов)", Required: true},
+			{Name: "threshold_percent", Type: "number", Description: "Порог нагрузки в процентах (по умолчанию 43)"},
+		},
+		Handler: s.dtiHandler,
+	})
+	s.register(&Tool{
+		Name:        "rate_convert",
+		Description: "Пересчитывает номинальную годовую ставку между частотами начисления с сохранением эффективной доходности.",
+		Params: []Param{
+			{Name: "rate", Type: "number", Description: "Годовая номинальная ставка в процентах", Required: true},
+			{Name: "from_frequency", Type: "string", Description: "Текущая частота начисления", Required: true, Enum: frequencies},
+			{Name: "to_frequency", Type: "string", Description: "Целевая частота начисления", Required: true, Enum: frequencies},
+		},
+		Handler: s.rateConvertHandler,
+	})
+}